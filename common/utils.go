@@ -2,8 +2,11 @@ package common
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // FormatBytes 格式化字节大小为可读的字符串
@@ -69,4 +72,70 @@ func FormatFileNameForDisplay(fileName string, maxDisplayLength int) string {
 		return baseName[:availableBaseLen] + "..." + ext
 	}
 	return fileName
-}
\ No newline at end of file
+}
+
+// FileURIToPath 将 file:// URI 转换为本地文件路径。
+// 路径中的 %XX 会被解码，但 "+" 保持原样（不会被当作空格）；
+// Windows 盘符路径 (file:///C:/path) 会去掉开头的斜杠。
+func FileURIToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("解析文件 URI 失败: %w", err)
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("不是 file:// URI: %s", uri)
+	}
+
+	p := u.Path
+	// 未编码的 "?" 和 "#" 会被解析为查询和片段，这里还原到路径中
+	if u.RawQuery != "" || u.ForceQuery {
+		p += "?" + u.RawQuery
+	}
+	if u.Fragment != "" {
+		p += "#" + u.Fragment
+	}
+
+	if u.Host != "" && u.Host != "localhost" {
+		// 网络共享路径 file://server/share -> //server/share
+		return "//" + u.Host + p, nil
+	}
+	if len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:]
+	}
+	return p, nil
+}
+
+// SafeFileExt 返回对象键最后一段的扩展名，用于生成临时文件名。
+// 如果扩展名中包含不能出现在文件名模式中的字符，则返回空字符串。
+func SafeFileExt(key string) string {
+	ext := path.Ext(path.Base(key))
+	if strings.ContainsAny(ext, "*/\\:?\"<>|") {
+		return ""
+	}
+	return ext
+}
+
+// DefaultTimestampTemplate 是上传时追加时间戳的默认命名模板，例如 report_20240115-1030.csv
+const DefaultTimestampTemplate = "{name}_{YYYY}{MM}{DD}-{HH}{mm}{ext}"
+
+// ApplyTimestampTemplate 按模板生成带时间戳的文件名。
+// 支持的占位符：{name} 不含扩展名的文件名、{ext} 扩展名（含点）、
+// {YYYY} 年、{MM} 月、{DD} 日、{HH} 时、{mm} 分、{ss} 秒。
+// 模板中没有 {name} 时会被视为无效，返回原文件名。
+func ApplyTimestampTemplate(fileName, template string, t time.Time) string {
+	if !strings.Contains(template, "{name}") {
+		return fileName
+	}
+	ext := filepath.Ext(fileName)
+	replacer := strings.NewReplacer(
+		"{name}", strings.TrimSuffix(fileName, ext),
+		"{ext}", ext,
+		"{YYYY}", t.Format("2006"),
+		"{MM}", t.Format("01"),
+		"{DD}", t.Format("02"),
+		"{HH}", t.Format("15"),
+		"{mm}", t.Format("04"),
+		"{ss}", t.Format("05"),
+	)
+	return replacer.Replace(template)
+}
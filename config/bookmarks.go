@@ -0,0 +1,80 @@
+package config
+
+import "fmt"
+
+// Bookmark 是用户收藏的一个存储桶位置，按服务分别保存
+type Bookmark struct {
+	ID           int64
+	ServiceAlias string
+	Bucket       string
+	Prefix       string // 为空表示存储桶根目录
+	Label        string
+}
+
+// createBookmarksTable 创建保存书签的表，同一服务下的同一位置只保存一次
+func createBookmarksTable() error {
+	_, err := db.Exec(`
+	CREATE TABLE IF NOT EXISTS bookmarks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		serviceAlias TEXT NOT NULL,
+		bucket TEXT NOT NULL,
+		prefix TEXT NOT NULL,
+		label TEXT NOT NULL,
+		UNIQUE(serviceAlias, bucket, prefix)
+	);`)
+	if err != nil {
+		return fmt.Errorf("创建 bookmarks 表失败: %w", err)
+	}
+	return nil
+}
+
+// LoadBookmarks 按添加顺序返回服务的所有书签
+func LoadBookmarks(serviceAlias string) ([]Bookmark, error) {
+	rows, err := db.Query("SELECT id, serviceAlias, bucket, prefix, label FROM bookmarks WHERE serviceAlias = ? ORDER BY id", serviceAlias)
+	if err != nil {
+		return nil, fmt.Errorf("查询书签失败: %w", err)
+	}
+	defer rows.Close()
+
+	var bookmarks []Bookmark
+	for rows.Next() {
+		var b Bookmark
+		if err := rows.Scan(&b.ID, &b.ServiceAlias, &b.Bucket, &b.Prefix, &b.Label); err != nil {
+			return nil, fmt.Errorf("扫描书签数据失败: %w", err)
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("遍历书签结果集失败: %w", err)
+	}
+	return bookmarks, nil
+}
+
+// AddBookmark 添加一个书签，该位置已有书签时只更新名称
+func AddBookmark(b Bookmark) error {
+	_, err := db.Exec(`INSERT INTO bookmarks (serviceAlias, bucket, prefix, label) VALUES (?, ?, ?, ?)
+		ON CONFLICT(serviceAlias, bucket, prefix) DO UPDATE SET label = excluded.label`,
+		b.ServiceAlias, b.Bucket, b.Prefix, b.Label)
+	if err != nil {
+		return fmt.Errorf("添加书签失败: %w", err)
+	}
+	return nil
+}
+
+// RenameBookmark 修改书签的名称
+func RenameBookmark(id int64, label string) error {
+	_, err := db.Exec("UPDATE bookmarks SET label = ? WHERE id = ?", label, id)
+	if err != nil {
+		return fmt.Errorf("重命名书签失败: %w", err)
+	}
+	return nil
+}
+
+// DeleteBookmark 删除一个书签
+func DeleteBookmark(id int64) error {
+	_, err := db.Exec("DELETE FROM bookmarks WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("删除书签失败: %w", err)
+	}
+	return nil
+}
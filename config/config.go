@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	_ "github.com/mattn/go-sqlite3" // SQLite 驱动
@@ -21,8 +22,30 @@ type S3ServiceConfig struct {
 	SecretKey string `json:"secretKey"`           // 秘密访问密钥
 	ViewMode  string `json:"view_mode,omitempty"` // 视图模式 ("list" or "grid")
 	Proxy     string `json:"proxy,omitempty"`     // 代理地址
+
+	ConsoleURL string `json:"consoleURL,omitempty"` // Web 控制台地址，为空时根据 Endpoint 推断
+	PageSize   int    `json:"pageSize,omitempty"`   // 每页显示数量，0 表示不分页
+	Anonymous  bool   `json:"anonymous,omitempty"`  // 匿名访问，不使用凭证签名请求，仅能访问公开资源
+
+	CaseInsensitiveKeys bool `json:"caseInsensitiveKeys,omitempty"` // 检测对象键冲突时不区分大小写，用于不区分大小写的网关
+	PathStyle           bool `json:"pathStyle,omitempty"`           // 使用路径风格访问（endpoint/bucket/key），否则使用虚拟主机风格（bucket.endpoint/key）
+	FoldersFirst        bool `json:"foldersFirst,omitempty"`        // 排序时文件夹是否排在文件前面
+
+	SortField      string `json:"sortField,omitempty"`      // 对象列表的排序字段（name、size 或 modified），为空时按名称排序
+	SortDescending bool   `json:"sortDescending,omitempty"` // 对象列表是否降序排序
+
+	Provider             string `json:"provider,omitempty"`             // 服务类型，如 "aws"、"minio"，为空时视为自定义
+	Region               string `json:"region,omitempty"`               // 签名使用的区域，为空时使用 us-east-1
+	ChecksumWhenRequired bool   `json:"checksumWhenRequired,omitempty"` // 只在必需时计算校验和，用于不支持新版校验和请求头的服务
+
+	SessionToken string `json:"sessionToken,omitempty"` // 临时凭证（STS）的会话令牌，为空时使用长期凭证，与 SecretKey 一样加密保存
+
+	UploadStorageClass string `json:"uploadStorageClass,omitempty"` // 上传时使用的存储类别，为空时使用服务端默认的存储类别
 }
 
+// DefaultPageSize 是未保存分页设置的服务使用的每页显示数量
+const DefaultPageSize = 100
+
 // ConfigStore 存储所有 S3 服务的配置列表
 type ConfigStore struct {
 	Services []S3ServiceConfig `json:"services"` // S3 服务配置列表
@@ -30,18 +53,34 @@ type ConfigStore struct {
 
 var db *sql.DB
 
+// AppConfigDir 返回应用的配置目录，数据库、本机密钥和缩略图缓存都保存在该目录下
+func AppConfigDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("获取用户配置目录失败: %w", err)
+	}
+	return filepath.Join(configDir, "s3-explorer"), nil
+}
+
 // initDB 初始化 SQLite 数据库连接和表
 func InitDB() error {
-	configDir, err := os.UserConfigDir()
+	appConfigDir, err := AppConfigDir()
 	if err != nil {
-		return fmt.Errorf("获取用户配置目录失败: %w", err)
+		return err
 	}
-	appConfigDir := filepath.Join(configDir, "s3-explorer")
 	if err := os.MkdirAll(appConfigDir, 0755); err != nil {
 		return fmt.Errorf("创建应用配置目录失败: %w", err)
 	}
 	dbPath := filepath.Join(appConfigDir, "s3-explorer.db")
 
+	masterSecret, err := loadMasterSecret(appConfigDir)
+	if err != nil {
+		return err
+	}
+	if secretAEAD, err = newSecretAEAD(masterSecret); err != nil {
+		return err
+	}
+
 	// 在连接字符串中添加_busy_timeout以防止“数据库已锁定”错误
 	db, err = sql.Open("sqlite3", dbPath+"?_busy_timeout=5000")
 	if err != nil {
@@ -56,49 +95,34 @@ func InitDB() error {
 		accessKey TEXT NOT NULL,
 		secretKey TEXT NOT NULL,
 		viewMode TEXT,
-		proxy TEXT
+		proxy TEXT,
+		consoleURL TEXT,
+		pageSize INTEGER,
+		anonymous INTEGER NOT NULL DEFAULT 0,
+		caseInsensitiveKeys INTEGER NOT NULL DEFAULT 0,
+		pathStyle INTEGER NOT NULL DEFAULT 1,
+		foldersFirst INTEGER NOT NULL DEFAULT 1,
+		provider TEXT,
+		region TEXT,
+		checksumWhenRequired INTEGER NOT NULL DEFAULT 0,
+		sortField TEXT,
+		sortDescending INTEGER NOT NULL DEFAULT 0,
+		sessionToken TEXT,
+		uploadStorageClass TEXT,
+		sortOrder INTEGER
 	);`
 	_, err = db.Exec(createTableSQL)
 	if err != nil {
 		return fmt.Errorf("创建 services 表失败: %w", err)
 	}
 
-	// 检查并添加 proxy 列（用于旧版本升级）
-	rows, err := db.Query("PRAGMA table_info(services)")
-	if err != nil {
-		return fmt.Errorf("查询表结构失败: %w", err)
-	}
-	defer rows.Close()
-
-	var proxyColumnExists bool
-	for rows.Next() {
-		var cid int
-		var name string
-		var typeName string
-		var notnull bool
-		var dfltValue sql.NullString
-		var pk int
-		if err := rows.Scan(&cid, &name, &typeName, &notnull, &dfltValue, &pk); err != nil {
-			return fmt.Errorf("扫描表结构行失败: %w", err)
-		}
-		if name == "proxy" {
-			proxyColumnExists = true
-			break
-		}
-	}
-	// 迭代后显式关闭行
-	rows.Close()
-	if err := rows.Err(); err != nil { // Check for errors during iteration
-		return fmt.Errorf("遍历表结构行失败: %w", err)
+	// 检查并添加旧版本中缺少的列（用于旧版本升级）
+	if err := ensureColumns("services", serviceColumnMigrations); err != nil {
+		return err
 	}
 
-	if !proxyColumnExists {
-		log.Println("数据库中缺少 proxy 列，正在添加...")
-		alterTableSQL := `ALTER TABLE services ADD COLUMN proxy TEXT;`
-		_, err := db.Exec(alterTableSQL)
-		if err != nil {
-			return fmt.Errorf("向 services 表添加 proxy 列失败: %w", err)
-		}
+	if err := createBookmarksTable(); err != nil {
+		return err
 	}
 
 	// 检查是否需要从旧的 JSON 文件迁移数据
@@ -127,6 +151,81 @@ func InitDB() error {
 		}
 	}
 
+	// 旧版本和 JSON 迁移的服务没有排列顺序，按添加顺序（rowid）排列
+	if _, err := db.Exec("UPDATE services SET sortOrder = rowid WHERE sortOrder IS NULL"); err != nil {
+		return fmt.Errorf("初始化服务排列顺序失败: %w", err)
+	}
+
+	// 旧版本和 JSON 迁移的数据中 SecretKey 是明文，统一加密
+	if err := encryptPlaintextSecrets(); err != nil {
+		return fmt.Errorf("加密已保存的 SecretKey 失败: %w", err)
+	}
+
+	return nil
+}
+
+// columnMigration 描述一个在后续版本中新增的列
+type columnMigration struct {
+	name       string // 列名
+	definition string // 列类型及约束
+}
+
+// serviceColumnMigrations 是 services 表在后续版本中新增的列
+var serviceColumnMigrations = []columnMigration{
+	{"proxy", "TEXT"},
+	{"consoleURL", "TEXT"},
+	{"pageSize", "INTEGER"},
+	{"anonymous", "INTEGER NOT NULL DEFAULT 0"},
+	{"caseInsensitiveKeys", "INTEGER NOT NULL DEFAULT 0"},
+	{"pathStyle", "INTEGER NOT NULL DEFAULT 1"}, // 旧版本总是使用路径风格访问，升级后保持不变
+	{"foldersFirst", "INTEGER NOT NULL DEFAULT 1"},
+	{"provider", "TEXT"},
+	{"region", "TEXT"},
+	{"checksumWhenRequired", "INTEGER NOT NULL DEFAULT 0"},
+	{"sortField", "TEXT"},
+	{"sortDescending", "INTEGER NOT NULL DEFAULT 0"},
+	{"sessionToken", "TEXT"},
+	{"uploadStorageClass", "TEXT"},
+	{"sortOrder", "INTEGER"},
+}
+
+// ensureColumns 检查表中是否存在指定的列，缺少时通过 ALTER TABLE 添加
+func ensureColumns(table string, columns []columnMigration) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("查询表结构失败: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid int
+		var name string
+		var typeName string
+		var notnull bool
+		var dfltValue sql.NullString
+		var pk int
+		if err := rows.Scan(&cid, &name, &typeName, &notnull, &dfltValue, &pk); err != nil {
+			return fmt.Errorf("扫描表结构行失败: %w", err)
+		}
+		existing[name] = true
+	}
+	// 迭代后显式关闭行
+	rows.Close()
+	if err := rows.Err(); err != nil { // Check for errors during iteration
+		return fmt.Errorf("遍历表结构行失败: %w", err)
+	}
+
+	for _, column := range columns {
+		if existing[column.name] {
+			continue
+		}
+		log.Printf("数据库中缺少 %s 列，正在添加...", column.name)
+		alterTableSQL := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, column.name, column.definition)
+		if _, err := db.Exec(alterTableSQL); err != nil {
+			return fmt.Errorf("向 %s 表添加 %s 列失败: %w", table, column.name, err)
+		}
+	}
 	return nil
 }
 
@@ -172,7 +271,7 @@ func migrateFromJSON(filePath string) error {
 
 // LoadConfig 从数据库加载 S3 服务配置
 func LoadConfig() (*ConfigStore, error) {
-	rows, err := db.Query("SELECT alias, endpoint, accessKey, secretKey, viewMode, proxy FROM services")
+	rows, err := db.Query("SELECT alias, endpoint, accessKey, secretKey, viewMode, proxy, consoleURL, pageSize, anonymous, caseInsensitiveKeys, pathStyle, foldersFirst, provider, region, checksumWhenRequired, sortField, sortDescending, sessionToken, uploadStorageClass FROM services ORDER BY sortOrder, rowid")
 	if err != nil {
 		return nil, fmt.Errorf("查询服务失败: %w", err)
 	}
@@ -182,12 +281,38 @@ func LoadConfig() (*ConfigStore, error) {
 	for rows.Next() {
 		var svc S3ServiceConfig
 		var proxy sql.NullString // 使用 sql.NullString 来处理可能为 NULL 的 proxy 列
-		if err := rows.Scan(&svc.Alias, &svc.Endpoint, &svc.AccessKey, &svc.SecretKey, &svc.ViewMode, &proxy); err != nil {
+		var consoleURL sql.NullString
+		var pageSize sql.NullInt64 // 旧版本的服务没有保存分页设置
+		var provider, region, sortField, uploadStorageClass sql.NullString
+		var sessionToken sql.NullString // 旧版本的服务没有会话令牌
+		if err := rows.Scan(&svc.Alias, &svc.Endpoint, &svc.AccessKey, &svc.SecretKey, &svc.ViewMode, &proxy, &consoleURL, &pageSize, &svc.Anonymous, &svc.CaseInsensitiveKeys, &svc.PathStyle, &svc.FoldersFirst, &provider, &region, &svc.ChecksumWhenRequired, &sortField, &svc.SortDescending, &sessionToken, &uploadStorageClass); err != nil {
 			return nil, fmt.Errorf("扫描服务数据失败: %w", err)
 		}
 		if proxy.Valid {
 			svc.Proxy = proxy.String
 		}
+		if consoleURL.Valid {
+			svc.ConsoleURL = consoleURL.String
+		}
+		// 本机密钥丢失或更换时无法解密，清空 SecretKey 让用户重新填写，不影响其他服务
+		if svc.SecretKey, err = decryptSecret(secretAEAD, svc.SecretKey); err != nil {
+			log.Printf("服务 '%s' 的 SecretKey 无法解密: %v", svc.Alias, err)
+			svc.SecretKey = ""
+		}
+		if sessionToken.Valid {
+			if svc.SessionToken, err = decryptSecret(secretAEAD, sessionToken.String); err != nil {
+				log.Printf("服务 '%s' 的会话令牌无法解密: %v", svc.Alias, err)
+				svc.SessionToken = ""
+			}
+		}
+		svc.Provider = provider.String
+		svc.Region = region.String
+		svc.SortField = sortField.String
+		svc.UploadStorageClass = uploadStorageClass.String
+		svc.PageSize = DefaultPageSize
+		if pageSize.Valid {
+			svc.PageSize = int(pageSize.Int64)
+		}
 		services = append(services, svc)
 	}
 
@@ -198,10 +323,18 @@ func LoadConfig() (*ConfigStore, error) {
 	return &ConfigStore{Services: services}, nil
 }
 
-// AddService 添加一个新的 S3 服务配置到数据库
+// AddService 添加一个新的 S3 服务配置到数据库，新服务排在最后
 func (cs *ConfigStore) AddService(service S3ServiceConfig) error {
-	_, err := db.Exec("INSERT INTO services (alias, endpoint, accessKey, secretKey, viewMode, proxy) VALUES (?, ?, ?, ?, ?, ?)",
-		service.Alias, service.Endpoint, service.AccessKey, service.SecretKey, service.ViewMode, service.Proxy)
+	secretKey, err := encryptSecret(secretAEAD, service.SecretKey)
+	if err != nil {
+		return fmt.Errorf("添加服务失败: %w", err)
+	}
+	sessionToken, err := encryptSecret(secretAEAD, service.SessionToken)
+	if err != nil {
+		return fmt.Errorf("添加服务失败: %w", err)
+	}
+	_, err = db.Exec("INSERT INTO services (alias, endpoint, accessKey, secretKey, viewMode, proxy, consoleURL, pageSize, anonymous, caseInsensitiveKeys, pathStyle, foldersFirst, provider, region, checksumWhenRequired, sortField, sortDescending, sessionToken, uploadStorageClass, sortOrder) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, (SELECT COALESCE(MAX(sortOrder), 0) + 1 FROM services))",
+		service.Alias, service.Endpoint, service.AccessKey, secretKey, service.ViewMode, service.Proxy, service.ConsoleURL, service.PageSize, service.Anonymous, service.CaseInsensitiveKeys, service.PathStyle, service.FoldersFirst, service.Provider, service.Region, service.ChecksumWhenRequired, service.SortField, service.SortDescending, sessionToken, service.UploadStorageClass)
 	if err != nil {
 		return fmt.Errorf("添加服务失败: %w", err)
 	}
@@ -210,11 +343,69 @@ func (cs *ConfigStore) AddService(service S3ServiceConfig) error {
 
 // UpdateService 更新一个 S3 服务配置到数据库
 func (cs *ConfigStore) UpdateService(oldAlias string, newService S3ServiceConfig) error {
-	_, err := db.Exec("UPDATE services SET alias = ?, endpoint = ?, accessKey = ?, secretKey = ?, viewMode = ?, proxy = ? WHERE alias = ?",
-		newService.Alias, newService.Endpoint, newService.AccessKey, newService.SecretKey, newService.ViewMode, newService.Proxy, oldAlias)
+	secretKey, err := encryptSecret(secretAEAD, newService.SecretKey)
+	if err != nil {
+		return fmt.Errorf("更新服务失败: %w", err)
+	}
+	sessionToken, err := encryptSecret(secretAEAD, newService.SessionToken)
 	if err != nil {
 		return fmt.Errorf("更新服务失败: %w", err)
 	}
+	_, err = db.Exec("UPDATE services SET alias = ?, endpoint = ?, accessKey = ?, secretKey = ?, viewMode = ?, proxy = ?, consoleURL = ?, pageSize = ?, anonymous = ?, caseInsensitiveKeys = ?, pathStyle = ?, foldersFirst = ?, provider = ?, region = ?, checksumWhenRequired = ?, sortField = ?, sortDescending = ?, sessionToken = ?, uploadStorageClass = ? WHERE alias = ?",
+		newService.Alias, newService.Endpoint, newService.AccessKey, secretKey, newService.ViewMode, newService.Proxy, newService.ConsoleURL, newService.PageSize, newService.Anonymous, newService.CaseInsensitiveKeys, newService.PathStyle, newService.FoldersFirst, newService.Provider, newService.Region, newService.ChecksumWhenRequired, newService.SortField, newService.SortDescending, sessionToken, newService.UploadStorageClass, oldAlias)
+	if err != nil {
+		return fmt.Errorf("更新服务失败: %w", err)
+	}
+	// 服务改名后书签跟随新的别名
+	if newService.Alias != oldAlias {
+		if _, err := db.Exec("UPDATE bookmarks SET serviceAlias = ? WHERE serviceAlias = ?", newService.Alias, oldAlias); err != nil {
+			return fmt.Errorf("更新服务书签失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// moveService 返回将别名为 alias 的服务向前（delta < 0）或向后（delta > 0）移动 |delta| 位后的服务列表。
+// 服务不存在或移动后超出范围时返回 false。
+func moveService(services []S3ServiceConfig, alias string, delta int) ([]S3ServiceConfig, bool) {
+	from := -1
+	for i, svc := range services {
+		if svc.Alias == alias {
+			from = i
+			break
+		}
+	}
+	to := from + delta
+	if from < 0 || delta == 0 || to < 0 || to >= len(services) {
+		return nil, false
+	}
+	moved := slices.Delete(slices.Clone(services), from, from+1)
+	return slices.Insert(moved, to, services[from]), true
+}
+
+// ReorderService 将服务在列表中向前（delta < 0）或向后（delta > 0）移动，并按新的顺序重写所有服务的 sortOrder。
+// 已在最前或最后时不做任何操作。
+func (cs *ConfigStore) ReorderService(alias string, delta int) error {
+	moved, ok := moveService(cs.Services, alias, delta)
+	if !ok {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("调整服务顺序失败: %w", err)
+	}
+	defer tx.Rollback() // 发生错误时回滚
+
+	for i, svc := range moved {
+		if _, err := tx.Exec("UPDATE services SET sortOrder = ? WHERE alias = ?", i+1, svc.Alias); err != nil {
+			return fmt.Errorf("调整服务顺序失败: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("调整服务顺序失败: %w", err)
+	}
+	cs.Services = moved
 	return nil
 }
 
@@ -224,5 +415,8 @@ func (cs *ConfigStore) DeleteService(alias string) error {
 	if err != nil {
 		return fmt.Errorf("删除服务失败: %w", err)
 	}
+	if _, err := db.Exec("DELETE FROM bookmarks WHERE serviceAlias = ?", alias); err != nil {
+		return fmt.Errorf("删除服务书签失败: %w", err)
+	}
 	return nil
 }
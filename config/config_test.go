@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestMoveService(t *testing.T) {
+	services := []S3ServiceConfig{{Alias: "a"}, {Alias: "b"}, {Alias: "c"}}
+	aliases := func(list []S3ServiceConfig) string {
+		s := ""
+		for _, svc := range list {
+			s += svc.Alias
+		}
+		return s
+	}
+
+	tests := []struct {
+		alias string
+		delta int
+		want  string
+		ok    bool
+	}{
+		{"b", -1, "bac", true},
+		{"b", 1, "acb", true},
+		{"a", 2, "bca", true},
+		{"a", -1, "", false}, // 已在最前
+		{"c", 1, "", false},  // 已在最后
+		{"x", 1, "", false},  // 服务不存在
+	}
+	for _, tt := range tests {
+		got, ok := moveService(services, tt.alias, tt.delta)
+		if ok != tt.ok || aliases(got) != tt.want {
+			t.Errorf("moveService(%q, %d) = %q, %v, 期望 %q, %v", tt.alias, tt.delta, aliases(got), ok, tt.want, tt.ok)
+		}
+	}
+	if aliases(services) != "abc" {
+		t.Errorf("moveService 不应修改传入的列表，实际为 %q", aliases(services))
+	}
+}
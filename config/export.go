@@ -0,0 +1,108 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ExportServices 将服务配置序列化为 JSON，格式与旧版的 JSON 配置文件相同。
+// includeSecrets 为 false 时不导出 Secret Key 和会话令牌。
+func ExportServices(services []S3ServiceConfig, includeSecrets bool) ([]byte, error) {
+	exported := make([]S3ServiceConfig, len(services))
+	copy(exported, services)
+	if !includeSecrets {
+		for i := range exported {
+			exported[i].SecretKey = ""
+			exported[i].SessionToken = ""
+		}
+	}
+	return json.MarshalIndent(ConfigStore{Services: exported}, "", "  ")
+}
+
+// ParseServices 解析导出的 JSON 数据，别名为空或重复时返回错误
+func ParseServices(data []byte) ([]S3ServiceConfig, error) {
+	var store ConfigStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("解析 JSON 数据失败: %w", err)
+	}
+	seen := make(map[string]struct{}, len(store.Services))
+	for _, svc := range store.Services {
+		if svc.Alias == "" {
+			return nil, errors.New("存在别名为空的服务")
+		}
+		if _, exists := seen[svc.Alias]; exists {
+			return nil, fmt.Errorf("服务别名 '%s' 重复", svc.Alias)
+		}
+		seen[svc.Alias] = struct{}{}
+	}
+	return store.Services, nil
+}
+
+// ConflictingAliases 返回 services 中与已有服务同名的别名
+func (cs *ConfigStore) ConflictingAliases(services []S3ServiceConfig) []string {
+	var conflicts []string
+	for _, svc := range services {
+		if _, exists := cs.findService(svc.Alias); exists {
+			conflicts = append(conflicts, svc.Alias)
+		}
+	}
+	return conflicts
+}
+
+// findService 按别名查找已加载的服务
+func (cs *ConfigStore) findService(alias string) (S3ServiceConfig, bool) {
+	for _, svc := range cs.Services {
+		if svc.Alias == alias {
+			return svc, true
+		}
+	}
+	return S3ServiceConfig{}, false
+}
+
+// ImportResult 是导入服务配置的结果
+type ImportResult struct {
+	Added   int // 新添加的服务数量
+	Updated int // 覆盖的同名服务数量
+	Skipped int // 因同名而跳过的服务数量
+}
+
+// planImport 按别名合并导入的服务：不存在的服务被添加，同名服务在 overwrite 为 true 时被覆盖，否则跳过。
+// 覆盖时导入的服务没有 Secret Key（导出时未包含密钥）则保留已有的 Secret Key 和会话令牌。
+func (cs *ConfigStore) planImport(services []S3ServiceConfig, overwrite bool) (toAdd, toUpdate []S3ServiceConfig, skipped int) {
+	for _, svc := range services {
+		existing, exists := cs.findService(svc.Alias)
+		switch {
+		case !exists:
+			toAdd = append(toAdd, svc)
+		case overwrite:
+			if svc.SecretKey == "" && !svc.Anonymous {
+				svc.SecretKey = existing.SecretKey
+				svc.SessionToken = existing.SessionToken
+			}
+			toUpdate = append(toUpdate, svc)
+		default:
+			skipped++
+		}
+	}
+	return toAdd, toUpdate, skipped
+}
+
+// ImportServices 按别名将导入的服务合并到数据库，规则见 planImport。遇到错误时停止，已保存的服务不会回滚。
+func (cs *ConfigStore) ImportServices(services []S3ServiceConfig, overwrite bool) (ImportResult, error) {
+	toAdd, toUpdate, skipped := cs.planImport(services, overwrite)
+	result := ImportResult{Skipped: skipped}
+	for _, svc := range toAdd {
+		if err := cs.AddService(svc); err != nil {
+			return result, err
+		}
+		result.Added++
+	}
+	for _, svc := range toUpdate {
+		if err := cs.UpdateService(svc.Alias, svc); err != nil {
+			return result, err
+		}
+		result.Updated++
+	}
+	return result, nil
+}
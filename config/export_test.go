@@ -0,0 +1,76 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportServicesRoundTrip(t *testing.T) {
+	services := []S3ServiceConfig{
+		{Alias: "minio", Endpoint: "http://localhost:9000", AccessKey: "ak", SecretKey: "sk", SessionToken: "token", PageSize: 50, PathStyle: true},
+		{Alias: "public", Endpoint: "https://s3.amazonaws.com", Anonymous: true},
+	}
+
+	data, err := ExportServices(services, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseServices(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, services) {
+		t.Errorf("导入的服务 = %+v, 期望 %+v", parsed, services)
+	}
+
+	data, err = ExportServices(services, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"sk"`) || strings.Contains(string(data), "token") {
+		t.Errorf("不包含密钥的导出数据中出现了密钥: %s", data)
+	}
+	if services[0].SecretKey != "sk" {
+		t.Error("导出不应修改传入的服务配置")
+	}
+}
+
+func TestParseServicesRejectsInvalidAliases(t *testing.T) {
+	for _, data := range []string{
+		`{"services":[{"alias":""}]}`,
+		`{"services":[{"alias":"a"},{"alias":"a"}]}`,
+		`not json`,
+	} {
+		if _, err := ParseServices([]byte(data)); err == nil {
+			t.Errorf("ParseServices(%s) 应返回错误", data)
+		}
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	cs := &ConfigStore{Services: []S3ServiceConfig{
+		{Alias: "minio", Endpoint: "http://old:9000", SecretKey: "old-secret", SessionToken: "old-token"},
+	}}
+	imported := []S3ServiceConfig{
+		{Alias: "minio", Endpoint: "http://new:9000"}, // 导出时未包含密钥
+		{Alias: "aws", Endpoint: "https://s3.amazonaws.com"},
+	}
+	if conflicts := cs.ConflictingAliases(imported); !reflect.DeepEqual(conflicts, []string{"minio"}) {
+		t.Errorf("ConflictingAliases = %v, 期望 [minio]", conflicts)
+	}
+
+	toAdd, toUpdate, skipped := cs.planImport(imported, false)
+	if len(toAdd) != 1 || toAdd[0].Alias != "aws" || len(toUpdate) != 0 || skipped != 1 {
+		t.Errorf("跳过同名服务: 添加 %v, 覆盖 %v, 跳过 %d", toAdd, toUpdate, skipped)
+	}
+
+	toAdd, toUpdate, skipped = cs.planImport(imported, true)
+	if len(toAdd) != 1 || len(toUpdate) != 1 || skipped != 0 {
+		t.Fatalf("覆盖同名服务: 添加 %v, 覆盖 %v, 跳过 %d", toAdd, toUpdate, skipped)
+	}
+	want := S3ServiceConfig{Alias: "minio", Endpoint: "http://new:9000", SecretKey: "old-secret", SessionToken: "old-token"}
+	if toUpdate[0] != want {
+		t.Errorf("覆盖后的服务 = %+v, 期望保留已有密钥 %+v", toUpdate[0], want)
+	}
+}
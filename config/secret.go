@@ -0,0 +1,163 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// masterSecretFile 是保存本机密钥的文件名，与数据库放在同一目录，仅当前用户可读写
+	masterSecretFile = "secret.key"
+	// masterSecretSize 是本机密钥的字节数
+	masterSecretSize = 32
+	// encryptedSecretPrefix 标记数据库中已加密的 SecretKey，没有该前缀的值是旧版本保存的明文
+	encryptedSecretPrefix = "enc:v1:"
+	// secretKeyInfo 是从本机密钥派生 SecretKey 加密密钥时使用的用途标识
+	secretKeyInfo = "s3-explorer services.secretKey"
+)
+
+// secretAEAD 用于加密和解密数据库中的 SecretKey，由 InitDB 初始化
+var secretAEAD cipher.AEAD
+
+// loadMasterSecret 读取配置目录中的本机密钥，不存在时生成一个新的密钥并以 0600 权限保存
+func loadMasterSecret(appConfigDir string) ([]byte, error) {
+	path := filepath.Join(appConfigDir, masterSecretFile)
+	secret, err := ioutil.ReadFile(path)
+	if err == nil {
+		if len(secret) != masterSecretSize {
+			return nil, fmt.Errorf("本机密钥文件 '%s' 已损坏", path)
+		}
+		return secret, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("读取本机密钥失败: %w", err)
+	}
+
+	secret = make([]byte, masterSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("生成本机密钥失败: %w", err)
+	}
+	// O_EXCL 保证不会覆盖同时启动的另一个实例刚生成的密钥
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return loadMasterSecret(appConfigDir)
+		}
+		return nil, fmt.Errorf("保存本机密钥失败: %w", err)
+	}
+	if _, err := f.Write(secret); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("保存本机密钥失败: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("保存本机密钥失败: %w", err)
+	}
+	log.Printf("已生成本机密钥: %s", path)
+	return secret, nil
+}
+
+// newSecretAEAD 从本机密钥派生 AES-256 密钥并创建 AES-GCM 加密器
+func newSecretAEAD(masterSecret []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, masterSecret, nil, secretKeyInfo, 32)
+	if err != nil {
+		return nil, fmt.Errorf("派生加密密钥失败: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("创建加密器失败: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptSecret 加密要保存到数据库的 SecretKey。空值（匿名访问的服务）保持为空。
+func encryptSecret(aead cipher.AEAD, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedSecretPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret 解密数据库中保存的 SecretKey，没有加密前缀的旧值按明文返回
+func decryptSecret(aead cipher.AEAD, stored string) (string, error) {
+	if !isEncryptedSecret(stored) {
+		return stored, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encryptedSecretPrefix))
+	if err != nil {
+		return "", fmt.Errorf("解码 SecretKey 失败: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("加密的 SecretKey 已损坏")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("解密 SecretKey 失败，本机密钥可能已更换: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// isEncryptedSecret 判断数据库中的 SecretKey 是否已加密
+func isEncryptedSecret(stored string) bool {
+	return strings.HasPrefix(stored, encryptedSecretPrefix)
+}
+
+// encryptPlaintextSecrets 加密旧版本以明文保存在数据库中的 SecretKey（用于旧版本升级）
+func encryptPlaintextSecrets() error {
+	rows, err := db.Query("SELECT alias, secretKey FROM services")
+	if err != nil {
+		return fmt.Errorf("查询服务失败: %w", err)
+	}
+	plaintexts := make(map[string]string)
+	for rows.Next() {
+		var alias, secretKey string
+		if err := rows.Scan(&alias, &secretKey); err != nil {
+			rows.Close()
+			return fmt.Errorf("扫描服务数据失败: %w", err)
+		}
+		if secretKey != "" && !isEncryptedSecret(secretKey) {
+			plaintexts[alias] = secretKey
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("遍历服务结果集失败: %w", err)
+	}
+	if len(plaintexts) == 0 {
+		return nil
+	}
+
+	log.Printf("检测到 %d 个以明文保存的 SecretKey，正在加密...", len(plaintexts))
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %w", err)
+	}
+	defer tx.Rollback() // 发生错误时回滚
+	for alias, plaintext := range plaintexts {
+		encrypted, err := encryptSecret(secretAEAD, plaintext)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE services SET secretKey = ? WHERE alias = ?", encrypted, alias); err != nil {
+			return fmt.Errorf("加密服务 '%s' 的 SecretKey 失败: %w", alias, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretEncryptionRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	secret, err := loadMasterSecret(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, masterSecretFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("本机密钥文件权限 = %o, 期望 600", perm)
+	}
+	// 再次读取得到同一个密钥
+	again, err := loadMasterSecret(dir)
+	if err != nil || string(again) != string(secret) {
+		t.Fatalf("再次读取本机密钥 = %x, %v, 期望与首次生成的一致", again, err)
+	}
+
+	aead, err := newSecretAEAD(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := encryptSecret(aead, "wJalrXUtnFEMI")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEncryptedSecret(encrypted) || strings.Contains(encrypted, "wJalrXUtnFEMI") {
+		t.Errorf("加密结果 = %q, 不应包含明文", encrypted)
+	}
+	if got, err := decryptSecret(aead, encrypted); err != nil || got != "wJalrXUtnFEMI" {
+		t.Errorf("decryptSecret = %q, %v, 期望还原明文", got, err)
+	}
+
+	// 旧版本的明文和匿名服务的空值原样返回
+	for _, stored := range []string{"plain-secret", ""} {
+		if got, err := decryptSecret(aead, stored); err != nil || got != stored {
+			t.Errorf("decryptSecret(%q) = %q, %v", stored, got, err)
+		}
+	}
+	if got, _ := encryptSecret(aead, ""); got != "" {
+		t.Errorf("空 SecretKey 加密后 = %q, 期望保持为空", got)
+	}
+
+	// 更换本机密钥后无法解密
+	other, err := newSecretAEAD(make([]byte, masterSecretSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decryptSecret(other, encrypted); err == nil {
+		t.Error("使用其他本机密钥解密应当失败")
+	}
+}
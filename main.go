@@ -1,12 +1,15 @@
 package main
 
 import (
+	_ "embed"
 	"fmt"
 	"image/color" // 导入 image/color 包用于颜色定义
 	"io/ioutil"   // 导入 ioutil 包用于读取文件
 	"log"         // 导入 log 包用于日志输出
 	"net/url"
+	"path/filepath"
 	"s3-explorer/config"
+	"slices"
 
 	"fyne.io/fyne/v2"           // 导入 fyne 主包
 	"fyne.io/fyne/v2/app"       // 导入 fyne 应用包
@@ -18,27 +21,56 @@ import (
 	"s3-explorer/ui"       // 导入 ui 包
 )
 
+// embeddedFont 是编译进程序的中文字体，单独分发可执行文件时也能正确显示中文
+//
+//go:embed assets/font/SourceHanSansSC-Regular.otf
+var embeddedFont []byte
+
 // customTheme 自定义主题结构体
-type customTheme struct{}
+type customTheme struct {
+	font    fyne.Resource // 界面使用的字体，在创建主题时确定
+	variant string        // 用户选择的浅色/深色主题，ui.ThemeVariantSystem 表示跟随系统
+}
+
+// newCustomTheme 创建自定义主题。fontPath 为用户配置的字体文件路径，
+// 为空或读取失败时使用内嵌的字体。
+func newCustomTheme(fontPath, variant string) *customTheme {
+	t := &customTheme{font: fyne.NewStaticResource("SourceHanSansSC-Regular.otf", embeddedFont), variant: variant}
+	if fontPath == "" {
+		return t
+	}
+	// 读取用户配置的字体文件
+	fontData, err := ioutil.ReadFile(fontPath)
+	if err != nil {
+		log.Printf("无法加载字体文件: %v, 将使用内嵌字体", err)
+		return t
+	}
+	// 将字体数据封装为 fyne.Resource
+	t.font = fyne.NewStaticResource(filepath.Base(fontPath), fontData)
+	return t
+}
+
+// withVariant 返回使用相同字体、但使用指定浅色/深色设置的主题
+func (t *customTheme) withVariant(variant string) *customTheme {
+	return &customTheme{font: t.font, variant: variant}
+}
 
-// Color 返回主题特定颜色
+// Color 返回主题特定颜色，用户选择了浅色或深色主题时忽略系统的设置
 // 实现了 fyne.Theme 接口的 Color 方法
 func (t *customTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	switch t.variant {
+	case ui.ThemeVariantLight:
+		variant = theme.VariantLight
+	case ui.ThemeVariantDark:
+		variant = theme.VariantDark
+	}
 	return theme.DefaultTheme().Color(name, variant)
 }
 
 // Font 返回自定义字体
 // 实现了 fyne.Theme 接口的 Font 方法
 func (t *customTheme) Font(textStyle fyne.TextStyle) fyne.Resource {
-	// 读取字体文件
-	fontData, err := ioutil.ReadFile("assets/font/SourceHanSansSC-Regular.otf")
-	if err != nil {
-		log.Printf("无法加载字体文件: %v, 将使用默认字体", err)
-		// 如果字体加载失败，返回默认字体
-		return theme.DefaultTheme().Font(textStyle)
-	}
-	// 将字体数据封装为 fyne.Resource
-	return fyne.NewStaticResource("SourceHanSansSC-Regular.otf", fontData)
+	return t.font
 }
 
 // Icon 返回主题特定图标资源
@@ -53,6 +85,60 @@ func (t *customTheme) Size(name fyne.ThemeSizeName) float32 {
 	return theme.DefaultTheme().Size(name)
 }
 
+// helpTextEN 是英文界面下的使用说明，内容与 showHelpDialog 中的中文说明保持一致
+const helpTextEN = `S3 Explorer Help:
+
+1. Add a service:
+   - Click the "+" button at the top left.
+   - Fill in the alias, Endpoint, Access Key and Secret Key.
+   - Click "Add" to save.
+   - To add another service on the same Endpoint, select a service and click the copy button, then edit the copy.
+   - Select a service and use the up/down arrow buttons to change its position in the list.
+   - Use "Settings > Export configuration" and "Import configuration" to move services to another computer.
+
+2. Browse and operate:
+   - Select a service in the left list.
+   - The middle list shows the buckets, click one to open it.
+   - A bucket can only be deleted when it is empty; the delete button is disabled otherwise.
+   - Click "Empty" and type the bucket name to delete every object in the bucket (including old versions).
+   - The "Delete" button only deletes empty buckets; to delete a bucket with contents, right-click it, choose "Force delete (with contents)" and type the bucket name to confirm.
+   - The right list shows files and folders.
+   - Use the toolbar buttons to create folders, upload, download and delete.
+   - Right-click a folder and choose "Download as ZIP" to download all of its files as one archive.
+   - Uploads and downloads run in the transfers panel below the object list, so you can keep browsing or start other transfers meanwhile; cancel running transfers there, or click "Clear finished" to remove finished ones.
+   - Double-click a file to preview it.
+   - Drag files or folders from the system into the window to upload them; drop onto a folder to upload into it.
+
+3. Keyboard shortcuts:
+   - Ctrl+C: copy the selected S3 objects (files/folders) inside the app
+   - Ctrl+V: upload files from the clipboard to the current folder, or paste copied S3 objects into it
+   - Ctrl+Shift+V: switch between list and thumbnail view
+   - Ctrl+P: quick open buckets, recently visited folders and objects in the current listing
+   - Ctrl+A: select all items in the current (filtered) listing
+   - Delete: delete the selected items
+   - Up/Down: move the selection (hold Shift to extend it)
+   - Enter: open the selected folder or preview the selected file
+   - Backspace: go up one folder
+   - F5: reload the current folder
+
+4. Views:
+   - Use the view switch button at the top right to switch between list and thumbnail view.
+   - In thumbnail view, rest the mouse on an item to see its full name, size and modification time.
+   - The view mode and page size are remembered per service.
+   - "Settings > Preferences" gathers all app settings in one dialog, one tab per category.
+   - Use "Settings > New service defaults" to choose the default view and page size for new services.
+   - Use the "View" menu to switch between the light and dark theme, or follow the system setting.
+
+5. Notes:
+   - S3 does not support real paging, so the number of folders per page may be inaccurate; the total file count is correct.
+   - A page size of 0 disables paging.
+   - Requests time out after 30 seconds by default, adjustable in "Settings > Timeout settings".
+   - Batch uploads, downloads and deletes process 10 files at a time by default; lower "Transfer concurrency" in the same dialog for small servers. Large files are downloaded in parallel parts; the number of parts per file is half the transfer concurrency, between 2 and 8.
+   - Failed or interrupted downloads keep the data received so far (.s3part files); retrying or downloading the same file again resumes where it stopped.
+   - Change the interface language in "Settings > Language".
+   - Turn off the confirmation before deleting, overwriting or pasting objects in "Settings > Confirmations".
+`
+
 // showHelpDialog 显示帮助说明对话框
 func showHelpDialog(w fyne.Window) {
 	helpText := `S3 Explorer 使用说明:
@@ -61,35 +147,62 @@ func showHelpDialog(w fyne.Window) {
    - 点击左上角的 "+" 按钮。
    - 填写服务别名、Endpoint、Access Key 和 Secret Key。
    - 点击 "添加" 保存。
+   - 在同一 Endpoint 上添加多个服务时，可选中服务后点击复制按钮，再编辑生成的副本。
+   - 选中服务后点击上移/下移按钮可以调整服务在列表中的位置。
+   - 通过 "设置 > 导出配置" 和 "导入配置" 可以将服务迁移到其他电脑。
 
 2. 浏览和操作:
    - 左侧列表选择一个服务。
    - 中间列表会显示存储桶，点击进入。
    - 存储桶不为空时才可以删除，选中的存储桶不为空时删除按钮无法点击。
+   - 点击 "清空" 并输入存储桶名称确认后，可删除存储桶中的所有对象（包括历史版本）。
+   - "删除" 按钮只能删除空存储桶；要删除有内容的存储桶，右键点击存储桶选择 "强制删除（含内容）" 并输入存储桶名称确认。
    - 右侧列表显示文件和文件夹。
    - 使用顶部的按钮进行创建文件夹、上传、下载、删除等操作。
+   - 右键点击文件夹选择 "下载为 ZIP" 可将其中的所有文件打包下载为一个压缩包。
+   - 上传和下载在对象列表下方的传输面板中进行，期间可以继续浏览或开始其它传输；可以取消进行中的传输，或点击 "清除已结束" 移除已结束的传输。
    - 双击文件可进行预览。
-   - 将文件或文件夹从系统拖拽到窗口内可直接上传。
+   - 将文件或文件夹从系统拖拽到窗口内可直接上传，拖拽到文件夹上时上传到该文件夹。
 
 3. 键盘快捷键:
    - Ctrl+C: 复制选中的S3对象（文件/文件夹）信息到应用内部
    - Ctrl+V: 粘贴剪贴板中的文件并上传到当前目录，或粘贴已复制的S3对象到当前目录
+   - Ctrl+Shift+V: 在列表和缩略图模式间切换
+   - Ctrl+P: 快速打开存储桶、最近访问的目录和当前列表中的对象
+   - Ctrl+A: 选中当前列表（搜索和筛选后）中的所有项目
+   - Delete: 删除选中的项目
+   - 上/下方向键: 移动选择（按住 Shift 扩展选择）
+   - 回车: 打开选中的文件夹或预览选中的文件
+   - 退格: 返回上一级目录
+   - F5: 重新加载当前目录
 
 4. 视图切换:
    - 点击右上角的视图切换按钮可在列表和缩略图模式间切换。
-   - 程序会为每个服务记住您的视图偏好。
+   - 在缩略图模式中，鼠标停留在项目上可查看完整名称、大小和修改时间。
+   - 程序会为每个服务记住您的视图偏好和每页显示数量。
+   - "设置 > 首选项" 在一个对话框中汇总所有应用设置，每类设置一个标签页。
+   - 通过 "设置 > 新服务默认设置" 可以指定新添加服务的默认视图和每页显示数量。
+   - 通过 "视图" 菜单可以在浅色和深色主题间切换，或跟随系统设置。
 
 5. 注意事项:
    - 由于 S3 协议不支持分页，所以分页功能文件夹显示数量可能不准确，但是总文件数是正确的。
    - 分页配置为 0 表示不分页。
+   - 请求默认 30 秒超时，可通过 "设置 > 超时设置" 调整。
+   - 批量上传、下载和删除默认同时处理 10 个文件，小型服务器可在同一对话框中调低 "传输并发数"。大文件分段并行下载，每个文件同时下载的分段数为传输并发数的一半，限制在 2–8 之间。
+   - 下载失败或中断时会保留已下载的部分（.s3part 文件），重试或再次下载同一文件时从中断处继续。
+   - 通过 "设置 > 语言" 可以切换界面语言。
+   - 通过 "设置 > 操作确认" 可以关闭删除、覆盖或粘贴对象前的确认。
 `
+	if ui.Language() == "en" {
+		helpText = helpTextEN
+	}
 	content := widget.NewMultiLineEntry()
 	content.SetText(helpText)
 	content.Wrapping = fyne.TextWrapWord
 	content.Disable()
 
 	scrollableContent := container.NewScroll(content)
-	d := dialog.NewCustom("使用说明", "关闭", scrollableContent, w)
+	d := dialog.NewCustom(ui.T("使用说明"), ui.T("关闭"), scrollableContent, w)
 	d.Resize(fyne.NewSize(500, 400))
 	d.Show()
 }
@@ -101,13 +214,13 @@ func showAboutDialog(w fyne.Window) {
 
 	aboutContent := container.NewVBox(
 		widget.NewLabel("S3 Explorer"),
-		widget.NewLabel("版本: 1.0.0"),
-		widget.NewLabel("一个简单的 S3 兼容对象存储桌面浏览器。"),
-		widget.NewHyperlink("GitHub 仓库", ghURL),
-		widget.NewHyperlink("Gitee 仓库", gtURL),
+		widget.NewLabel(ui.T("版本: 1.0.0")),
+		widget.NewLabel(ui.T("一个简单的 S3 兼容对象存储桌面浏览器。")),
+		widget.NewHyperlink(ui.T("GitHub 仓库"), ghURL),
+		widget.NewHyperlink(ui.T("Gitee 仓库"), gtURL),
 	)
 
-	dialog.ShowCustom("关于 S3 Explorer", "关闭", aboutContent, w)
+	dialog.ShowCustom(ui.T("关于 S3 Explorer"), ui.T("关闭"), aboutContent, w)
 }
 
 func main() {
@@ -119,26 +232,84 @@ func main() {
 	// 创建一个新的 Fyne 应用，并指定一个唯一的 ID
 	a := app.NewWithID("link.yifan.s3explorer")
 
-	// 设置自定义主题
-	a.Settings().SetTheme(&customTheme{})
+	// 设置自定义主题，优先使用用户配置的字体文件
+	appTheme := newCustomTheme(ui.FontPathSetting(), ui.ThemeVariantSetting())
+	a.Settings().SetTheme(appTheme)
+
+	// 应用已保存的设置（界面语言、请求超时等），必须在创建界面之前调用
+	ui.ApplySavedSettings()
 
 	// 创建一个新窗口
-	w := a.NewWindow("S3 资源管理器")
+	w := a.NewWindow(ui.T("S3 资源管理器"))
 
 	// --- 创建主菜单 ---
-	helpMenu := fyne.NewMenu("帮助",
-		fyne.NewMenuItem("使用说明", func() {
+	preferencesItem := fyne.NewMenuItem(ui.T("首选项"), nil)
+	settingsMenu := fyne.NewMenu(ui.T("设置"),
+		preferencesItem,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.T("新服务默认设置"), func() {
+			ui.ShowNewServiceDefaultsDialog(w)
+		}),
+		fyne.NewMenuItem(ui.T("超时设置"), func() {
+			ui.ShowTimeoutSettingsDialog(w)
+		}),
+		fyne.NewMenuItem(ui.T("操作确认"), func() {
+			ui.ShowConfirmationSettingsDialog(w)
+		}),
+		fyne.NewMenuItem(ui.T("语言"), func() {
+			ui.ShowLanguageDialog(w)
+		}),
+	)
+	// 文件夹对象数量需要额外的列举请求，默认关闭，通过菜单切换
+	folderCountsItem := fyne.NewMenuItem(ui.T("显示文件夹对象数量"), nil)
+	relativeTimeItem := fyne.NewMenuItem(ui.T("以相对时间显示修改时间"), nil)
+	thumbnailsItem := fyne.NewMenuItem(ui.T("加载缩略图"), nil)
+	clearThumbnailsItem := fyne.NewMenuItem(ui.T("清除缩略图缓存"), nil)
+	openAfterDownloadItem := fyne.NewMenuItem(ui.T("下载后自动打开"), nil)
+	settingsMenu.Items = append(settingsMenu.Items, fyne.NewMenuItemSeparator(), folderCountsItem, relativeTimeItem, thumbnailsItem, clearThumbnailsItem, openAfterDownloadItem)
+
+	// 切换浅色/深色主题立即生效，并保存到首选项
+	viewMenu := fyne.NewMenu(ui.T("视图"))
+	themeItems := map[string]*fyne.MenuItem{
+		ui.ThemeVariantSystem: fyne.NewMenuItem(ui.T("跟随系统主题"), nil),
+		ui.ThemeVariantLight:  fyne.NewMenuItem(ui.T("浅色主题"), nil),
+		ui.ThemeVariantDark:   fyne.NewMenuItem(ui.T("深色主题"), nil),
+	}
+	// applyThemeVariant 重新设置应用主题并更新菜单的勾选状态，调用前主题已保存到首选项
+	applyThemeVariant := func(variant string) {
+		appTheme = appTheme.withVariant(variant)
+		a.Settings().SetTheme(appTheme)
+		for v, it := range themeItems {
+			it.Checked = v == variant
+		}
+		viewMenu.Refresh()
+	}
+	for _, variant := range []string{ui.ThemeVariantSystem, ui.ThemeVariantLight, ui.ThemeVariantDark} {
+		item := themeItems[variant]
+		item.Checked = variant == appTheme.variant
+		item.Action = func() {
+			ui.SetThemeVariantSetting(variant)
+			applyThemeVariant(variant)
+		}
+		viewMenu.Items = append(viewMenu.Items, item)
+	}
+	preferencesItem.Action = func() {
+		ui.ShowPreferencesDialog(w, applyThemeVariant)
+	}
+
+	helpMenu := fyne.NewMenu(ui.T("帮助"),
+		fyne.NewMenuItem(ui.T("使用说明"), func() {
 			showHelpDialog(w)
 		}),
 	)
 
-	aboutMenu := fyne.NewMenu("关于",
-		fyne.NewMenuItem("关于 S3 Explorer", func() {
+	aboutMenu := fyne.NewMenu(ui.T("关于"),
+		fyne.NewMenuItem(ui.T("关于 S3 Explorer"), func() {
 			showAboutDialog(w)
 		}),
 	)
 
-	mainMenu := fyne.NewMainMenu(helpMenu, aboutMenu)
+	mainMenu := fyne.NewMainMenu(settingsMenu, viewMenu, helpMenu, aboutMenu)
 	w.SetMainMenu(mainMenu)
 
 	// 创建动画管理器实例
@@ -153,6 +324,78 @@ func main() {
 
 	// 当对象视图的模式改变时，更新服务视图中的配置
 	objectsView.OnViewModeChanged = servicesView.UpdateServiceViewMode
+	objectsView.OnPageSizeChanged = servicesView.UpdateServicePageSize
+	objectsView.OnFoldersFirstChanged = servicesView.UpdateServiceFoldersFirst
+	objectsView.OnUploadStorageClassChanged = servicesView.UpdateServiceUploadStorageClass
+	objectsView.OnSortChanged = servicesView.UpdateServiceSort
+
+	// 没有配置服务时，对象视图显示添加服务的引导
+	objectsView.OnAddServiceRequested = servicesView.ShowAddServiceDialog
+
+	// 导入/导出服务配置需要服务视图，创建视图后再加入设置菜单
+	settingsMenu.Items = append(settingsMenu.Items, fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ui.T("导出配置"), servicesView.ShowExportServicesDialog),
+		fyne.NewMenuItem(ui.T("导入配置"), servicesView.ShowImportServicesDialog),
+	)
+	mainMenu.Refresh()
+
+	// 启动时恢复上次打开的服务、存储桶和目录。服务、存储桶或目录已不存在时停留在上一级，不提示错误。
+	restoreService, restoreBucket, restorePrefix := ui.LastLocation()
+	selectedService := ""
+	servicesView.OnServicesLoaded = func(count int) {
+		objectsView.SetHasServices(count > 0)
+		if restoreService != "" {
+			alias := restoreService
+			restoreService = ""
+			if !servicesView.SelectService(alias) {
+				restoreBucket = ""
+			} else if restoreBucket != "" {
+				restoreService = alias // 等待存储桶列表加载后继续恢复
+			}
+		}
+	}
+
+	// 临时凭证过期时，提示重新认证，成功后重建客户端并重试失败的操作
+	reauthenticate := func(retry func()) {
+		servicesView.ShowReauthenticateDialog(func(svc config.S3ServiceConfig) {
+			client, err := s3client.NewS3Client(svc)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(ui.T("创建 S3 客户端失败: %v"), err), w)
+				return
+			}
+			bucketsView.S3Client = client
+			objectsView.ReplaceS3Client(client)
+			retry()
+		})
+	}
+	objectsView.OnCredentialsExpired = reauthenticate
+
+	folderCountsItem.Checked = objectsView.ShowFolderCounts()
+	folderCountsItem.Action = func() {
+		objectsView.SetShowFolderCounts(!objectsView.ShowFolderCounts())
+		folderCountsItem.Checked = objectsView.ShowFolderCounts()
+		mainMenu.Refresh()
+	}
+	relativeTimeItem.Checked = objectsView.ShowRelativeTime()
+	relativeTimeItem.Action = func() {
+		objectsView.SetShowRelativeTime(!objectsView.ShowRelativeTime())
+		relativeTimeItem.Checked = objectsView.ShowRelativeTime()
+		mainMenu.Refresh()
+	}
+	thumbnailsItem.Checked = objectsView.LoadThumbnails()
+	thumbnailsItem.Action = func() {
+		objectsView.SetLoadThumbnails(!objectsView.LoadThumbnails())
+		thumbnailsItem.Checked = objectsView.LoadThumbnails()
+		mainMenu.Refresh()
+	}
+	clearThumbnailsItem.Action = objectsView.ClearThumbnailCache
+	openAfterDownloadItem.Checked = objectsView.OpenAfterDownload()
+	openAfterDownloadItem.Action = func() {
+		objectsView.SetOpenAfterDownload(!objectsView.OpenAfterDownload())
+		openAfterDownloadItem.Checked = objectsView.OpenAfterDownload()
+		mainMenu.Refresh()
+	}
+	bucketsView.OnCredentialsExpired = reauthenticate
 
 	// 当选中存储桶时，更新对象视图
 	bucketsView.OnBucketSelected = func(bucketName string) {
@@ -163,30 +406,90 @@ func main() {
 		}
 	}
 
-	// 当选中服务时，更新存储桶和对象视图
-	servicesView.OnServiceSelected = func(svc config.S3ServiceConfig) {
-		objectsView.SetServiceAlias(svc.Alias)
-
-		if svc.Alias == "" && svc.Endpoint == "" && svc.AccessKey == "" {
-			bucketsView.SetS3Client(nil)
-			objectsView.SetBucketAndPrefix(nil, "", "")
+	bucketsView.OnBucketsLoaded = func(buckets []string) {
+		alias, bucket, prefix := restoreService, restoreBucket, restorePrefix
+		restoreService, restoreBucket, restorePrefix = "", "", ""
+		if bucket == "" || alias != selectedService || !slices.Contains(buckets, bucket) {
 			return
 		}
+		client := bucketsView.S3Client
+		go func() {
+			if prefix != "" {
+				exists, err := client.PrefixExists(bucket, prefix)
+				if err != nil {
+					log.Printf("检查上次打开的目录 '%s/%s' 失败: %v", bucket, prefix, err)
+				}
+				if !exists {
+					prefix = "" // 目录已不存在，改为打开存储桶根目录
+				}
+			}
+			fyne.Do(func() {
+				if bucketsView.S3Client != client || alias != selectedService {
+					return // 恢复期间用户已选择了其它服务
+				}
+				bucketsView.SelectBucket(bucket)
+				objectsView.SetBucketAndPrefix(client, bucket, prefix)
+			})
+		}()
+	}
 
-		client, err := s3client.NewS3Client(svc)
-		if err != nil {
-			log.Printf("创建 S3 客户端失败: %v", err)
-			dialog.ShowError(fmt.Errorf("创建 S3 客户端失败: %v", err), w)
-			bucketsView.SetS3Client(nil)
-			objectsView.SetBucketAndPrefix(nil, "", "")
+	// 打开书签或快速打开的位置时选中对应的存储桶并跳转
+	objectsView.QuickOpenBuckets = bucketsView.Buckets
+	objectsView.OnLocationOpened = func(bucketName, prefix string) {
+		if bucketsView.S3Client == nil {
+			log.Println("S3 客户端未初始化，无法打开该位置")
 			return
 		}
+		bucketsView.SelectBucket(bucketName)
+		objectsView.SetBucketAndPrefix(bucketsView.S3Client, bucketName, prefix)
+	}
 
-		// 根据服务的配置设置视图模式
-		objectsView.SetViewMode(svc.ViewMode)
+	// 当选中服务时，在后台创建客户端，完成后更新存储桶和对象视图。
+	// 快速切换服务时只应用最后一次选择的结果。
+	connectGeneration := 0
+	servicesView.OnServiceSelected = func(svc config.S3ServiceConfig) {
+		selectedService = svc.Alias
+		objectsView.SetServiceAlias(svc.Alias)
+		bucketsView.SetServiceAlias(svc.Alias)
 
-		bucketsView.SetS3Client(client)
-		objectsView.SetBucketAndPrefix(client, "", "") // 清空对象列表，等待存储桶选择
+		connectGeneration++
+		generation := connectGeneration
+		bucketsView.SetS3Client(nil)
+		objectsView.SetBucketAndPrefix(nil, "", "")
+
+		if svc.Alias == "" && svc.Endpoint == "" && svc.AccessKey == "" {
+			bucketsView.SetConnecting(false)
+			objectsView.SetConnecting(false)
+			return
+		}
+
+		bucketsView.SetConnecting(true)
+		objectsView.SetConnecting(true)
+		go func() {
+			client, err := s3client.NewS3Client(svc)
+			fyne.Do(func() {
+				if generation != connectGeneration {
+					return // 已经选择了其它服务
+				}
+				bucketsView.SetConnecting(false)
+				objectsView.SetConnecting(false)
+				if err != nil {
+					log.Printf("创建 S3 客户端失败: %v", err)
+					dialog.ShowError(fmt.Errorf(ui.T("创建 S3 客户端失败: %v"), err), w)
+					return
+				}
+
+				// 根据服务的配置设置视图模式和每页显示数量
+				objectsView.SetViewMode(svc.ViewMode)
+				objectsView.SetPageSize(svc.PageSize)
+				objectsView.SetFoldersFirst(svc.FoldersFirst)
+				objectsView.SetSortOrder(svc.SortField, svc.SortDescending)
+				objectsView.SetUploadStorageClass(svc.UploadStorageClass)
+
+				bucketsView.SetS3Client(client)
+				objectsView.SetBucketAndPrefix(client, "", "") // 清空对象列表，等待存储桶选择
+			})
+		}()
 	}
 
 	// --- 布局设置 ---
@@ -207,8 +510,11 @@ func main() {
 	// 外层分割比例：左侧占 1.0 ➗ 10.0 = 0.1
 	content.Offset = 0.1
 
+	// 后台任务的非致命问题显示在窗口底部的通知栏中
+	notificationBar := ui.NewNotificationBar(w)
+
 	// 设置窗口内容和大小
-	w.SetContent(content)
+	w.SetContent(container.NewBorder(nil, notificationBar.GetContent(), nil, nil, content))
 	w.Resize(fyne.NewSize(1280, 720))
 
 	// 显示并运行窗口
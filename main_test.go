@@ -2,8 +2,12 @@ package main
 
 import (
 	"testing"
+	"time"
 
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
 	"s3-explorer/common"
+	"s3-explorer/ui"
 )
 
 func TestFormatBytes(t *testing.T) {
@@ -93,4 +97,99 @@ func TestFormatFileNameForDisplay(t *testing.T) {
 			t.Errorf("FormatFileNameForDisplay(%s, %d) = %s; expected %s", test.filename, test.maxDisplayLength, result, test.expected)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestFileURIToPath(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected string
+	}{
+		{"file:///home/user/my%20folder/file%20(1)+.txt", "/home/user/my folder/file (1)+.txt"},
+		{"file:///C:/Users/me/a%2Bb.txt", "C:/Users/me/a+b.txt"},
+		{"file:///tmp/%E4%B8%AD%E6%96%87.txt", "/tmp/中文.txt"},
+		{"file:///tmp/a%23b.txt", "/tmp/a#b.txt"},
+		{"file:///tmp/a#b.txt", "/tmp/a#b.txt"},
+		{"file://server/share/file.txt", "//server/share/file.txt"},
+	}
+
+	for _, test := range tests {
+		result, err := common.FileURIToPath(test.uri)
+		if err != nil {
+			t.Errorf("FileURIToPath(%s) returned error: %v", test.uri, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("FileURIToPath(%s) = %s; expected %s", test.uri, result, test.expected)
+		}
+	}
+}
+
+func TestSafeFileExt(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"my folder/file (1)+.txt", ".txt"},
+		{"dir.v2/file", ""},
+		{"photo.JPG", ".JPG"},
+		{"weird.t*t", ""},
+		{"noext", ""},
+	}
+
+	for _, test := range tests {
+		result := common.SafeFileExt(test.key)
+		if result != test.expected {
+			t.Errorf("SafeFileExt(%s) = %s; expected %s", test.key, result, test.expected)
+		}
+	}
+}
+
+func TestApplyTimestampTemplate(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		fileName string
+		template string
+		expected string
+	}{
+		{"report.csv", common.DefaultTimestampTemplate, "report_20240115-1030.csv"},
+		{"archive.tar.gz", common.DefaultTimestampTemplate, "archive.tar_20240115-1030.gz"},
+		{"noext", common.DefaultTimestampTemplate, "noext_20240115-1030"},
+		{"app.log", "{YYYY}-{MM}-{DD}/{name}-{HH}{mm}{ss}{ext}", "2024-01-15/app-103045.log"},
+		{"app.log", "{YYYY}{MM}{DD}", "app.log"},
+	}
+
+	for _, test := range tests {
+		result := common.ApplyTimestampTemplate(test.fileName, test.template, ts)
+		if result != test.expected {
+			t.Errorf("ApplyTimestampTemplate(%s, %s) = %s; expected %s", test.fileName, test.template, result, test.expected)
+		}
+	}
+}
+
+func TestNewCustomThemeFallsBackToEmbeddedFont(t *testing.T) {
+	if len(embeddedFont) == 0 {
+		t.Fatal("字体没有被内嵌到程序中")
+	}
+	th := newCustomTheme("does/not/exist.otf", ui.ThemeVariantSystem)
+	if th.Font(fyne.TextStyle{}).Name() != "SourceHanSansSC-Regular.otf" {
+		t.Errorf("字体文件读取失败时应使用内嵌字体，实际为 %s", th.Font(fyne.TextStyle{}).Name())
+	}
+}
+
+func TestCustomThemeHonorsChosenVariant(t *testing.T) {
+	dark := theme.DefaultTheme().Color(theme.ColorNameBackground, theme.VariantDark)
+	light := theme.DefaultTheme().Color(theme.ColorNameBackground, theme.VariantLight)
+
+	th := newCustomTheme("", ui.ThemeVariantDark)
+	if got := th.Color(theme.ColorNameBackground, theme.VariantLight); got != dark {
+		t.Errorf("选择深色主题时背景色 = %v, 期望 %v", got, dark)
+	}
+	th = th.withVariant(ui.ThemeVariantLight)
+	if got := th.Color(theme.ColorNameBackground, theme.VariantDark); got != light {
+		t.Errorf("选择浅色主题时背景色 = %v, 期望 %v", got, light)
+	}
+	th = th.withVariant(ui.ThemeVariantSystem)
+	if got := th.Color(theme.ColorNameBackground, theme.VariantDark); got != dark {
+		t.Errorf("跟随系统时背景色 = %v, 期望 %v", got, dark)
+	}
+}
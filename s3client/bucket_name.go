@@ -0,0 +1,61 @@
+package s3client
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+// BucketRegions 是创建存储桶时可选的常用区域，列表中的第一项为默认区域
+var BucketRegions = []string{
+	defaultRegion,
+	"us-east-2",
+	"us-west-1",
+	"us-west-2",
+	"ca-central-1",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-central-1",
+	"eu-north-1",
+	"ap-east-1",
+	"ap-south-1",
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"sa-east-1",
+}
+
+// ValidateBucketName 按 S3 的命名规则检查存储桶名称：3–63 个字符，只能包含小写字母、数字、点和连字符，
+// 以字母或数字开头和结尾，不能包含连续的点，也不能是 IP 地址格式。不符合时返回说明原因的错误。
+func ValidateBucketName(name string) error {
+	if len(name) < 3 || len(name) > 63 {
+		return errors.New("存储桶名称长度必须为 3 到 63 个字符")
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '.', c == '-':
+		case c >= 'A' && c <= 'Z':
+			return errors.New("存储桶名称不能包含大写字母")
+		case c == '_':
+			return errors.New("存储桶名称不能包含下划线")
+		default:
+			return errors.New("存储桶名称只能包含小写字母、数字、点和连字符")
+		}
+	}
+	if !isLowerAlnum(name[0]) || !isLowerAlnum(name[len(name)-1]) {
+		return errors.New("存储桶名称必须以小写字母或数字开头和结尾")
+	}
+	if strings.Contains(name, "..") {
+		return errors.New("存储桶名称不能包含连续的点")
+	}
+	if net.ParseIP(name) != nil {
+		return errors.New("存储桶名称不能是 IP 地址格式")
+	}
+	return nil
+}
+
+// isLowerAlnum 判断字符是否为小写字母或数字
+func isLowerAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
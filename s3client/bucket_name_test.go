@@ -0,0 +1,21 @@
+package s3client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBucketName(t *testing.T) {
+	valid := []string{"abc", "my-bucket", "logs.2024", "a1b2c3"}
+	for _, name := range valid {
+		if err := ValidateBucketName(name); err != nil {
+			t.Errorf("ValidateBucketName(%q) = %v, 期望通过", name, err)
+		}
+	}
+	invalid := []string{"ab", "My-Bucket", "my_bucket", "-bucket", "bucket-", "a..b", "192.168.1.1", "bucket!", strings.Repeat("a", 64)}
+	for _, name := range invalid {
+		if err := ValidateBucketName(name); err == nil {
+			t.Errorf("ValidateBucketName(%q) 期望返回错误", name)
+		}
+	}
+}
@@ -1,30 +1,106 @@
 package s3client
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io" // 导入 io 包
+	"log"
+	"mime"
 	"net/http"
 	"net/url"
+	"path"
 	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	appConfig "s3-explorer/config" // 导入应用程序的配置包
 )
 
+// defaultRegion 是未配置区域时使用的默认区域
+const defaultRegion = "us-east-1"
+
+// expiredTokenErrorCodes 是各类 S3 兼容服务在临时凭证过期时返回的错误码
+var expiredTokenErrorCodes = map[string]bool{
+	"ExpiredToken":          true,
+	"ExpiredTokenException": true,
+	"TokenRefreshRequired":  true,
+}
+
+// IsExpiredTokenError 判断错误是否由临时凭证（Session Token）过期引起
+func IsExpiredTokenError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return expiredTokenErrorCodes[apiErr.ErrorCode()]
+	}
+	return false
+}
+
+// IsNotImplementedError 判断错误是否表示服务端不支持该操作，部分 S3 兼容服务不支持版本列举、对象标签等功能
+func IsNotImplementedError(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotImplemented"
+}
+
+// isNotFoundError 判断 HeadObject 等请求的错误是否表示对象不存在：NoSuchKey/NotFound 错误或 404 状态码。
+// 403（没有权限）、400（请求无效）等错误不代表对象不存在，当作不存在可能覆盖已有对象或导致重命名时反复尝试。
+func isNotFoundError(err error) bool {
+	var noSuchKey *s3types.NoSuchKey
+	var notFound *s3types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return true
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NoSuchKey", "NotFound":
+			return true
+		case "NoSuchBucket":
+			return false
+		}
+	}
+	var respErr *smithyhttp.ResponseError
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound
+}
+
+// ErrAnonymousReadOnly 表示匿名访问的服务执行了需要凭证的写操作
+var ErrAnonymousReadOnly = errors.New("匿名访问模式下不支持此操作，请为服务配置 Access Key 和 Secret Key")
+
 // S3Client 结构体封装了 AWS S3 客户端
 type S3Client struct {
-	client *s3.Client
+	client       s3API
+	presigner    presignAPI  // 生成预签名地址，与 client 使用相同的 Endpoint 和路径风格设置
+	endpoint     string      // 自定义 Endpoint，为空时表示使用 AWS 官方地址
+	region       string      // 签名及生成地址时使用的区域
+	usePathStyle bool        // 是否使用路径风格访问
+	consoleURL   string      // 服务的 Web 控制台地址，为空时根据 Endpoint 推断
+	anonymous    bool        // 是否为匿名访问
+	ignoreCase   bool        // 检测对象是否存在时是否不区分大小写
+	foldersFirst atomic.Bool // 列出对象时文件夹是否排在文件前面，可在后台列举期间修改
+
+	// PreserveMetadataOnCopy 复制对象时是否保留源对象的 Content-Type 和用户元数据，默认开启
+	PreserveMetadataOnCopy bool
 }
 
 // NewS3Client 根据 S3 服务配置创建一个新的 S3Client 实例
 func NewS3Client(svcConfig appConfig.S3ServiceConfig) (*S3Client, error) {
+	// 缺少协议或带有末尾斜杠的 Endpoint 会导致签名或连接失败，创建客户端前先规范化
+	endpoint, err := NormalizeEndpoint(svcConfig.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	svcConfig.Endpoint = endpoint
+
 	// 构建自定义解析器，用于支持 Minio 等自定义 Endpoint
 	customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 		if svcConfig.Endpoint != "" {
@@ -40,11 +116,22 @@ func NewS3Client(svcConfig appConfig.S3ServiceConfig) (*S3Client, error) {
 		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
 	})
 
+	region := strings.TrimSpace(svcConfig.Region)
+	if region == "" {
+		region = defaultRegion
+	}
+
+	// 匿名访问时不签名请求，只能访问公开的存储桶和对象；填写了会话令牌时使用临时凭证（STS），过期后由界面提示重新输入
+	var credentialsProvider aws.CredentialsProvider = credentials.NewStaticCredentialsProvider(svcConfig.AccessKey, svcConfig.SecretKey, strings.TrimSpace(svcConfig.SessionToken))
+	if svcConfig.Anonymous {
+		credentialsProvider = aws.AnonymousCredentials{}
+	}
+
 	cfg, err := config.LoadDefaultConfig( // 修正：使用 LoadDefaultConfig
 		context.TODO(),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(svcConfig.AccessKey, svcConfig.SecretKey, "")),
+		config.WithCredentialsProvider(credentialsProvider),
 		config.WithEndpointResolverWithOptions(customResolver),
-		config.WithRegion("us-east-1"), // 即使使用自定义 Endpoint，也通常需要指定一个区域
+		config.WithRegion(region), // 即使使用自定义 Endpoint，也通常需要指定一个区域
 	)
 	if err != nil {
 		return nil, fmt.Errorf("加载 AWS 配置失败: %w", err)
@@ -64,181 +151,835 @@ func NewS3Client(svcConfig appConfig.S3ServiceConfig) (*S3Client, error) {
 		}
 	}
 
-	// 创建 S3 客户端，并启用路径风格访问
+	// 创建 S3 客户端。MinIO 等 S3 兼容服务通常需要路径风格访问，AWS 则推荐虚拟主机风格
 	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.UsePathStyle = true // 修正：启用路径风格访问，对于 Minio 等 S3 兼容服务很重要
+		o.UsePathStyle = svcConfig.PathStyle
 		// 显式设置校验和计算和验证策略为 Unset，以避免与 HTTP 和非 seekable streams 相关的问题
 		o.RequestChecksumCalculation = aws.RequestChecksumCalculationUnset
 		o.ResponseChecksumValidation = aws.ResponseChecksumValidationUnset
+		// 阿里云 OSS 等服务不支持新版 SDK 默认附加的校验和，只在操作必需时计算
+		if svcConfig.ChecksumWhenRequired {
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
 	})
-	return &S3Client{client: client},
-		nil
+	sc := &S3Client{
+		client:       client,
+		presigner:    s3.NewPresignClient(client),
+		endpoint:     svcConfig.Endpoint,
+		region:       region,
+		usePathStyle: svcConfig.PathStyle,
+		consoleURL:   svcConfig.ConsoleURL,
+		anonymous:    svcConfig.Anonymous,
+		ignoreCase:   svcConfig.CaseInsensitiveKeys,
+
+		PreserveMetadataOnCopy: true,
+	}
+	sc.foldersFirst.Store(svcConfig.FoldersFirst)
+	return sc, nil
+}
+
+// DefaultPathStyle 返回新服务是否默认使用路径风格访问：AWS 官方地址使用虚拟主机风格，其它自定义 Endpoint 使用路径风格
+func DefaultPathStyle(endpoint string) bool {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return false
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	return !(strings.HasSuffix(host, ".amazonaws.com") || strings.HasSuffix(host, ".amazonaws.com.cn"))
+}
+
+// Region 返回服务配置的区域，未配置时为 us-east-1
+func (sc *S3Client) Region() string {
+	if sc.region == "" {
+		return defaultRegion
+	}
+	return sc.region
+}
+
+// IsAnonymous 返回客户端是否为匿名访问
+func (sc *S3Client) IsAnonymous() bool {
+	return sc.anonymous
+}
+
+// requireCredentials 匿名访问时返回 ErrAnonymousReadOnly，用于在发送请求前拒绝写操作
+func (sc *S3Client) requireCredentials() error {
+	if sc.anonymous {
+		return ErrAnonymousReadOnly
+	}
+	return nil
+}
+
+// escapeKey 对对象键的每一段单独进行 URL 编码，保留路径分隔符。
+// 空格编码为 %20，"+" 编码为 %2B，避免 S3 将 "+" 解析为空格。
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.QueryEscape(segment), "+", "%20")
+	}
+	return strings.Join(segments, "/")
+}
+
+// copySource 构建 CopyObject 所需的 CopySource 参数（"bucket/key"，key 经过 URL 编码）
+func copySource(bucketName, key string) string {
+	return bucketName + "/" + escapeKey(key)
+}
+
+// PublicObjectURL 生成对象的公网访问地址
+// 根据路径风格/虚拟主机风格以及配置的 Endpoint 和区域构造 URL。
+// 注意：只有当对象或存储桶允许公开读取时，该地址才能在浏览器中直接访问。
+func (sc *S3Client) PublicObjectURL(bucketName, key string) string {
+	escapedKey := escapeKey(key)
+
+	scheme := "https"
+	host := "s3.amazonaws.com"
+	if sc.region != "" && sc.region != defaultRegion {
+		host = fmt.Sprintf("s3.%s.amazonaws.com", sc.region)
+	}
+	basePath := ""
+	if sc.endpoint != "" {
+		endpoint := sc.endpoint
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+			scheme = u.Scheme
+			host = u.Host
+			basePath = strings.TrimSuffix(u.Path, "/")
+		}
+	}
+
+	if sc.usePathStyle {
+		return fmt.Sprintf("%s://%s%s/%s/%s", scheme, host, basePath, bucketName, escapedKey)
+	}
+	return fmt.Sprintf("%s://%s.%s%s/%s", scheme, bucketName, host, basePath, escapedKey)
+}
+
+// ConsoleURL 生成在服务商 Web 控制台中打开指定存储桶和前缀的地址。
+//   - 配置了控制台地址且包含 {bucket}/{prefix} 占位符时，直接替换占位符；
+//   - 配置了控制台地址但没有占位符时，按 MinIO 控制台的路径格式拼接；
+//   - 未配置 Endpoint 时视为 AWS，生成 S3 控制台的深度链接；
+//   - 否则假定为 MinIO，并将 Endpoint 的 9000 端口替换为控制台默认的 9001 端口。
+func (sc *S3Client) ConsoleURL(bucketName, prefix string) (string, error) {
+	if sc.consoleURL != "" {
+		base := strings.TrimSuffix(sc.consoleURL, "/")
+		if strings.Contains(base, "{bucket}") || strings.Contains(base, "{prefix}") {
+			replacer := strings.NewReplacer("{bucket}", url.PathEscape(bucketName), "{prefix}", url.QueryEscape(prefix))
+			return replacer.Replace(base), nil
+		}
+		return minioConsoleURL(base, bucketName, prefix), nil
+	}
+
+	if sc.endpoint == "" {
+		query := url.Values{}
+		query.Set("region", sc.region)
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		return fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?%s", url.PathEscape(bucketName), query.Encode()), nil
+	}
+
+	endpoint := sc.endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("无法从 Endpoint 推断控制台地址，请在服务配置中填写控制台地址")
+	}
+	if u.Port() != "9000" {
+		return "", fmt.Errorf("无法从 Endpoint 推断控制台地址，请在服务配置中填写控制台地址")
+	}
+	base := fmt.Sprintf("%s://%s:9001", u.Scheme, u.Hostname())
+	return minioConsoleURL(base, bucketName, prefix), nil
+}
+
+// minioConsoleURL 按 MinIO 控制台的格式拼接浏览地址，前缀使用 Base64 编码
+func minioConsoleURL(base, bucketName, prefix string) string {
+	consoleURL := fmt.Sprintf("%s/browser/%s", base, url.PathEscape(bucketName))
+	if prefix != "" {
+		consoleURL += "/" + base64.StdEncoding.EncodeToString([]byte(prefix))
+	}
+	return consoleURL
+}
+
+// MaxPresignExpiry 是预签名地址允许的最长有效期（SigV4 的上限）
+const MaxPresignExpiry = 7 * 24 * time.Hour
+
+// ErrPresignAnonymous 表示匿名访问的服务无法生成预签名地址
+var ErrPresignAnonymous = errors.New("匿名访问模式下无法生成预签名链接，请使用公网地址")
+
+// PresignGetObject 生成对象的预签名下载地址，持有地址的人在有效期内无需凭证即可下载对象。
+// 有效期不能超过 MaxPresignExpiry。
+func (sc *S3Client) PresignGetObject(bucketName, key string, expiry time.Duration) (string, error) {
+	if sc.anonymous {
+		return "", ErrPresignAnonymous
+	}
+	if expiry <= 0 || expiry > MaxPresignExpiry {
+		return "", fmt.Errorf("无效的有效期: %v", expiry)
+	}
+	req, err := sc.presigner.PresignGetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("生成预签名链接失败: %w", err)
+	}
+	return req.URL, nil
 }
 
 // ListBuckets 列出所有存储桶
 func (sc *S3Client) ListBuckets() ([]string, error) {
-	output, err := sc.client.ListBuckets(context.TODO(), &s3.ListBucketsInput{})
+	ctx, cancel := listContext()
+	defer cancel()
+	output, err := sc.client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
-		return nil, fmt.Errorf("列出存储桶失败: %w", err)
+		return nil, fmt.Errorf("列出存储桶失败: %w", withTimeoutError(ctx, err))
 	}
 
 	var buckets []string
 	for _, bucket := range output.Buckets {
+		// 个别服务的响应格式不规范，没有名称的存储桶无法访问，直接跳过
+		if bucket.Name == nil {
+			continue
+		}
 		buckets = append(buckets, *bucket.Name)
 	}
 	return buckets, nil
 }
 
+// ConnectionTestTimeout 是测试连接时等待服务响应的最长时间
+const ConnectionTestTimeout = 10 * time.Second
+
+// TestConnection 通过列出存储桶检查 Endpoint 和凭证是否可用，返回存储桶数量。
+// 匿名访问的服务通常无权列出存储桶，此时只要服务返回了 S3 错误响应就视为连接成功，数量返回 -1。
+func (sc *S3Client) TestConnection() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ConnectionTestTimeout)
+	defer cancel()
+	output, err := sc.client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		var apiErr smithy.APIError
+		if sc.anonymous && errors.As(err, &apiErr) {
+			return -1, nil
+		}
+		return 0, fmt.Errorf("连接服务失败: %w", withTimeoutError(ctx, err))
+	}
+	return len(output.Buckets), nil
+}
+
 // S3Object 表示 S3 中的一个对象（文件或文件夹）
 type S3Object struct {
-	Name         string // 对象的简称 (例如 "file.txt" 或 "subfolder")
-	Key          string // 对象的完整 S3 Key
-	IsFolder     bool   // 是否是文件夹
-	Size         int64  // 文件大小 (字节)
-	LastModified string // 最后修改时间
+	Name         string    // 对象的简称 (例如 "file.txt" 或 "subfolder")
+	Key          string    // 对象的完整 S3 Key
+	IsFolder     bool      // 是否是文件夹
+	Size         int64     // 文件大小 (字节)
+	LastModified string    // 最后修改时间
+	ModifiedTime time.Time // 解析后的最后修改时间，网关未返回时为零值
+	ETag         string    // 对象的 ETag（已去除引号），分段上传的对象形如 "<md5>-<分段数>"
+	StorageClass string    // 存储类型，仅 WalkObjects 返回的对象会填充
 }
 
-// ListObjects 列出指定存储桶和前缀下的对象（分页）
-// 此方法会优先显示文件夹，然后再显示文件
-func (sc *S3Client) ListObjects(bucketName, prefix, marker string, pageSize int32) ([]S3Object, *string, error) {
-	// 如果 marker 为空，说明是第一页，我们需要获取所有对象然后重新分页
-	if marker == "" {
-		// 获取所有对象
-		allObjects, err := sc.ListAllObjectsUnderPrefix(bucketName, prefix)
-		if err != nil {
-			return nil, nil, fmt.Errorf("列出所有对象失败: %w", err)
-		}
-		
-		// 分离文件夹和文件
-		var folders, files []S3Object
-		for _, obj := range allObjects {
-			if obj.IsFolder {
-				folders = append(folders, obj)
-			} else {
-				files = append(files, obj)
+// formatLastModified 格式化对象的修改时间。部分网关不返回修改时间，此时返回空字符串。
+func formatLastModified(t *time.Time, layout string) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// IsMultipartETag 判断 ETag 是否来自分段上传（此时 ETag 不是文件内容的 MD5）
+func IsMultipartETag(etag string) bool {
+	return strings.Contains(etag, "-")
+}
+
+// SortObjects 按名称排序对象；foldersFirst 为 true 时文件夹排在文件前面，否则文件夹和文件混合排序
+func SortObjects(objects []S3Object, foldersFirst bool) {
+	SortObjectsBy(objects, SortOrder{FoldersFirst: foldersFirst})
+}
+
+// SortField 是对象列表的排序字段
+type SortField string
+
+const (
+	SortByName     SortField = "name"     // 按名称排序
+	SortBySize     SortField = "size"     // 按大小排序
+	SortByModified SortField = "modified" // 按修改时间排序
+)
+
+// SortOrder 描述对象列表的排序方式，零值表示按名称升序且文件夹和文件混合排序
+type SortOrder struct {
+	Field        SortField
+	Descending   bool
+	FoldersFirst bool // 文件夹始终排在文件前面，不受升序/降序影响
+}
+
+// SortObjectsBy 按 order 排序对象。文件夹没有大小和修改时间，按大小或时间排序时文件夹之间按名称排序；
+// 字段相同的对象也按名称排序。
+func SortObjectsBy(objects []S3Object, order SortOrder) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if order.FoldersFirst && a.IsFolder != b.IsFolder {
+			return a.IsFolder
+		}
+		c := 0
+		if !a.IsFolder && !b.IsFolder {
+			switch order.Field {
+			case SortBySize:
+				c = cmp.Compare(a.Size, b.Size)
+			case SortByModified:
+				c = a.ModifiedTime.Compare(b.ModifiedTime)
 			}
 		}
-		
-		// 合并文件夹和文件（文件夹在前）
-		allObjects = append(folders, files...)
-		
-		// 如果对象数量小于等于页面大小，直接返回所有对象
-		if int32(len(allObjects)) <= pageSize {
-			return allObjects, nil, nil
-		}
-		
-		// 返回第一页数据
-		nextMarker := fmt.Sprintf("page_2")
-		return allObjects[:pageSize], &nextMarker, nil
-	} else {
-		// 获取所有对象
-		allObjects, err := sc.ListAllObjectsUnderPrefix(bucketName, prefix)
+		if c == 0 {
+			c = strings.Compare(a.Name, b.Name)
+		}
+		if order.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// SetFoldersFirst 设置列出对象时文件夹是否排在文件前面
+func (sc *S3Client) SetFoldersFirst(foldersFirst bool) {
+	sc.foldersFirst.Store(foldersFirst)
+}
+
+// ListObjects 列出指定存储桶和前缀下的一页对象，marker 为空表示第一页。
+// 使用 ListObjectsV2 的 ContinuationToken 分页，返回的 marker 是服务端的 NextContinuationToken，没有下一页时为 nil。
+// 每页只请求 pageSize 个条目，文件夹（CommonPrefixes）和文件在页内按 SetFoldersFirst 设置的顺序排序。
+func (sc *S3Client) ListObjects(bucketName, prefix, marker string, pageSize int32) ([]S3Object, *string, error) {
+	if pageSize <= 0 {
+		return nil, nil, fmt.Errorf("无效的每页数量: %d", pageSize)
+	}
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucketName),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+		MaxKeys:   aws.Int32(pageSize),
+	}
+	if marker != "" {
+		input.ContinuationToken = aws.String(marker)
+	}
+
+	for {
+		ctx, cancel := listContext()
+		page, err := sc.client.ListObjectsV2(ctx, input)
+		err = withTimeoutError(ctx, err)
+		cancel()
 		if err != nil {
-			return nil, nil, fmt.Errorf("列出所有对象失败: %w", err)
-		}
-		
-		// 分离文件夹和文件
-		var folders, files []S3Object
-		for _, obj := range allObjects {
-			if obj.IsFolder {
-				folders = append(folders, obj)
-			} else {
-				files = append(files, obj)
-			}
+			return nil, nil, fmt.Errorf("列出对象失败: %w", err)
 		}
-		
-		// 合并文件夹和文件（文件夹在前）
-		allObjects = append(folders, files...)
-		
-		// 解析页码
-		var page int
-		fmt.Sscanf(marker, "page_%d", &page)
-		
-		// 计算起始索引
-		startIndex := int32((page - 1)) * pageSize
-		
-		// 如果起始索引超出范围，返回空
-		if startIndex >= int32(len(allObjects)) {
-			return []S3Object{}, nil, nil
-		}
-		
-		// 计算结束索引
-		endIndex := startIndex + pageSize
-		if endIndex > int32(len(allObjects)) {
-			endIndex = int32(len(allObjects))
-		}
-		
-		// 获取当前页数据
-		pageObjects := allObjects[startIndex:endIndex]
-		
-		// 如果还有更多页面，设置下一个标记
+
+		objects := dropFolderMarkers(objectsFromListPage(page, prefix, make(map[string]bool)))
 		var nextMarker *string
-		if endIndex < int32(len(allObjects)) {
-			nextMarkerStr := fmt.Sprintf("page_%d", page+1)
-			nextMarker = &nextMarkerStr
+		if aws.ToBool(page.IsTruncated) && aws.ToString(page.NextContinuationToken) != "" {
+			nextMarker = page.NextContinuationToken
+		}
+		// 只包含当前文件夹占位对象的页没有可显示的内容，继续读取下一页，避免显示空白页
+		if len(objects) == 0 && nextMarker != nil {
+			input.ContinuationToken = nextMarker
+			continue
 		}
-		
-		return pageObjects, nextMarker, nil
+		SortObjects(objects, sc.foldersFirst.Load())
+		return objects, nextMarker, nil
 	}
 }
 
-// UploadObject 上传文件到 S3
-func (sc *S3Client) UploadObject(bucketName, key string, reader io.Reader, size int64) error {
-	_, err := sc.client.PutObject(context.TODO(), &s3.PutObjectInput{
-		Bucket:        aws.String(bucketName),
-		Key:           aws.String(key),
-		Body:          reader,
-		ContentLength: &size,
+// UploadOptions 是上传对象时附加的请求头，字段为空时不设置
+type UploadOptions struct {
+	ContentType        string // 为空时根据对象键的扩展名推断
+	ContentDisposition string
+	CacheControl       string
+	StorageClass       string            // 为空时使用服务端默认的存储类别
+	Metadata           map[string]string // 用户元数据，键不含 x-amz-meta- 前缀
+}
+
+// StorageClasses 是上传时可选的存储类别，第一个为默认的标准存储
+var StorageClasses = []string{
+	string(s3types.StorageClassStandard),
+	string(s3types.StorageClassStandardIa),
+	string(s3types.StorageClassOnezoneIa),
+	string(s3types.StorageClassIntelligentTiering),
+	string(s3types.StorageClassGlacierIr),
+	string(s3types.StorageClassGlacier),
+	string(s3types.StorageClassDeepArchive),
+	string(s3types.StorageClassReducedRedundancy),
+}
+
+// contentTypeFor 根据对象键的扩展名推断 Content-Type，无法推断时返回空字符串，由服务端使用默认类型
+func contentTypeFor(key string) string {
+	return mime.TypeByExtension(strings.ToLower(path.Ext(key)))
+}
+
+// sniffLength 是根据内容推断 Content-Type 时读取的字节数，与 http.DetectContentType 使用的长度一致
+const sniffLength = 512
+
+// DetectContentType 根据对象键的扩展名推断 Content-Type，扩展名无法识别时读取 r 的前 512 字节推断，
+// 读取后 r 回到起始位置。仍无法推断时返回空字符串，由服务端使用默认类型。
+func DetectContentType(key string, r io.ReadSeeker) (string, error) {
+	if contentType := contentTypeFor(key); contentType != "" {
+		return contentType, nil
+	}
+	buf := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return "", nil
+	}
+	if contentType := http.DetectContentType(buf[:n]); contentType != "application/octet-stream" {
+		return contentType, nil
+	}
+	return "", nil
+}
+
+// putObjectInput 根据上传选项构造上传请求，不包含请求体
+func putObjectInput(bucketName, key string, opts UploadOptions) *s3.PutObjectInput {
+	input := &s3.PutObjectInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(key),
+		Metadata: opts.Metadata,
 		// 移除了 ChecksumAlgorithm 字段，让 SDK 使用默认行为
-	})
+	}
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = contentTypeFor(key)
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	if opts.ContentDisposition != "" {
+		input.ContentDisposition = aws.String(opts.ContentDisposition)
+	}
+	if opts.CacheControl != "" {
+		input.CacheControl = aws.String(opts.CacheControl)
+	}
+	// 不支持该存储类别的服务端会返回错误，由调用方显示，不在这里降级为默认类别
+	if opts.StorageClass != "" {
+		input.StorageClass = s3types.StorageClass(opts.StorageClass)
+	}
+	return input
+}
+
+// UploadObject 上传文件到 S3，ctx 被取消时中止上传。
+// reader 实现了 io.Seeker 时，遇到临时错误会回到开头重新上传，否则只上传一次，由调用方决定是否重试。
+func (sc *S3Client) UploadObject(ctx context.Context, bucketName, key string, reader io.Reader, size int64, opts UploadOptions) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	seeker, seekable := reader.(io.Seeker)
+	attempt := 0
+	upload := func() error {
+		if attempt++; attempt > 1 {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		input := putObjectInput(bucketName, key, opts)
+		input.ContentLength = &size
+
+		// 上传耗时取决于文件大小，使用空闲超时：超过超时时间没有读取到数据才取消
+		ctx, watchdog := newIdleContext(ctx)
+		defer watchdog.stop()
+		input.Body = watchdog.watchReader(reader)
+		_, err := sc.client.PutObject(ctx, input)
+		return watchdog.wrap(err)
+	}
+
+	var err error
+	if seekable {
+		err = withRetry(ctx, "上传 '"+key+"' ", upload)
+	} else {
+		err = upload()
+	}
 	if err != nil {
 		return fmt.Errorf("上传文件失败: %w", err)
 	}
 	return nil
 }
 
-// DownloadObject 从 S3 下载文件
-func (sc *S3Client) DownloadObject(bucketName, key string) (io.ReadCloser, error) {
-	output, err := sc.client.GetObject(context.TODO(), &s3.GetObjectInput{
+// DownloadObject 从 S3 下载文件，ctx 被取消时中止请求和响应体的读取。
+// 发起请求遇到临时错误时会重试；开始读取响应体后的错误由调用方处理。
+func (sc *S3Client) DownloadObject(ctx context.Context, bucketName, key string) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := withRetry(ctx, "下载 '"+key+"' ", func() error {
+		// 响应体的读取同样受空闲超时约束，关闭响应体时释放 context
+		ctx, watchdog := newIdleContext(ctx)
+		output, err := sc.client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			watchdog.stop()
+			return watchdog.wrap(err)
+		}
+		body = watchdog.watchBody(output.Body)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("下载文件失败: %w", err)
+	}
+	return body, nil
+}
+
+// ErrRangeNotSupported 表示服务端忽略了 Range 请求头，返回了完整对象
+var ErrRangeNotSupported = errors.New("服务端不支持范围下载")
+
+// DownloadObjectRange 下载对象的指定字节范围 [start, end]，end 小于 0 表示一直读取到对象末尾。
+// 如果服务端不支持范围请求，返回 ErrRangeNotSupported。
+func (sc *S3Client) DownloadObjectRange(ctx context.Context, bucketName, key string, start, end int64) (io.ReadCloser, error) {
+	rangeHeader := fmt.Sprintf("bytes=%d-", start)
+	if end >= 0 {
+		rangeHeader = fmt.Sprintf("bytes=%d-%d", start, end)
+	}
+	ctx, watchdog := newIdleContext(ctx)
+	output, err := sc.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucketName),
 		Key:    aws.String(key),
+		Range:  aws.String(rangeHeader),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("下载文件失败: %w", err)
+		watchdog.stop()
+		return nil, fmt.Errorf("范围下载文件失败: %w", watchdog.wrap(err))
 	}
-	return output.Body, nil
+	if aws.ToString(output.ContentRange) == "" {
+		output.Body.Close()
+		watchdog.stop()
+		return nil, ErrRangeNotSupported
+	}
+	return watchdog.watchBody(output.Body), nil
 }
 
-// DeleteObject 从 S3 删除对象 (文件或空文件夹) 或空文件夹
-func (sc *S3Client) DeleteObject(bucketName, key string) error {
-	_, err := sc.client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+// ObjectInfo 保存通过 HeadObject 获取的对象信息
+type ObjectInfo struct {
+	Size               int64
+	ETag               string
+	ContentType        string
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	LastModified       time.Time
+	StorageClass       string
+	Metadata           map[string]string
+}
+
+// StatObject 通过 HeadObject 获取对象的大小、ETag 等信息
+func (sc *S3Client) StatObject(bucketName, key string) (*ObjectInfo, error) {
+	ctx, cancel := operationContext()
+	defer cancel()
+	output, err := sc.client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(bucketName),
 		Key:    aws.String(key),
 	})
+	if err != nil {
+		return nil, fmt.Errorf("获取对象信息失败: %w", withTimeoutError(ctx, err))
+	}
+	return &ObjectInfo{
+		Size:               aws.ToInt64(output.ContentLength),
+		ETag:               strings.Trim(aws.ToString(output.ETag), "\""),
+		ContentType:        aws.ToString(output.ContentType),
+		CacheControl:       aws.ToString(output.CacheControl),
+		ContentDisposition: aws.ToString(output.ContentDisposition),
+		ContentEncoding:    aws.ToString(output.ContentEncoding),
+		ContentLanguage:    aws.ToString(output.ContentLanguage),
+		LastModified:       aws.ToTime(output.LastModified),
+		StorageClass:       string(output.StorageClass),
+		Metadata:           output.Metadata,
+	}, nil
+}
+
+// MaxObjectTags 是 S3 允许单个对象设置的最大标签数量
+const MaxObjectTags = 10
+
+// GetObjectTags 获取对象的标签
+func (sc *S3Client) GetObjectTags(bucketName, key string) (map[string]string, error) {
+	ctx, cancel := operationContext()
+	defer cancel()
+	output, err := sc.client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取对象标签失败: %w", withTimeoutError(ctx, err))
+	}
+
+	tags := make(map[string]string, len(output.TagSet))
+	for _, tag := range output.TagSet {
+		if tag.Key != nil {
+			tags[*tag.Key] = aws.ToString(tag.Value)
+		}
+	}
+	return tags, nil
+}
+
+// PutObjectTags 用 tags 替换对象的全部标签
+func (sc *S3Client) PutObjectTags(bucketName, key string, tags map[string]string) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+
+	tagSet := make([]s3types.Tag, 0, len(tags))
+	for k, v := range tags {
+		tagSet = append(tagSet, s3types.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	sort.Slice(tagSet, func(i, j int) bool { return *tagSet[i].Key < *tagSet[j].Key })
+
+	ctx, cancel := operationContext()
+	defer cancel()
+	_, err := sc.client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+		Bucket:  aws.String(bucketName),
+		Key:     aws.String(key),
+		Tagging: &s3types.Tagging{TagSet: tagSet},
+	})
+	if err != nil {
+		return fmt.Errorf("设置对象标签失败: %w", withTimeoutError(ctx, err))
+	}
+	return nil
+}
+
+// DeleteObject 从 S3 删除对象 (文件或空文件夹) 或空文件夹
+func (sc *S3Client) DeleteObject(ctx context.Context, bucketName, key string) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	err := withRetry(ctx, "删除 '"+key+"' ", func() error {
+		ctx, cancel := operationContextFrom(ctx)
+		defer cancel()
+		_, err := sc.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(key),
+		})
+		return withTimeoutError(ctx, err)
+	})
 	if err != nil {
 		return fmt.Errorf("删除对象失败: %w", err)
 	}
 	return nil
 }
 
-// CreateBucket 创建存储桶
-func (sc *S3Client) CreateBucket(bucketName string) error {
-	_, err := sc.client.CreateBucket(context.TODO(), &s3.CreateBucketInput{
+// MaxDeleteBatchSize 是单次 DeleteObjects 请求允许的最大对象数
+const MaxDeleteBatchSize = 1000
+
+// ObjectVersion 表示对象的一个版本或删除标记，VersionID 为空时表示对象的当前版本
+type ObjectVersion struct {
+	Key       string
+	VersionID string
+}
+
+// ListObjectVersions 列出存储桶中指定前缀下的所有对象版本和删除标记。
+// 如果服务端不支持版本列举，则退回为列出当前对象。
+func (sc *S3Client) ListObjectVersions(bucketName, prefix string) ([]ObjectVersion, error) {
+	var versions []ObjectVersion
+	paginator := s3.NewListObjectVersionsPaginator(sc.client, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
+		if err != nil {
+			if len(versions) == 0 && IsNotImplementedError(err) {
+				keys, listErr := sc.ListAllKeysUnderPrefix(bucketName, prefix)
+				if listErr != nil {
+					return nil, listErr
+				}
+				for _, key := range keys {
+					versions = append(versions, ObjectVersion{Key: key})
+				}
+				return versions, nil
+			}
+			return nil, fmt.Errorf("列出对象版本失败: %w", err)
+		}
+		for _, v := range page.Versions {
+			versions = append(versions, ObjectVersion{Key: aws.ToString(v.Key), VersionID: aws.ToString(v.VersionId)})
+		}
+		for _, m := range page.DeleteMarkers {
+			versions = append(versions, ObjectVersion{Key: aws.ToString(m.Key), VersionID: aws.ToString(m.VersionId)})
+		}
+	}
+	return versions, nil
+}
+
+// DeleteObjectVersions 使用 DeleteObjects 批量删除对象版本，每批最多 MaxDeleteBatchSize 个。
+// 返回删除失败的对象键。ctx 被取消时不再发送后续批次。
+func (sc *S3Client) DeleteObjectVersions(ctx context.Context, bucketName string, versions []ObjectVersion) ([]string, error) {
+	if err := sc.requireCredentials(); err != nil {
+		return nil, err
+	}
+	var failed []string
+	for start := 0; start < len(versions); start += MaxDeleteBatchSize {
+		end := start + MaxDeleteBatchSize
+		if end > len(versions) {
+			end = len(versions)
+		}
+
+		identifiers := make([]s3types.ObjectIdentifier, 0, end-start)
+		for _, v := range versions[start:end] {
+			identifier := s3types.ObjectIdentifier{Key: aws.String(v.Key)}
+			if v.VersionID != "" {
+				identifier.VersionId = aws.String(v.VersionID)
+			}
+			identifiers = append(identifiers, identifier)
+		}
+
+		batchCtx, cancel := listContextFrom(ctx) // 单次最多删除 1000 个对象，使用较长的超时
+		output, err := sc.client.DeleteObjects(batchCtx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &s3types.Delete{
+				Objects: identifiers,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		err = withTimeoutError(batchCtx, err)
+		cancel()
+		if err != nil {
+			return failed, fmt.Errorf("批量删除对象失败: %w", err)
+		}
+		for _, e := range output.Errors {
+			failed = append(failed, aws.ToString(e.Key))
+		}
+	}
+	return failed, nil
+}
+
+// DeleteObjects 批量删除对象的当前版本，返回删除失败的对象键
+func (sc *S3Client) DeleteObjects(ctx context.Context, bucketName string, keys []string) ([]string, error) {
+	versions := make([]ObjectVersion, len(keys))
+	for i, key := range keys {
+		versions[i] = ObjectVersion{Key: key}
+	}
+	return sc.DeleteObjectVersions(ctx, bucketName, versions)
+}
+
+// MultipartUpload 是存储桶中一个未完成的分段上传，已上传的分段会一直占用存储空间直到上传完成或被中止
+type MultipartUpload struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+	Parts     int   // 已上传的分段数量
+	Size      int64 // 已上传分段的总大小
+}
+
+// ListMultipartUploads 列出存储桶中所有未完成的分段上传，并统计每个上传已上传的分段数量和大小
+func (sc *S3Client) ListMultipartUploads(bucketName string) ([]MultipartUpload, error) {
+	var uploads []MultipartUpload
+	paginator := s3.NewListMultipartUploadsPaginator(sc.client, &s3.ListMultipartUploadsInput{
 		Bucket: aws.String(bucketName),
 	})
+	for paginator.HasMorePages() {
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("列出分段上传失败: %w", err)
+		}
+		for _, u := range page.Uploads {
+			upload := MultipartUpload{Key: aws.ToString(u.Key), UploadID: aws.ToString(u.UploadId)}
+			if u.Initiated != nil {
+				upload.Initiated = *u.Initiated
+			}
+			if err := sc.countUploadedParts(bucketName, &upload); err != nil {
+				return nil, err
+			}
+			uploads = append(uploads, upload)
+		}
+	}
+	return uploads, nil
+}
+
+// countUploadedParts 统计分段上传已上传的分段数量和总大小
+func (sc *S3Client) countUploadedParts(bucketName string, upload *MultipartUpload) error {
+	paginator := s3.NewListPartsPaginator(sc.client, &s3.ListPartsInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(upload.Key),
+		UploadId: aws.String(upload.UploadID),
+	})
+	for paginator.HasMorePages() {
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("列出分段上传 '%s' 的分段失败: %w", upload.Key, err)
+		}
+		for _, part := range page.Parts {
+			upload.Parts++
+			upload.Size += aws.ToInt64(part.Size)
+		}
+	}
+	return nil
+}
+
+// AbortMultipartUpload 中止未完成的分段上传，服务端会删除已上传的分段
+func (sc *S3Client) AbortMultipartUpload(bucketName, key, uploadID string) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	ctx, cancel := operationContext()
+	defer cancel()
+	_, err := sc.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("中止分段上传失败: %w", withTimeoutError(ctx, err))
+	}
+	return nil
+}
+
+// CreateBucket 在指定区域创建存储桶，region 为空或为 us-east-1 时不指定区域
+func (sc *S3Client) CreateBucket(bucketName, region string) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	input := &s3.CreateBucketInput{
+		Bucket: aws.String(bucketName),
+	}
+	// us-east-1 不能作为 LocationConstraint，区域为空时不指定，由服务使用默认区域（MinIO 等服务）
+	if region = strings.TrimSpace(region); region != "" && region != defaultRegion {
+		input.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{
+			LocationConstraint: s3types.BucketLocationConstraint(region),
+		}
+	}
+	ctx, cancel := operationContext()
+	defer cancel()
+	_, err := sc.client.CreateBucket(ctx, input)
 	if err != nil {
-		return fmt.Errorf("创建存储桶失败: %w", err)
+		return fmt.Errorf("创建存储桶失败: %w", withTimeoutError(ctx, err))
 	}
 	return nil
 }
 
 // DeleteBucket 删除存储桶
 func (sc *S3Client) DeleteBucket(bucketName string) error {
-	_, err := sc.client.DeleteBucket(context.TODO(), &s3.DeleteBucketInput{
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	ctx, cancel := operationContext()
+	defer cancel()
+	_, err := sc.client.DeleteBucket(ctx, &s3.DeleteBucketInput{
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
-		return fmt.Errorf("删除存储桶失败: %w", err)
+		return fmt.Errorf("删除存储桶失败: %w", withTimeoutError(ctx, err))
 	}
 	return nil
 }
@@ -249,110 +990,204 @@ func (sc *S3Client) IsBucketEmpty(bucketName string) (bool, error) {
 		Bucket:  aws.String(bucketName),
 		MaxKeys: aws.Int32(1), // 只请求一个对象，用于判断是否为空
 	}
-	output, err := sc.client.ListObjectsV2(context.TODO(), input)
+	ctx, cancel := operationContext()
+	defer cancel()
+	output, err := sc.client.ListObjectsV2(ctx, input)
 	if err != nil {
-		return false, fmt.Errorf("检查存储桶是否为空失败: %w", err)
+		return false, fmt.Errorf("检查存储桶是否为空失败: %w", withTimeoutError(ctx, err))
 	}
 	return len(output.Contents) == 0 && len(output.CommonPrefixes) == 0, nil
 }
 
+// PrefixExists 检查前缀下是否存在任何对象（包括文件夹占位对象），用于判断文件夹是否存在
+func (sc *S3Client) PrefixExists(bucketName, prefix string) (bool, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucketName),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(1),
+	}
+	ctx, cancel := operationContext()
+	defer cancel()
+	output, err := sc.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return false, fmt.Errorf("检查文件夹是否存在失败: %w", withTimeoutError(ctx, err))
+	}
+	return len(output.Contents) > 0, nil
+}
+
 // CreateFolder 在 S3 中创建一个文件夹（即一个以 / 结尾的 0 字节对象）
 func (sc *S3Client) CreateFolder(bucketName, key string) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
 	// 确保 key 以 / 结尾
 	if !strings.HasSuffix(key, "/") {
 		key += "/"
 	}
 
-	_, err := sc.client.PutObject(context.TODO(), &s3.PutObjectInput{
+	ctx, cancel := operationContext()
+	defer cancel()
+	_, err := sc.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(bucketName),
 		Key:    aws.String(key),
 		Body:   strings.NewReader(""), // 空内容
 	})
 
 	if err != nil {
-		return fmt.Errorf("创建文件夹失败: %w", err)
+		return fmt.Errorf("创建文件夹失败: %w", withTimeoutError(ctx, err))
 	}
 	return nil
 }
 
 // ListAllObjectsUnderPrefix 递归地列出指定前缀下的所有对象（包括文件和文件夹）
 func (sc *S3Client) ListAllObjectsUnderPrefix(bucketName, prefix string) ([]S3Object, error) {
+	return sc.listAllObjects(bucketName, prefix, "/")
+}
+
+// ListAllObjectsFlat 以平铺方式列出指定前缀下的所有文件（不使用分隔符，不返回文件夹）。
+// 返回对象的 Name 为相对于前缀的完整路径，适用于不以 "/" 组织 key 的存储桶。
+func (sc *S3Client) ListAllObjectsFlat(bucketName, prefix string) ([]S3Object, error) {
+	return sc.listAllObjects(bucketName, prefix, "")
+}
+
+// listAllObjects 列出前缀下的所有对象，delimiter 为空时不按文件夹分组
+func (sc *S3Client) listAllObjects(bucketName, prefix, delimiter string) ([]S3Object, error) {
 	var objects []S3Object
-	paginator := s3.NewListObjectsV2Paginator(sc.client, &s3.ListObjectsV2Input{
-		Bucket:    aws.String(bucketName),
-		Prefix:    aws.String(prefix),
-		Delimiter: aws.String("/"), // 添加分隔符以识别文件夹
-	})
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	}
+	if delimiter != "" {
+		input.Delimiter = aws.String(delimiter) // 添加分隔符以识别文件夹
+	}
+	paginator := s3.NewListObjectsV2Paginator(sc.client, input)
 
 	processedKeys := make(map[string]bool) // 用于跟踪已处理的键，避免重复
 
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(context.TODO())
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
 		if err != nil {
 			return nil, fmt.Errorf("列出对象失败: %w", err)
 		}
 
-		// 处理 CommonPrefixes (文件夹)
-		for _, commonPrefix := range page.CommonPrefixes {
-			fullKey := *commonPrefix.Prefix
-			// 避免重复处理
-			if processedKeys[fullKey] {
-				continue
-			}
-			processedKeys[fullKey] = true
+		objects = append(objects, objectsFromListPage(page, prefix, processedKeys)...)
+	}
+	// 表示文件夹的 0 字节对象可能与文件夹出现在不同的页中，合并所有页后再去重
+	objects = dropFolderMarkers(objects)
 
-			name := strings.TrimSuffix(fullKey, "/")
-			if prefix != "" {
-				name = strings.TrimPrefix(name, prefix)
-			}
+	// 按名称排序，默认将文件夹放在前面
+	SortObjects(objects, sc.foldersFirst.Load())
 
-			objects = append(objects, S3Object{
-				Name:     name,
-				Key:      fullKey,
-				IsFolder: true,
-			})
+	return objects, nil
+}
+
+// objectsFromListPage 将一页列举结果转换为对象列表。文件夹的 Name 不带结尾的 "/"，
+// 当前文件夹自身和其它工具创建的占位对象被忽略；seen 记录已处理的键，跨页合并时用于去重。
+func objectsFromListPage(page *s3.ListObjectsV2Output, prefix string, seen map[string]bool) []S3Object {
+	var objects []S3Object
+	// 处理 CommonPrefixes (文件夹)
+	for _, commonPrefix := range page.CommonPrefixes {
+		if commonPrefix.Prefix == nil {
+			continue
+		}
+		fullKey := *commonPrefix.Prefix
+		// 避免重复处理
+		if seen[fullKey] {
+			continue
 		}
+		seen[fullKey] = true
 
-		// 处理 Contents (文件)
-		for _, content := range page.Contents {
-			fullKey := *content.Key
-			// 避免重复处理
-			if processedKeys[fullKey] {
-				continue
-			}
-			processedKeys[fullKey] = true
+		name := strings.TrimSuffix(fullKey, "/")
+		if prefix != "" {
+			name = strings.TrimPrefix(name, prefix)
+		}
 
-			// 忽略 S3 中的"文件夹"占位符对象（key 以 / 结尾且大小为 0）
-			if strings.HasSuffix(fullKey, "/") && *content.Size == 0 {
-				continue
-			}
+		objects = append(objects, S3Object{
+			Name:     name,
+			Key:      fullKey,
+			IsFolder: true,
+		})
+	}
 
-			// 提取文件名，去除前缀
-			fileName := strings.TrimPrefix(fullKey, prefix)
-			objects = append(objects, S3Object{
-				Name:         fileName,
-				Key:          fullKey,
-				IsFolder:     false,
-				Size:         *content.Size,
-				LastModified: content.LastModified.Format("2006-01-02 15:04:05"),
-			})
+	// 处理 Contents (文件)
+	for _, content := range page.Contents {
+		if content.Key == nil {
+			continue
 		}
+		fullKey := *content.Key
+		// 避免重复处理
+		if seen[fullKey] {
+			continue
+		}
+		seen[fullKey] = true
+
+		// 忽略其它工具创建的"文件夹"占位对象，文件夹只根据 CommonPrefixes 生成
+		size := aws.ToInt64(content.Size) // 部分网关不返回大小，视为 0
+		if isFolderPlaceholder(fullKey, size) {
+			continue
+		}
+
+		// 提取文件名，去除前缀
+		fileName := strings.TrimPrefix(fullKey, prefix)
+		objects = append(objects, S3Object{
+			Name:         fileName,
+			Key:          fullKey,
+			IsFolder:     false,
+			Size:         size,
+			LastModified: formatLastModified(content.LastModified, "2006-01-02 15:04:05"),
+			ModifiedTime: aws.ToTime(content.LastModified),
+			ETag:         strings.Trim(aws.ToString(content.ETag), "\""),
+		})
 	}
+	return objects
+}
 
-	// 对对象进行排序，将文件夹放在前面，然后按名称排序
-	sort.Slice(objects, func(i, j int) bool {
-		// 如果一个是文件夹，另一个是文件，则文件夹排在前面
-		if objects[i].IsFolder && !objects[j].IsFolder {
-			return true
+// WalkObjects 以平铺方式逐页列出前缀下的所有文件，并对每个对象调用 fn，不会在内存中缓存整个列表。
+// ctx 被取消或 fn 返回错误时停止列举并返回该错误。文件夹占位对象会被忽略。
+func (sc *S3Client) WalkObjects(ctx context.Context, bucketName, prefix string, fn func(obj S3Object) error) error {
+	paginator := s3.NewListObjectsV2Paginator(sc.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		pageCtx, cancel := listContextFrom(ctx)
+		page, err := paginator.NextPage(pageCtx)
+		err = withTimeoutError(pageCtx, err)
+		cancel()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
 		}
-		if !objects[i].IsFolder && objects[j].IsFolder {
-			return false
+		if err != nil {
+			return fmt.Errorf("列出对象失败: %w", err)
 		}
-		// 如果两个都是文件夹或都是文件，则按名称排序
-		return objects[i].Name < objects[j].Name
-	})
 
-	return objects, nil
+		for _, content := range page.Contents {
+			if content.Key == nil {
+				continue
+			}
+			fullKey := *content.Key
+			if isFolderPlaceholder(fullKey, aws.ToInt64(content.Size)) {
+				continue
+			}
+			obj := S3Object{
+				Name:         strings.TrimPrefix(fullKey, prefix),
+				Key:          fullKey,
+				Size:         aws.ToInt64(content.Size),
+				ETag:         strings.Trim(aws.ToString(content.ETag), "\""),
+				StorageClass: string(content.StorageClass),
+			}
+			obj.LastModified = formatLastModified(content.LastModified, time.RFC3339)
+			obj.ModifiedTime = aws.ToTime(content.LastModified)
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // ListAllKeysUnderPrefix 递归地列出指定前缀下的所有对象键（文件和文件夹标记）。
@@ -364,67 +1199,172 @@ func (sc *S3Client) ListAllKeysUnderPrefix(bucketName, prefix string) ([]string,
 	})
 
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(context.TODO())
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
 		if err != nil {
 			return nil, fmt.Errorf("列出对象键失败: %w", err)
 		}
 
 		for _, content := range page.Contents {
-			keys = append(keys, *content.Key)
+			if content.Key != nil {
+				keys = append(keys, *content.Key)
+			}
 		}
 	}
 	return keys, nil
 }
 
+// CountObjectsUnderPrefix 统计前缀下（包括所有子目录）的对象数量，不包括文件夹占位对象本身
+func (sc *S3Client) CountObjectsUnderPrefix(bucketName, prefix string) (int, error) {
+	count := 0
+	paginator := s3.NewListObjectsV2Paginator(sc.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
+		if err != nil {
+			return 0, fmt.Errorf("统计对象数量失败: %w", err)
+		}
+
+		for _, content := range page.Contents {
+			if aws.ToString(content.Key) != prefix {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
 // CopyObject 在同一个存储桶内复制对象
 func (sc *S3Client) CopyObject(bucketName, sourceKey, targetKey string) error {
-	// 构建源对象的完整路径
-	source := fmt.Sprintf("%s/%s", bucketName, sourceKey)
-	
-	_, err := sc.client.CopyObject(context.TODO(), &s3.CopyObjectInput{
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	// 构建源对象的完整路径，key 中的空格、"+"、"#" 及非 ASCII 字符需要编码
+	source := copySource(bucketName, sourceKey)
+
+	input := &s3.CopyObjectInput{
 		Bucket:     aws.String(bucketName),
 		CopySource: aws.String(source),
 		Key:        aws.String(targetKey),
+	}
+
+	// 部分服务在默认的 COPY 指令下会丢失或重置 Content-Type，
+	// 因此先读取源对象的元数据，再以 REPLACE 指令显式写回。
+	if sc.PreserveMetadataOnCopy {
+		info, err := sc.StatObject(bucketName, sourceKey)
+		if err != nil {
+			log.Printf("读取源对象元数据失败，使用默认方式复制 '%s': %v", sourceKey, err)
+		} else {
+			input.MetadataDirective = s3types.MetadataDirectiveReplace
+			input.Metadata = info.Metadata
+			if info.ContentType != "" {
+				input.ContentType = aws.String(info.ContentType)
+			}
+			if info.CacheControl != "" {
+				input.CacheControl = aws.String(info.CacheControl)
+			}
+			if info.ContentDisposition != "" {
+				input.ContentDisposition = aws.String(info.ContentDisposition)
+			}
+			if info.ContentEncoding != "" {
+				input.ContentEncoding = aws.String(info.ContentEncoding)
+			}
+			if info.ContentLanguage != "" {
+				input.ContentLanguage = aws.String(info.ContentLanguage)
+			}
+		}
+	}
+
+	err := withRetry(context.Background(), "复制 '"+sourceKey+"' ", func() error {
+		// 服务端复制大文件需要更长时间，使用列举请求的超时
+		ctx, cancel := listContext()
+		defer cancel()
+		_, err := sc.client.CopyObject(ctx, input)
+		return withTimeoutError(ctx, err)
 	})
-	
 	if err != nil {
 		return fmt.Errorf("复制对象失败: %w", err)
 	}
 	return nil
 }
 
-// ObjectExists 检查对象是否存在于存储桶中
+// ObjectExists 检查对象是否存在于存储桶中。
+// 服务配置为不区分大小写时，仅大小写不同的对象或文件夹也视为已存在。
 func (sc *S3Client) ObjectExists(bucketName, key string) (bool, error) {
 	// 如果键为空，直接返回false
 	if key == "" {
 		return false, nil
 	}
+	if sc.ignoreCase {
+		return sc.objectExistsIgnoreCase(bucketName, key)
+	}
 	
-	_, err := sc.client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+	ctx, cancel := operationContext()
+	defer cancel()
+	_, err := sc.client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(bucketName),
 		Key:    aws.String(key),
 	})
+	err = withTimeoutError(ctx, err)
 	
 	if err != nil {
-		// 检查是否是因为对象不存在导致的错误
-		var noSuchKey *s3types.NoSuchKey
-		if errors.As(err, &noSuchKey) {
-			return false, nil // 对象不存在，但不是错误
+		// 超时不能被当作对象不存在，否则可能覆盖已有对象
+		if IsTimeoutError(err) {
+			return false, fmt.Errorf("检查对象是否存在失败: %w", err)
 		}
-		
-		// 检查是否包含404错误
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "NotFound") {
+
+		if isNotFoundError(err) {
 			return false, nil // 对象不存在，但不是错误
 		}
-		
-		// 检查是否包含400错误
-		if strings.Contains(err.Error(), "400") || strings.Contains(err.Error(), "BadRequest") {
-			// 400错误通常意味着键格式不正确，我们也认为对象不存在
-			return false, nil
-		}
-		
+
 		return false, fmt.Errorf("检查对象是否存在时出错: %w", err)
 	}
 	
 	return true, nil // 对象存在
 }
+
+// parentPrefix 返回对象键所在目录的前缀，根目录返回空字符串
+func parentPrefix(key string) string {
+	return key[:strings.LastIndex(strings.TrimSuffix(key, "/"), "/")+1]
+}
+
+// objectExistsIgnoreCase 列出对象键所在的目录，以不区分大小写的方式比较对象键和子目录前缀。
+// 用于模拟不区分大小写的网关：在这类服务上 file.TXT 和 file.txt 指向同一个对象。
+func (sc *S3Client) objectExistsIgnoreCase(bucketName, key string) (bool, error) {
+	paginator := s3.NewListObjectsV2Paginator(sc.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucketName),
+		Prefix:    aws.String(parentPrefix(key)),
+		Delimiter: aws.String("/"),
+	})
+
+	for paginator.HasMorePages() {
+		ctx, cancel := listContext()
+		page, err := paginator.NextPage(ctx)
+		err = withTimeoutError(ctx, err)
+		cancel()
+		if err != nil {
+			return false, fmt.Errorf("检查对象是否存在失败: %w", err)
+		}
+
+		for _, content := range page.Contents {
+			if strings.EqualFold(aws.ToString(content.Key), key) {
+				return true, nil
+			}
+		}
+		for _, commonPrefix := range page.CommonPrefixes {
+			// 文件夹键以 "/" 结尾，与子目录前缀比较
+			if strings.EqualFold(aws.ToString(commonPrefix.Prefix), key) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,66 @@
+package s3client
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// NormalizeEndpoint 规范化用户填写的 Endpoint：去掉首尾空白和末尾的斜杠，没有协议时补全协议。
+// 本机、局域网地址和不带点的主机名（如 Docker 中的 minio）补全为 http://，其它地址补全为 https://。
+// 地址明显无效时返回说明原因的错误；空字符串表示使用 AWS 官方地址，原样返回。
+func NormalizeEndpoint(endpoint string) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return "", nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = defaultEndpointScheme(endpoint) + "://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.New("Endpoint 格式无效")
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		u.Scheme = strings.ToLower(u.Scheme)
+	default:
+		return "", errors.New("Endpoint 只支持 http:// 或 https:// 协议")
+	}
+	if u.Hostname() == "" || strings.ContainsAny(u.Host, " \t") {
+		return "", errors.New("Endpoint 缺少主机名")
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", errors.New("Endpoint 不能包含用户名、查询参数或锚点")
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// defaultEndpointScheme 返回没有填写协议的 Endpoint 应使用的协议
+func defaultEndpointScheme(endpoint string) string {
+	host := endpoint
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
+			return "http"
+		}
+		return "https"
+	}
+	if !strings.Contains(host, ".") {
+		return "http"
+	}
+	return "https"
+}
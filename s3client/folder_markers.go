@@ -0,0 +1,37 @@
+package s3client
+
+import "strings"
+
+// hadoopFolderSuffix 是 Hadoop S3N 等工具为文件夹创建的占位对象的后缀，如 logs_$folder$ 表示文件夹 logs/
+const hadoopFolderSuffix = "_$folder$"
+
+// isFolderPlaceholder 判断列举结果中的对象是否为其它工具创建的文件夹占位对象，这些对象不应显示为文件。
+// 以 "/" 结尾的键总是文件夹占位对象（部分工具会写入非空内容）；0 字节的 xxx_$folder$ 对象同样是占位对象。
+// 文件夹本身只根据 CommonPrefixes 生成，因此只有占位对象而没有内容的文件夹不会显示。
+func isFolderPlaceholder(key string, size int64) bool {
+	return strings.HasSuffix(key, "/") || (size == 0 && strings.HasSuffix(key, hadoopFolderSuffix))
+}
+
+// dropFolderMarkers 删除与文件夹同名的 0 字节对象。部分工具用不带 "/" 的 0 字节对象（如 photos）表示文件夹 photos/，
+// 列表中已有同名文件夹或该文件夹下的文件时，这个对象不再显示为文件。
+func dropFolderMarkers(objects []S3Object) []S3Object {
+	dirs := make(map[string]bool)
+	for _, obj := range objects {
+		key := strings.TrimSuffix(obj.Key, "/")
+		if obj.IsFolder {
+			dirs[key] = true
+		}
+		for i := strings.LastIndex(key, "/"); i > 0; i = strings.LastIndex(key[:i], "/") {
+			dirs[key[:i]] = true
+		}
+	}
+
+	kept := objects[:0]
+	for _, obj := range objects {
+		if !obj.IsFolder && obj.Size == 0 && dirs[obj.Key] {
+			continue
+		}
+		kept = append(kept, obj)
+	}
+	return kept
+}
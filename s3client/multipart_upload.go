@@ -0,0 +1,76 @@
+package s3client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+)
+
+const (
+	// MultipartUploadThreshold 是使用分段上传的文件大小阈值，超过该大小的文件使用 UploadLargeObject 上传
+	MultipartUploadThreshold = 64 << 20
+	// DefaultPartSize 是分段上传的默认分段大小
+	DefaultPartSize = 16 << 20
+	// DefaultUploadConcurrency 是分段上传时默认同时上传的分段数量
+	DefaultUploadConcurrency = 4
+	// MaxPartSize 是 S3 允许的最大分段大小
+	MaxPartSize = 5 << 30
+)
+
+// 分段上传的配置在界面保存设置时修改，后台上传同时读取，因此使用原子变量
+var (
+	// partSize 是分段上传的分段大小，在 S3 允许的最小和最大分段之间
+	partSize atomic.Int64
+	// uploadConcurrency 是分段上传时同时上传的分段数量，每个分段在内存中缓存一份
+	uploadConcurrency atomic.Int64
+)
+
+func init() {
+	SetMultipartUploadConfig(DefaultPartSize, DefaultUploadConcurrency)
+}
+
+// SetMultipartUploadConfig 设置分段上传的分段大小和并发数。分段大小被限制在 5 MiB–5 GiB 之间，
+// 并发数小于等于 0 时恢复默认值。
+func SetMultipartUploadConfig(size int64, concurrency int) {
+	size = min(max(size, manager.MinUploadPartSize), MaxPartSize)
+	if concurrency <= 0 {
+		concurrency = DefaultUploadConcurrency
+	}
+	partSize.Store(size)
+	uploadConcurrency.Store(int64(concurrency))
+}
+
+// partSizeFor 返回上传 size 字节时使用的分段大小。分段数量不能超过 S3 的上限，超过时增大分段。
+func partSizeFor(size int64) int64 {
+	maxParts := int64(manager.MaxUploadParts)
+	configured := partSize.Load()
+	if size/configured >= maxParts {
+		return size/(maxParts-1) + 1
+	}
+	return configured
+}
+
+// UploadLargeObject 使用分段上传将 r 中的 size 字节上传到 S3，各分段并发上传，内存中最多缓存并发数加一个分段。
+// 上传失败或 ctx 被取消时已上传的分段会被中止，不会留下未完成的分段上传。
+func (sc *S3Client) UploadLargeObject(ctx context.Context, bucketName, key string, r io.Reader, size int64, opts UploadOptions) error {
+	if err := sc.requireCredentials(); err != nil {
+		return err
+	}
+	uploader := manager.NewUploader(sc.client, func(u *manager.Uploader) {
+		u.PartSize = partSizeFor(size)
+		u.Concurrency = int(uploadConcurrency.Load())
+	})
+
+	// 与单次上传相同，使用空闲超时：超过超时时间没有读取到数据才取消
+	ctx, watchdog := newIdleContext(ctx)
+	defer watchdog.stop()
+	input := putObjectInput(bucketName, key, opts)
+	input.Body = watchdog.watchReader(r)
+	if _, err := uploader.Upload(ctx, input); err != nil {
+		return fmt.Errorf("分段上传文件失败: %w", watchdog.wrap(err))
+	}
+	return nil
+}
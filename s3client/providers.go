@@ -0,0 +1,47 @@
+package s3client
+
+import "strings"
+
+// Provider 描述一种常见的 S3 兼容服务及其推荐的连接设置
+type Provider struct {
+	ID             string // 保存在服务配置中的标识
+	Name           string // 显示名称
+	EndpointFormat string // Endpoint 模板，{region} 会被替换为区域；为空表示需要用户自行填写
+	DefaultRegion  string // 默认区域
+	PathStyle      bool   // 是否使用路径风格访问
+	// ChecksumWhenRequired 只在操作必需时才计算和校验 CRC 校验和。
+	// 新版 SDK 默认为所有上传附加校验和，部分兼容服务不支持这些请求头或 aws-chunked 编码。
+	ChecksumWhenRequired bool
+}
+
+// ProviderCustom 是自定义服务的标识，旧版本保存的服务没有服务类型，也视为自定义
+const ProviderCustom = "custom"
+
+// Providers 是添加服务时可选的服务类型，最后一项为自定义
+var Providers = []Provider{
+	{ID: "aws", Name: "AWS S3", EndpointFormat: "https://s3.{region}.amazonaws.com", DefaultRegion: "us-east-1"},
+	{ID: "minio", Name: "MinIO", EndpointFormat: "http://localhost:9000", DefaultRegion: "us-east-1", PathStyle: true},
+	{ID: "aliyun", Name: "阿里云 OSS", EndpointFormat: "https://oss-{region}.aliyuncs.com", DefaultRegion: "cn-hangzhou", ChecksumWhenRequired: true},
+	{ID: "tencent", Name: "腾讯云 COS", EndpointFormat: "https://cos.{region}.myqcloud.com", DefaultRegion: "ap-guangzhou", ChecksumWhenRequired: true},
+	{ID: "b2", Name: "Backblaze B2", EndpointFormat: "https://s3.{region}.backblazeb2.com", DefaultRegion: "us-west-004", ChecksumWhenRequired: true},
+	{ID: ProviderCustom, Name: "自定义", DefaultRegion: defaultRegion, PathStyle: true},
+}
+
+// ProviderByID 根据标识查找服务类型，找不到时返回自定义类型
+func ProviderByID(id string) Provider {
+	for _, p := range Providers {
+		if p.ID == id {
+			return p
+		}
+	}
+	return Providers[len(Providers)-1]
+}
+
+// Endpoint 返回服务类型在指定区域下的 Endpoint，区域为空时使用默认区域
+func (p Provider) Endpoint(region string) string {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		region = p.DefaultRegion
+	}
+	return strings.ReplaceAll(p.EndpointFormat, "{region}", region)
+}
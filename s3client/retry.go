@@ -0,0 +1,112 @@
+package s3client
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// DefaultMaxRetries 是上传、下载、删除和复制单个对象遇到临时错误时的默认最大重试次数
+const DefaultMaxRetries = 3
+
+// maxRetries 是当前的最大重试次数，界面保存设置时修改，后台传输同时读取，因此使用原子变量
+var maxRetries atomic.Int32
+
+func init() {
+	maxRetries.Store(DefaultMaxRetries)
+}
+
+// SetMaxRetries 设置上传、下载、删除和复制单个对象遇到临时错误时的最大重试次数，设为 0 时不重试
+func SetMaxRetries(n int) {
+	maxRetries.Store(int32(max(n, 0)))
+}
+
+// MaxRetries 返回当前的最大重试次数
+func MaxRetries() int {
+	return int(maxRetries.Load())
+}
+
+// ErrRetried 表示请求遇到临时错误，并且已经在 s3client 中按 MaxRetries 重试过。
+// 调用方不应再次重试这类错误，否则重试次数会成倍增加，服务不可用时要很久才能看到错误。
+var ErrRetried = errors.New("已重试")
+
+// IsRetriedError 判断错误是否已经在 s3client 中重试过
+func IsRetriedError(err error) bool {
+	return errors.Is(err, ErrRetried)
+}
+
+// retriedError 包装重试次数用完后的最后一次错误，错误信息不变，errors.Is(err, ErrRetried) 返回 true
+type retriedError struct {
+	err error
+}
+
+func (e *retriedError) Error() string   { return e.err.Error() }
+func (e *retriedError) Unwrap() []error { return []error{e.err, ErrRetried} }
+
+// retryBaseDelay 是第一次重试前的等待时间，之后每次重试翻倍
+var retryBaseDelay = 500 * time.Millisecond
+
+// throttlingErrorCodes 是服务端因请求过多或暂时不可用返回的错误码，部分 S3 兼容服务返回这些错误码时状态码不是 5xx
+var throttlingErrorCodes = map[string]bool{
+	"SlowDown":               true,
+	"Throttling":             true,
+	"ThrottlingException":    true,
+	"RequestLimitExceeded":   true,
+	"TooManyRequests":        true,
+	"RequestTimeout":         true,
+	"InternalError":          true,
+	"ServiceUnavailable":     true,
+	"RequestThrottled":       true,
+	"BandwidthLimitExceeded": true,
+}
+
+// IsRetryableError 判断错误是否为值得重试的临时错误：请求超时、5xx、429 或限流错误码。
+// 404、403 等客户端错误以及用户取消的请求不重试。
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if IsTimeoutError(err) {
+		return true
+	}
+	var respErr *smithyhttp.ResponseError
+	if errors.As(err, &respErr) {
+		status := respErr.HTTPStatusCode()
+		if status >= 500 || status == http.StatusTooManyRequests {
+			return true
+		}
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return throttlingErrorCodes[apiErr.ErrorCode()]
+	}
+	return false
+}
+
+// withRetry 执行 fn，遇到临时错误时按指数退避最多重试 MaxRetries 次。ctx 被取消时停止等待并返回最后一次的错误。
+// 重试次数用完后返回的错误包装了 ErrRetried。fn 每次执行都应创建自己的请求超时，使一次超时不会耗尽后续重试的时间。
+func withRetry(ctx context.Context, what string, fn func() error) error {
+	retries := MaxRetries()
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || ctx.Err() != nil || !IsRetryableError(err) {
+			return err
+		}
+		if attempt >= retries {
+			return &retriedError{err: err}
+		}
+		delay := retryBaseDelay << attempt
+		log.Printf("%s第 %d 次失败，%v 后重试: %v", what, attempt+1, delay, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package s3client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// httpError 构造一个带有 HTTP 状态码的 SDK 响应错误
+func httpError(status int) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: status}},
+		Err:      fmt.Errorf("status %d", status),
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{httpError(500), true},
+		{httpError(503), true},
+		{httpError(429), true},
+		{httpError(404), false},
+		{httpError(403), false},
+		{&smithy.GenericAPIError{Code: "SlowDown"}, true},
+		{&smithy.GenericAPIError{Code: "NoSuchKey"}, false},
+		{fmt.Errorf("删除对象失败: %w", ErrOperationTimeout), true},
+		{context.Canceled, false},
+		{errors.New("其它错误"), false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableError(tt.err); got != tt.want {
+			t.Errorf("IsRetryableError(%v) = %v, 期望 %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// flakyDeleteAPI 的 DeleteObject 依次返回 errs 中的错误，用完后成功
+type flakyDeleteAPI struct {
+	s3API
+	errs  []error
+	calls int
+}
+
+func (f *flakyDeleteAPI) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return nil, f.errs[f.calls-1]
+	}
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestDeleteObjectRetriesTransientErrors(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	fake := &flakyDeleteAPI{errs: []error{httpError(503), httpError(500)}}
+	sc := &S3Client{client: fake}
+	if err := sc.DeleteObject(context.Background(), "bucket", "a.txt"); err != nil {
+		t.Fatalf("重试后应成功，实际错误: %v", err)
+	}
+	if fake.calls != 3 {
+		t.Errorf("DeleteObject 调用了 %d 次，期望 3 次", fake.calls)
+	}
+
+	// 客户端错误不重试
+	fake = &flakyDeleteAPI{errs: []error{httpError(403)}}
+	sc = &S3Client{client: fake}
+	if err := sc.DeleteObject(context.Background(), "bucket", "a.txt"); err == nil {
+		t.Error("403 错误应直接返回")
+	}
+	if fake.calls != 1 {
+		t.Errorf("403 错误后 DeleteObject 调用了 %d 次，期望 1 次", fake.calls)
+	}
+
+	// 重试次数用完后返回最后一次的错误
+	fake = &flakyDeleteAPI{errs: []error{httpError(503), httpError(503), httpError(503), httpError(503), httpError(503)}}
+	sc = &S3Client{client: fake}
+	if err := sc.DeleteObject(context.Background(), "bucket", "a.txt"); err == nil {
+		t.Error("重试次数用完后应返回错误")
+	}
+	if fake.calls != MaxRetries()+1 {
+		t.Errorf("DeleteObject 调用了 %d 次，期望 %d 次", fake.calls, MaxRetries()+1)
+	}
+}
+
+// flakyPutObjectAPI 的 PutObject 读取请求体，第一次返回 500 错误
+type flakyPutObjectAPI struct {
+	s3API
+	bodies []string
+}
+
+func (f *flakyPutObjectAPI) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.bodies = append(f.bodies, string(data))
+	if len(f.bodies) == 1 {
+		return nil, httpError(500)
+	}
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestUploadObjectRetriesSeekableReader(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	fake := &flakyPutObjectAPI{}
+	sc := &S3Client{client: fake}
+	if err := sc.UploadObject(context.Background(), "bucket", "a.txt", strings.NewReader("hello"), 5, UploadOptions{}); err != nil {
+		t.Fatalf("重试后应成功，实际错误: %v", err)
+	}
+	if len(fake.bodies) != 2 || fake.bodies[1] != "hello" {
+		t.Errorf("上传的请求体 = %q, 期望重试时从头上传", fake.bodies)
+	}
+
+	// 不可寻址的数据源无法重新读取，只上传一次
+	fake = &flakyPutObjectAPI{}
+	sc = &S3Client{client: fake}
+	if err := sc.UploadObject(context.Background(), "bucket", "a.txt", io.LimitReader(strings.NewReader("hello"), 5), 5, UploadOptions{}); err == nil {
+		t.Error("不可寻址的数据源失败后应直接返回错误")
+	}
+	if len(fake.bodies) != 1 {
+		t.Errorf("PutObject 调用了 %d 次，期望 1 次", len(fake.bodies))
+	}
+}
+
+// 重试次数由 SetMaxRetries 设置，用完后返回的错误标记为已重试，界面不再重复重试
+func TestSetMaxRetriesLimitsTotalAttempts(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+	defer SetMaxRetries(MaxRetries())
+	SetMaxRetries(2)
+
+	fake := &flakyDeleteAPI{errs: []error{httpError(503), httpError(503), httpError(503), httpError(503), httpError(503)}}
+	sc := &S3Client{client: fake}
+	err := sc.DeleteObject(context.Background(), "bucket", "a.txt")
+	if fake.calls != 3 {
+		t.Errorf("DeleteObject 调用了 %d 次，期望 3 次", fake.calls)
+	}
+	if !IsRetriedError(err) {
+		t.Errorf("重试次数用完后的错误应标记为已重试，实际 %v", err)
+	}
+
+	// 不可重试的错误没有重试过，不标记
+	fake = &flakyDeleteAPI{errs: []error{httpError(403)}}
+	sc = &S3Client{client: fake}
+	if err := sc.DeleteObject(context.Background(), "bucket", "a.txt"); IsRetriedError(err) {
+		t.Errorf("403 错误不应标记为已重试: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package s3client
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+// MaxSearchResults 是递归搜索最多返回的结果数量，超过后停止列举
+const MaxSearchResults = 1000
+
+// errSearchLimit 在结果达到上限时停止列举
+var errSearchLimit = errors.New("搜索结果达到上限")
+
+// SearchObjects 逐页列出前缀下的所有文件，返回文件名（不含路径）包含 substring 的对象，不区分大小写。
+// 对象的 Name 为相对于 prefix 的路径。最多返回 MaxSearchResults 个结果，还有更多匹配时 truncated 为 true。
+func (sc *S3Client) SearchObjects(ctx context.Context, bucketName, prefix, substring string) (results []S3Object, truncated bool, err error) {
+	substring = strings.ToLower(substring)
+	err = sc.WalkObjects(ctx, bucketName, prefix, func(obj S3Object) error {
+		if !strings.Contains(strings.ToLower(path.Base(obj.Key)), substring) {
+			return nil
+		}
+		if len(results) == MaxSearchResults {
+			truncated = true
+			return errSearchLimit
+		}
+		// 与列表使用相同的时间格式
+		obj.LastModified = formatLastModified(&obj.ModifiedTime, "2006-01-02 15:04:05")
+		results = append(results, obj)
+		return nil
+	})
+	if errors.Is(err, errSearchLimit) {
+		err = nil
+	}
+	return results, truncated, err
+}
@@ -0,0 +1,65 @@
+package s3client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// flatListAPI 一次返回前缀下的所有对象，不分页。以 / 结尾的键是大小为 0 的文件夹占位对象。
+type flatListAPI struct {
+	s3API
+	keys []string
+}
+
+func (f flatListAPI) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	out := &s3.ListObjectsV2Output{}
+	for _, key := range f.keys {
+		if !strings.HasPrefix(key, aws.ToString(params.Prefix)) {
+			continue
+		}
+		size := int64(1)
+		if strings.HasSuffix(key, "/") {
+			size = 0
+		}
+		out.Contents = append(out.Contents, s3types.Object{Key: aws.String(key), Size: aws.Int64(size)})
+	}
+	return out, nil
+}
+
+func TestSearchObjectsMatchesFileNames(t *testing.T) {
+	sc := &S3Client{client: flatListAPI{keys: []string{"reports/2024/Q1-report.pdf", "report/notes.txt", "misc/REPORT.md", "reports/"}}}
+	results, truncated, err := sc.SearchObjects(context.Background(), "bucket", "", "report")
+	if err != nil || truncated {
+		t.Fatalf("SearchObjects 返回 truncated=%v, err=%v", truncated, err)
+	}
+	var keys []string
+	for _, obj := range results {
+		keys = append(keys, obj.Key)
+	}
+	// 只匹配文件名，不匹配路径中的文件夹名
+	if want := []string{"reports/2024/Q1-report.pdf", "misc/REPORT.md"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("搜索结果 = %v, 期望 %v", keys, want)
+	}
+}
+
+func TestSearchObjectsTruncates(t *testing.T) {
+	var keys []string
+	for i := 0; i < MaxSearchResults+5; i++ {
+		keys = append(keys, fmt.Sprintf("logs/%05d.log", i))
+	}
+	sc := &S3Client{client: flatListAPI{keys: keys}}
+	results, truncated, err := sc.SearchObjects(context.Background(), "bucket", "logs/", ".log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !truncated || len(results) != MaxSearchResults {
+		t.Errorf("返回 %d 个结果, truncated=%v, 期望 %d 个且被截断", len(results), truncated, MaxSearchResults)
+	}
+}
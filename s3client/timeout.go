@@ -0,0 +1,169 @@
+package s3client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultOperationTimeout 是单次请求的默认超时时间
+const DefaultOperationTimeout = 30 * time.Second
+
+// 超时时间以纳秒保存在原子变量中：界面保存设置时修改，后台操作同时读取
+var (
+	// operationTimeoutNanos 用于 Head/Delete/Copy 等单次请求
+	operationTimeoutNanos atomic.Int64
+	// listTimeoutNanos 用于列举请求的每一页，列举大目录时服务端响应更慢，因此使用更长的超时
+	listTimeoutNanos atomic.Int64
+)
+
+func init() {
+	SetOperationTimeout(DefaultOperationTimeout)
+}
+
+// operationTimeout 返回单次请求的超时时间
+func operationTimeout() time.Duration {
+	return time.Duration(operationTimeoutNanos.Load())
+}
+
+// listTimeout 返回列举请求每一页的超时时间
+func listTimeout() time.Duration {
+	return time.Duration(listTimeoutNanos.Load())
+}
+
+// ErrOperationTimeout 表示请求在超时时间内没有完成（或传输长时间没有进展）
+var ErrOperationTimeout = errors.New("操作超时，请检查网络连接或服务地址")
+
+// SetOperationTimeout 设置单次请求的超时时间，列举请求使用其 4 倍。d 小于等于 0 时恢复默认值。
+func SetOperationTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultOperationTimeout
+	}
+	operationTimeoutNanos.Store(int64(d))
+	listTimeoutNanos.Store(int64(4 * d))
+}
+
+// IsTimeoutError 判断错误是否由请求超时引起
+func IsTimeoutError(err error) bool {
+	return errors.Is(err, ErrOperationTimeout) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// operationContext 返回单次请求使用的带超时的 context
+func operationContext() (context.Context, context.CancelFunc) {
+	return operationContextFrom(context.Background())
+}
+
+// operationContextFrom 与 operationContext 相同，但在 parent 被取消时也会取消，用于可由用户取消的操作
+func operationContextFrom(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, operationTimeout())
+}
+
+// listContext 返回列举请求（每一页）使用的带超时的 context
+func listContext() (context.Context, context.CancelFunc) {
+	return listContextFrom(context.Background())
+}
+
+// listContextFrom 与 listContext 相同，但在 parent 被取消时也会取消，用于可由用户取消的长时间列举
+func listContextFrom(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, listTimeout())
+}
+
+// withTimeoutError 如果 ctx 已超时，则将 err 包装为 ErrOperationTimeout
+func withTimeoutError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", ErrOperationTimeout, err)
+	}
+	return err
+}
+
+// idleWatchdog 在传输长时间没有进展时取消请求。
+// 上传和下载的总耗时取决于文件大小，因此不能使用固定的截止时间，
+// 而是每读取到数据就重置计时器，只有超过 operationTimeout 没有任何数据时才视为超时。
+type idleWatchdog struct {
+	timeout time.Duration
+	timer   *time.Timer
+	fired   atomic.Bool
+	cancel  context.CancelFunc
+}
+
+// newIdleContext 创建一个在空闲超时后自动取消的 context，parent 被取消时同样会取消
+func newIdleContext(parent context.Context) (context.Context, *idleWatchdog) {
+	ctx, cancel := context.WithCancel(parent)
+	w := &idleWatchdog{timeout: operationTimeout(), cancel: cancel}
+	w.timer = time.AfterFunc(w.timeout, func() {
+		w.fired.Store(true)
+		cancel()
+	})
+	return ctx, w
+}
+
+// kick 表示传输有进展，重置空闲计时器
+func (w *idleWatchdog) kick() {
+	w.timer.Reset(w.timeout)
+}
+
+// stop 停止计时器并释放 context
+func (w *idleWatchdog) stop() {
+	w.timer.Stop()
+	w.cancel()
+}
+
+// wrap 如果是空闲超时导致的错误，则包装为 ErrOperationTimeout
+func (w *idleWatchdog) wrap(err error) error {
+	if err != nil && err != io.EOF && w.fired.Load() {
+		return fmt.Errorf("%w: %v", ErrOperationTimeout, err)
+	}
+	return err
+}
+
+// idleReader 在每次读取后重置空闲计时器
+type idleReader struct {
+	reader io.Reader
+	w      *idleWatchdog
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.w.kick()
+	}
+	return n, r.w.wrap(err)
+}
+
+// idleReadSeeker 在底层 reader 可寻址时保留 Seek，SDK 依赖它计算校验和及重试
+type idleReadSeeker struct {
+	idleReader
+	seeker io.Seeker
+}
+
+func (r *idleReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	return r.seeker.Seek(offset, whence)
+}
+
+// watchReader 为上传的数据源添加空闲超时检测
+func (w *idleWatchdog) watchReader(reader io.Reader) io.Reader {
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		return &idleReadSeeker{idleReader: idleReader{reader: reader, w: w}, seeker: seeker}
+	}
+	return &idleReader{reader: reader, w: w}
+}
+
+// idleReadCloser 为下载的响应体添加空闲超时检测，关闭时释放 context
+type idleReadCloser struct {
+	idleReader
+	closer io.Closer
+}
+
+func (r *idleReadCloser) Close() error {
+	err := r.closer.Close()
+	r.w.stop()
+	return err
+}
+
+// watchBody 为下载的响应体添加空闲超时检测
+func (w *idleWatchdog) watchBody(body io.ReadCloser) io.ReadCloser {
+	return &idleReadCloser{idleReader: idleReader{reader: body, w: w}, closer: body}
+}
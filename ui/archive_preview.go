@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// zipTailSize 是读取 zip 中央目录时一次下载的文件末尾大小，中央目录通常完全位于其中
+var zipTailSize int64 = 1 << 20
+
+// archiveEntry 是压缩包中的一个条目
+type archiveEntry struct {
+	Name  string
+	Size  int64 // 解压后的大小
+	IsDir bool
+}
+
+// archiveFormat 返回压缩包的格式："zip"、"tar"、"tar.gz"，不支持的格式返回空字符串
+func archiveFormat(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	case strings.HasSuffix(lower, ".tar"):
+		return "tar"
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	default:
+		return ""
+	}
+}
+
+// objectReaderAt 通过范围下载按需读取对象内容，用于只读取 zip 的中央目录和单个条目。
+// 文件末尾的 zipTailSize 字节在第一次读取时整体下载并缓存，避免读取中央目录时发出大量小请求。
+type objectReaderAt struct {
+	ctx    context.Context
+	store  ObjectStore
+	bucket string
+	key    string
+	size   int64
+
+	tail      []byte
+	tailStart int64
+}
+
+func (r *objectReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if r.tail == nil {
+		r.tailStart = r.size - zipTailSize
+		if r.tailStart < 0 {
+			r.tailStart = 0
+		}
+		tail, err := r.readRange(r.tailStart, r.size-1)
+		if err != nil {
+			return 0, err
+		}
+		r.tail = tail
+	}
+
+	end := off + int64(len(p)) - 1
+	if end >= r.size {
+		end = r.size - 1
+	}
+	var data []byte
+	if off >= r.tailStart {
+		data = r.tail[off-r.tailStart : end-r.tailStart+1]
+	} else {
+		var err error
+		if data, err = r.readRange(off, end); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, data)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readRange 下载对象中 [start, end] 范围内的字节
+func (r *objectReaderAt) readRange(start, end int64) ([]byte, error) {
+	body, err := r.store.DownloadObjectRange(context.Background(), r.bucket, r.key, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(&contextReader{ctx: r.ctx, reader: body})
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) != end-start+1 {
+		return nil, fmt.Errorf("范围下载返回了 %d 字节，期望 %d 字节", len(data), end-start+1)
+	}
+	return data, nil
+}
+
+// openZip 打开对象存储中的 zip 文件。服务端支持范围下载时只读取中央目录，否则下载整个文件。
+func openZip(ctx context.Context, store ObjectStore, bucket string, item s3client.S3Object) (*zip.Reader, error) {
+	readerAt := &objectReaderAt{ctx: ctx, store: store, bucket: bucket, key: item.Key, size: item.Size}
+	zr, err := zip.NewReader(readerAt, item.Size)
+	if !errors.Is(err, s3client.ErrRangeNotSupported) {
+		return zr, err
+	}
+
+	body, err := store.DownloadObject(context.Background(), bucket, item.Key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(&contextReader{ctx: ctx, reader: body})
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// openTar 开始流式下载 tar 文件，返回的 closer 用于结束下载
+func openTar(ctx context.Context, store ObjectStore, bucket string, item s3client.S3Object) (*tar.Reader, io.Closer, error) {
+	body, err := store.DownloadObject(context.Background(), bucket, item.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	var reader io.Reader = &contextReader{ctx: ctx, reader: body}
+	if archiveFormat(item.Name) == "tar.gz" {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			body.Close()
+			return nil, nil, fmt.Errorf("无法读取 gzip 数据: %w", err)
+		}
+		reader = gz
+	}
+	return tar.NewReader(reader), body, nil
+}
+
+// listArchive 列出压缩包中的条目。zip 只读取中央目录；tar 没有目录，需要顺序读取整个文件。
+func listArchive(ctx context.Context, store ObjectStore, bucket string, item s3client.S3Object) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	switch archiveFormat(item.Name) {
+	case "zip":
+		zr, err := openZip(ctx, store, bucket, item)
+		if err != nil {
+			return nil, fmt.Errorf("无法读取 zip 文件: %w", err)
+		}
+		for _, f := range zr.File {
+			entries = append(entries, archiveEntry{Name: f.Name, Size: int64(f.UncompressedSize64), IsDir: f.FileInfo().IsDir()})
+		}
+	case "tar", "tar.gz":
+		tr, closer, err := openTar(ctx, store, bucket, item)
+		if err != nil {
+			return nil, err
+		}
+		defer closer.Close()
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("无法读取 tar 文件: %w", err)
+			}
+			entries = append(entries, archiveEntry{Name: header.Name, Size: header.Size, IsDir: header.Typeflag == tar.TypeDir})
+		}
+	default:
+		return nil, fmt.Errorf("不支持的压缩包格式: %s", item.Name)
+	}
+	return entries, nil
+}
+
+// extractArchiveEntry 将压缩包中名为 name 的条目解压写入 w
+func extractArchiveEntry(ctx context.Context, store ObjectStore, bucket string, item s3client.S3Object, name string, w io.Writer) error {
+	switch archiveFormat(item.Name) {
+	case "zip":
+		zr, err := openZip(ctx, store, bucket, item)
+		if err != nil {
+			return fmt.Errorf("无法读取 zip 文件: %w", err)
+		}
+		for _, f := range zr.File {
+			if f.Name != name {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("无法打开条目 '%s': %w", name, err)
+			}
+			defer rc.Close()
+			_, err = io.Copy(w, rc)
+			return err
+		}
+	case "tar", "tar.gz":
+		tr, closer, err := openTar(ctx, store, bucket, item)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("无法读取 tar 文件: %w", err)
+			}
+			if header.Name == name {
+				_, err = io.Copy(w, tr)
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("压缩包中没有条目 '%s'", name)
+}
+
+// createArchivePreview 创建压缩包内容列表，选中文件条目后可以单独解压保存到本地
+func (g *previewGallery) createArchivePreview(item s3client.S3Object, entries []archiveEntry) fyne.CanvasObject {
+	var fileCount int
+	var totalSize int64
+	for _, e := range entries {
+		if !e.IsDir {
+			fileCount++
+			totalSize += e.Size
+		}
+	}
+	summary := widget.NewLabel(fmt.Sprintf(T("共 %d 个文件，解压后 %s"), fileCount, formatBytes(totalSize)))
+
+	selected := -1
+	var extractButton *widget.Button
+	list := widget.NewList(
+		func() int { return len(entries) },
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("")
+			name.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, widget.NewIcon(theme.FileIcon()), widget.NewLabel(""), name)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			row := obj.(*fyne.Container)
+			e := entries[id]
+			row.Objects[0].(*widget.Label).SetText(e.Name)
+			icon := row.Objects[1].(*widget.Icon)
+			size := row.Objects[2].(*widget.Label)
+			if e.IsDir {
+				icon.SetResource(theme.FolderIcon())
+				size.SetText("")
+			} else {
+				icon.SetResource(getIconForFile(e.Name))
+				size.SetText(formatBytes(e.Size))
+			}
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		if entries[id].IsDir {
+			extractButton.Disable()
+		} else {
+			extractButton.Enable()
+		}
+	}
+
+	client, bucket, ctx := g.client, g.bucket, g.ctx
+	extractButton = widget.NewButtonWithIcon(T("提取选中的文件"), theme.DownloadIcon(), func() {
+		if selected < 0 || selected >= len(entries) {
+			return
+		}
+		name := entries[selected].Name
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, g.window)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			go func() {
+				err := extractArchiveEntry(ctx, client, bucket, item, name, writer)
+				if closeErr := writer.Close(); err == nil {
+					err = closeErr
+				}
+				fyne.Do(func() {
+					if err != nil {
+						log.Printf("提取 '%s' 失败: %v", name, err)
+						dialog.ShowError(fmt.Errorf(T("提取文件失败: %v"), err), g.window)
+						return
+					}
+					ShowToast(g.window, fmt.Sprintf(T("已提取 '%s'"), path.Base(name)))
+				})
+			}()
+		}, g.window)
+		saveDialog.SetFileName(path.Base(name))
+		saveDialog.Show()
+	})
+	extractButton.Disable()
+
+	return container.NewBorder(container.NewHBox(summary, extractButton), nil, nil, nil, list)
+}
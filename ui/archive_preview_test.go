@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func buildZip(t *testing.T, files map[string]string, names []string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(files[name]))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildTarGz(t *testing.T, files map[string]string, names []string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(files[name]))
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestListAndExtractArchives(t *testing.T) {
+	files := map[string]string{"docs/": "", "docs/readme.txt": "hello", "data.csv": "a,b\n1,2\n"}
+	store := newMemStore()
+	store.put(testBucket, "a.zip", string(buildZip(t, files, []string{"docs/", "docs/readme.txt", "data.csv"})))
+	store.put(testBucket, "b.tar.gz", string(buildTarGz(t, files, []string{"docs/readme.txt", "data.csv"})))
+
+	tests := []struct {
+		key  string
+		want []archiveEntry
+	}{
+		{"a.zip", []archiveEntry{{Name: "docs/", IsDir: true}, {Name: "docs/readme.txt", Size: 5}, {Name: "data.csv", Size: 8}}},
+		{"b.tar.gz", []archiveEntry{{Name: "docs/readme.txt", Size: 5}, {Name: "data.csv", Size: 8}}},
+	}
+	for _, tt := range tests {
+		item := s3client.S3Object{Key: tt.key, Name: tt.key, Size: int64(len(store.buckets[testBucket][tt.key]))}
+		entries, err := listArchive(context.Background(), store, testBucket, item)
+		if err != nil {
+			t.Fatalf("%s: 列出条目失败: %v", tt.key, err)
+		}
+		if !reflect.DeepEqual(entries, tt.want) {
+			t.Errorf("%s: 条目 = %+v, 期望 %+v", tt.key, entries, tt.want)
+		}
+
+		var out bytes.Buffer
+		if err := extractArchiveEntry(context.Background(), store, testBucket, item, "docs/readme.txt", &out); err != nil {
+			t.Fatalf("%s: 提取失败: %v", tt.key, err)
+		}
+		if out.String() != "hello" {
+			t.Errorf("%s: 提取的内容为 %q", tt.key, out.String())
+		}
+		if err := extractArchiveEntry(context.Background(), store, testBucket, item, "missing.txt", &out); err == nil {
+			t.Errorf("%s: 提取不存在的条目应返回错误", tt.key)
+		}
+	}
+}
+
+// 文件末尾的数据只下载一次并缓存，末尾之前的数据按需范围下载
+func TestObjectReaderAt(t *testing.T) {
+	oldTail := zipTailSize
+	defer func() { zipTailSize = oldTail }()
+	zipTailSize = 10
+
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	store := newMemStore()
+	store.put(testBucket, "big.bin", string(data))
+	r := &objectReaderAt{ctx: context.Background(), store: store, bucket: testBucket, key: "big.bin", size: int64(len(data))}
+
+	p := make([]byte, 4)
+	if n, err := r.ReadAt(p, 96); n != 4 || err != nil || string(p) != "6789" {
+		t.Errorf("ReadAt(96) = %d, %v, %q", n, err, p)
+	}
+	if r.tailStart != 90 || len(r.tail) != 10 {
+		t.Errorf("应缓存最后 10 个字节，实际从 %d 开始缓存 %d 个字节", r.tailStart, len(r.tail))
+	}
+	if n, err := r.ReadAt(p, 3); n != 4 || err != nil || string(p) != "3456" {
+		t.Errorf("ReadAt(3) = %d, %v, %q", n, err, p)
+	}
+	if n, err := r.ReadAt(p, 98); n != 2 || string(p[:n]) != "89" || err != io.EOF {
+		t.Errorf("读取超出末尾时应返回已读取的字节和 io.EOF，实际 %d, %v", n, err)
+	}
+}
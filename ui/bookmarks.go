@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/config"
+)
+
+// bookmarkLocation 返回书签位置的显示文字，形如 bucket/prefix
+func bookmarkLocation(bucket, prefix string) string {
+	return bucket + "/" + prefix
+}
+
+// defaultBookmarkLabel 返回收藏位置时默认的书签名称：前缀的最后一级目录，位于根目录时为存储桶名称
+func defaultBookmarkLabel(bucket, prefix string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return bucket
+	}
+	return prefix[strings.LastIndex(prefix, "/")+1:]
+}
+
+// newBookmarksButton 创建书签按钮，点击后弹出当前服务的书签菜单
+func (ov *ObjectsView) newBookmarksButton() *widget.Button {
+	var button *widget.Button
+	button = widget.NewButtonWithIcon("", starIcon, func() {
+		ov.showBookmarksMenu(button)
+	})
+	return button
+}
+
+// showBookmarksMenu 在 anchor 下方显示书签菜单，包含收藏当前位置、已有书签和管理入口
+func (ov *ObjectsView) showBookmarksMenu(anchor fyne.CanvasObject) {
+	if ov.currentServiceAlias == "" {
+		return
+	}
+	bookmarks, err := config.LoadBookmarks(ov.currentServiceAlias)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(T("读取书签失败: %v"), err), ov.window)
+		return
+	}
+
+	addItem := fyne.NewMenuItem(T("收藏当前位置"), ov.showAddBookmarkDialog)
+	addItem.Disabled = ov.currentBucket == ""
+	items := []*fyne.MenuItem{addItem, fyne.NewMenuItemSeparator()}
+	if len(bookmarks) == 0 {
+		empty := fyne.NewMenuItem(T("暂无书签"), nil)
+		empty.Disabled = true
+		items = append(items, empty)
+	}
+	for _, b := range bookmarks {
+		b := b
+		items = append(items, fyne.NewMenuItem(fmt.Sprintf("%s  (%s)", b.Label, bookmarkLocation(b.Bucket, b.Prefix)), func() {
+			ov.openBookmark(b)
+		}))
+	}
+	items = append(items, fyne.NewMenuItemSeparator(), fyne.NewMenuItem(T("管理书签..."), ov.showManageBookmarksDialog))
+
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(anchor)
+	if canvas == nil {
+		return
+	}
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(anchor).Add(fyne.NewPos(0, anchor.Size().Height))
+	widget.NewPopUpMenu(fyne.NewMenu("", items...), canvas).ShowAtPosition(pos)
+}
+
+// openBookmark 跳转到书签所在的存储桶和前缀
+func (ov *ObjectsView) openBookmark(b config.Bookmark) {
+	if ov.rejectIfBusy() {
+		return
+	}
+	if ov.OnLocationOpened != nil {
+		ov.OnLocationOpened(b.Bucket, b.Prefix)
+	}
+}
+
+// showAddBookmarkDialog 让用户为当前位置输入书签名称并保存，该位置已有书签时更新其名称
+func (ov *ObjectsView) showAddBookmarkDialog() {
+	alias, bucket, prefix := ov.currentServiceAlias, ov.currentBucket, ov.currentPrefix
+	labelEntry := widget.NewEntry()
+	labelEntry.SetText(defaultBookmarkLabel(bucket, prefix))
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("位置"), widget.NewLabel(bookmarkLocation(bucket, prefix))),
+		widget.NewFormItem(T("名称"), labelEntry),
+	}
+	d := dialog.NewForm(T("收藏当前位置"), T("保存"), T("取消"), items, func(ok bool) {
+		label := strings.TrimSpace(labelEntry.Text)
+		if !ok || label == "" {
+			return
+		}
+		err := config.AddBookmark(config.Bookmark{ServiceAlias: alias, Bucket: bucket, Prefix: prefix, Label: label})
+		if err != nil {
+			dialog.ShowError(err, ov.window)
+		}
+	}, ov.window)
+	d.Resize(fyne.NewSize(400, 200))
+	d.Show()
+}
+
+// showManageBookmarksDialog 显示当前服务的书签列表，可以重命名或删除书签
+func (ov *ObjectsView) showManageBookmarksDialog() {
+	alias := ov.currentServiceAlias
+	rows := container.NewVBox()
+
+	var reload func()
+	reload = func() {
+		rows.RemoveAll()
+		bookmarks, err := config.LoadBookmarks(alias)
+		if err != nil {
+			log.Printf("读取书签失败: %v", err)
+			rows.Add(widget.NewLabel(fmt.Sprintf(T("读取书签失败: %v"), err)))
+			return
+		}
+		if len(bookmarks) == 0 {
+			rows.Add(widget.NewLabel(T("暂无书签")))
+			return
+		}
+		for _, b := range bookmarks {
+			b := b
+			renameButton := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+				ov.showRenameBookmarkDialog(b, reload)
+			})
+			deleteButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				dialog.ShowConfirm(T("删除书签"), fmt.Sprintf(T("确定要删除书签 '%s' 吗？"), b.Label), func(confirmed bool) {
+					if !confirmed {
+						return
+					}
+					if err := config.DeleteBookmark(b.ID); err != nil {
+						dialog.ShowError(err, ov.window)
+					}
+					reload()
+				}, ov.window)
+			})
+			info := widget.NewLabel(fmt.Sprintf("%s\n%s", b.Label, bookmarkLocation(b.Bucket, b.Prefix)))
+			info.Truncation = fyne.TextTruncateEllipsis
+			rows.Add(container.NewBorder(nil, nil, nil, container.NewHBox(renameButton, deleteButton), info))
+		}
+	}
+	reload()
+
+	d := dialog.NewCustom(T("管理书签"), T("关闭"), container.NewVScroll(rows), ov.window)
+	d.Resize(fyne.NewSize(500, 400))
+	d.Show()
+}
+
+// showRenameBookmarkDialog 修改书签名称，保存后调用 onDone 刷新列表
+func (ov *ObjectsView) showRenameBookmarkDialog(b config.Bookmark, onDone func()) {
+	labelEntry := widget.NewEntry()
+	labelEntry.SetText(b.Label)
+	items := []*widget.FormItem{widget.NewFormItem(T("名称"), labelEntry)}
+	d := dialog.NewForm(T("重命名书签"), T("保存"), T("取消"), items, func(ok bool) {
+		label := strings.TrimSpace(labelEntry.Text)
+		if !ok || label == "" || label == b.Label {
+			return
+		}
+		if err := config.RenameBookmark(b.ID, label); err != nil {
+			dialog.ShowError(err, ov.window)
+		}
+		onDone()
+	}, ov.window)
+	d.Resize(fyne.NewSize(400, 160))
+	d.Show()
+}
@@ -0,0 +1,18 @@
+package ui
+
+import "testing"
+
+func TestDefaultBookmarkLabel(t *testing.T) {
+	tests := []struct {
+		bucket, prefix, want string
+	}{
+		{"photos", "", "photos"},
+		{"photos", "2024/", "2024"},
+		{"photos", "2024/trip/", "trip"},
+	}
+	for _, tt := range tests {
+		if got := defaultBookmarkLabel(tt.bucket, tt.prefix); got != tt.want {
+			t.Errorf("defaultBookmarkLabel(%q, %q) = %q, 期望 %q", tt.bucket, tt.prefix, got, tt.want)
+		}
+	}
+}
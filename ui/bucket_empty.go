@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// showEmptyBucketDialog 要求用户输入存储桶名称以确认清空操作
+func (bv *BucketsView) showEmptyBucketDialog(bucket string) {
+	message := fmt.Sprintf(T("此操作将永久删除存储桶 \"%s\" 中的所有对象（包括历史版本和删除标记），但保留存储桶本身。"), bucket)
+	bv.confirmWithBucketName(T("清空存储桶"), T("清空"), message, bucket, func() {
+		bv.emptyBucket(bucket, false)
+	})
+}
+
+// showForceDeleteBucketDialog 要求用户输入存储桶名称以确认强制删除：先删除存储桶中的所有对象版本，再删除存储桶本身
+func (bv *BucketsView) showForceDeleteBucketDialog(bucket string) {
+	message := fmt.Sprintf(T("此操作将永久删除存储桶 \"%s\" 中的所有对象（包括历史版本和删除标记），然后删除存储桶本身，且无法恢复。"), bucket)
+	bv.confirmWithBucketName(T("强制删除存储桶"), T("强制删除"), message, bucket, func() {
+		bv.emptyBucket(bucket, true)
+	})
+}
+
+// confirmWithBucketName 显示破坏性操作的确认对话框，用户输入的名称与 bucket 一致时才调用 onConfirmed
+func (bv *BucketsView) confirmWithBucketName(title, confirmLabel, message, bucket string, onConfirmed func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder(bucket)
+	messageLabel := widget.NewLabel(message)
+	messageLabel.Wrapping = fyne.TextWrapWord
+	content := container.NewVBox(
+		messageLabel,
+		widget.NewLabel(T("请输入存储桶名称以确认:")),
+		nameEntry,
+	)
+	d := dialog.NewCustomConfirm(title, confirmLabel, T("取消"), content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if nameEntry.Text != bucket {
+			dialog.ShowInformation(T("提示"), T("输入的名称与存储桶名称不一致，已取消操作。"), bv.window)
+			return
+		}
+		onConfirmed()
+	}, bv.window)
+	d.Resize(fyne.NewSize(450, 240))
+	d.Show()
+}
+
+// emptyBucket 列出存储桶中的所有对象版本并分批删除，支持进度显示和取消。
+// deleteBucket 为 true 时，所有对象版本删除成功后再删除存储桶本身；取消或有对象删除失败时保留存储桶。
+func (bv *BucketsView) emptyBucket(bucket string, deleteBucket bool) {
+	client := bv.S3Client
+
+	// 关闭进度对话框时取消 context，正在进行的批量删除请求也会中止
+	ctx, cancel := context.WithCancel(context.Background())
+
+	statusLabel := widget.NewLabel(T("正在列出对象..."))
+	progressBar := widget.NewProgressBar()
+	title := T("正在清空存储桶")
+	if deleteBucket {
+		title = T("正在删除存储桶")
+	}
+	progressDialog := dialog.NewCustom(title, T("取消"), container.NewVBox(statusLabel, progressBar), bv.window)
+	progressDialog.SetOnClosed(cancel)
+	progressDialog.Resize(fyne.NewSize(400, 150))
+	progressDialog.Show()
+
+	go func() {
+		defer cancel()
+		versions, err := client.ListObjectVersions(bucket, "")
+		if err != nil {
+			fyne.Do(func() {
+				progressDialog.SetOnClosed(nil)
+				progressDialog.Hide()
+				dialog.ShowError(fmt.Errorf(T("清空存储桶失败: %v"), err), bv.window)
+			})
+			return
+		}
+
+		total := len(versions)
+		deleted := 0
+		var failed []string
+		for start := 0; start < total; start += s3client.MaxDeleteBatchSize {
+			if ctx.Err() != nil {
+				break
+			}
+			end := start + s3client.MaxDeleteBatchSize
+			if end > total {
+				end = total
+			}
+			batchFailed, err := client.DeleteObjectVersions(ctx, bucket, versions[start:end])
+			if ctx.Err() != nil {
+				break // 被取消的批次不计入已处理数量
+			}
+			if err != nil {
+				log.Printf("清空存储桶 '%s' 时批量删除失败: %v", bucket, err)
+				for _, v := range versions[start:end] {
+					batchFailed = append(batchFailed, v.Key)
+				}
+			}
+			failed = append(failed, batchFailed...)
+			deleted = end
+
+			progress := float64(deleted) / float64(total)
+			status := fmt.Sprintf(T("已处理 %d / %d 个对象版本"), deleted, total)
+			fyne.Do(func() {
+				progressBar.SetValue(progress)
+				statusLabel.SetText(status)
+			})
+		}
+
+		wasCancelled := ctx.Err() != nil
+		var deleteErr error
+		if deleteBucket && !wasCancelled && len(failed) == 0 {
+			fyne.Do(func() { statusLabel.SetText(T("正在删除存储桶...")) })
+			deleteErr = client.DeleteBucket(bucket)
+		}
+		fyne.Do(func() {
+			progressDialog.SetOnClosed(nil)
+			progressDialog.Hide()
+			switch {
+			case wasCancelled:
+				ShowToast(bv.window, fmt.Sprintf(T("已取消清空，已处理 %d / %d 个对象版本。"), deleted, total))
+			case len(failed) > 0:
+				dialog.ShowError(fmt.Errorf(T("%d 个对象删除失败，例如: %s"), len(failed), failed[0]), bv.window)
+			case deleteErr != nil:
+				dialog.ShowError(fmt.Errorf(T("删除存储桶失败: %v"), deleteErr), bv.window)
+			case deleteBucket:
+				ShowToast(bv.window, fmt.Sprintf(T("存储桶 \"%s\" 及其中的 %d 个对象版本已删除。"), bucket, total))
+				bv.loadBuckets()
+				return
+			default:
+				ShowToast(bv.window, fmt.Sprintf(T("存储桶 \"%s\" 已清空，共删除 %d 个对象版本。"), bucket, total))
+			}
+			bv.checkDeleteButtonState()
+			if bv.OnBucketSelected != nil {
+				bv.OnBucketSelected(bucket)
+			}
+		})
+	}()
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// starIcon 是置顶存储桶前显示的星标图标，Fyne 内置主题没有星形图标
+var starIcon = theme.NewThemedResource(fyne.NewStaticResource("star.svg", []byte(
+	`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M12 17.27L18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/></svg>`)))
+
+// pinnedBucketsPrefKey 返回保存服务置顶存储桶的首选项键，每个服务单独保存
+func pinnedBucketsPrefKey(serviceAlias string) string {
+	return "pinnedBuckets." + serviceAlias
+}
+
+// SetServiceAlias 设置当前服务的别名，用于读取和保存该服务的置顶存储桶
+func (bv *BucketsView) SetServiceAlias(alias string) {
+	bv.serviceAlias = alias
+	bv.pinnedBuckets = make(map[string]bool)
+	if alias == "" {
+		return
+	}
+	for _, name := range fyne.CurrentApp().Preferences().StringList(pinnedBucketsPrefKey(alias)) {
+		bv.pinnedBuckets[name] = true
+	}
+}
+
+// orderBuckets 将置顶的存储桶排在前面，两组内部保持原有顺序，返回新的列表和置顶存储桶的数量
+func orderBuckets(buckets []string, pinned map[string]bool) ([]string, int) {
+	ordered := make([]string, 0, len(buckets))
+	for _, name := range buckets {
+		if pinned[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	pinnedCount := len(ordered)
+	for _, name := range buckets {
+		if !pinned[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered, pinnedCount
+}
+
+// setBuckets 按置顶状态排序后设置存储桶列表，并保持当前选中的存储桶
+func (bv *BucketsView) setBuckets(buckets []string) {
+	selected := ""
+	if bv.selectedBucketID >= 0 && bv.selectedBucketID < len(bv.buckets) {
+		selected = bv.buckets[bv.selectedBucketID]
+	}
+	bv.buckets, bv.pinnedCount = orderBuckets(buckets, bv.pinnedBuckets)
+	if selected == "" {
+		return
+	}
+	bv.selectedBucketID = -1
+	for i, name := range bv.buckets {
+		if name == selected {
+			bv.selectedBucketID = i
+			break
+		}
+	}
+}
+
+// togglePinned 置顶或取消置顶存储桶，并保存到首选项
+func (bv *BucketsView) togglePinned(bucketName string) {
+	if bv.pinnedBuckets[bucketName] {
+		delete(bv.pinnedBuckets, bucketName)
+	} else {
+		bv.pinnedBuckets[bucketName] = true
+	}
+
+	// 只保存仍然存在的存储桶，已被删除的存储桶不再保留置顶状态
+	var names []string
+	for _, name := range bv.buckets {
+		if bv.pinnedBuckets[name] {
+			names = append(names, name)
+		}
+	}
+	if bv.serviceAlias != "" {
+		fyne.CurrentApp().Preferences().SetStringList(pinnedBucketsPrefKey(bv.serviceAlias), names)
+	}
+
+	bv.setBuckets(bv.buckets)
+	bv.bucketList.Refresh()
+}
+
+// showBucketContextMenu 在存储桶上右键时显示菜单
+func (bv *BucketsView) showBucketContextMenu(id widget.ListItemID, pos fyne.Position) {
+	if id < 0 || id >= len(bv.buckets) {
+		return
+	}
+	bucketName := bv.buckets[id]
+
+	label := T("置顶")
+	if bv.pinnedBuckets[bucketName] {
+		label = T("取消置顶")
+	}
+	pinItem := fyne.NewMenuItem(label, func() {
+		bv.togglePinned(bucketName)
+	})
+	pinItem.Icon = starIcon
+
+	multipartItem := fyne.NewMenuItem(T("清理未完成的分段上传"), func() {
+		bv.showMultipartUploadsDialog(bucketName)
+	})
+	multipartItem.Disabled = bv.S3Client == nil
+
+	// 删除按钮只对空存储桶可用，强制删除需要在菜单中明确选择并输入存储桶名称确认
+	forceDeleteItem := fyne.NewMenuItem(T("强制删除（含内容）"), func() {
+		bv.showForceDeleteBucketDialog(bucketName)
+	})
+	forceDeleteItem.Icon = theme.DeleteIcon()
+	forceDeleteItem.Disabled = bv.S3Client == nil || bv.connecting
+
+	menu := fyne.NewMenu("", pinItem, fyne.NewMenuItemSeparator(), multipartItem, fyne.NewMenuItemSeparator(), forceDeleteItem)
+	widget.NewPopUpMenu(menu, bv.window.Canvas()).ShowAtPosition(pos)
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderBuckets(t *testing.T) {
+	buckets := []string{"alpha", "beta", "gamma", "delta"}
+	pinned := map[string]bool{"gamma": true, "alpha": true, "removed": true}
+
+	ordered, pinnedCount := orderBuckets(buckets, pinned)
+	want := []string{"alpha", "gamma", "beta", "delta"}
+	if !reflect.DeepEqual(ordered, want) {
+		t.Errorf("orderBuckets = %v, 期望 %v", ordered, want)
+	}
+	if pinnedCount != 2 {
+		t.Errorf("置顶数量 = %d, 期望 2", pinnedCount)
+	}
+
+	ordered, pinnedCount = orderBuckets(buckets, nil)
+	if !reflect.DeepEqual(ordered, buckets) || pinnedCount != 0 {
+		t.Errorf("没有置顶时应保持原顺序, 得到 %v (%d)", ordered, pinnedCount)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -22,6 +23,8 @@ import (
 type bucketListEntry struct {
 	widget.BaseWidget
 	label    *widget.Label
+	star     *widget.Icon      // 置顶存储桶的星标
+	divider  *widget.Separator // 显示在最后一个置顶存储桶下方，分隔置顶和其余存储桶
 	id       widget.ListItemID
 	bv       *BucketsView
 	selected bool
@@ -31,12 +34,18 @@ func (e *bucketListEntry) Tapped(_ *fyne.PointEvent) {
 	e.bv.handleBucketTapped(e.id)
 }
 
+// TappedSecondary 在存储桶上右键时显示上下文菜单
+func (e *bucketListEntry) TappedSecondary(ev *fyne.PointEvent) {
+	e.bv.showBucketContextMenu(e.id, ev.AbsolutePosition)
+}
+
 func (e *bucketListEntry) CreateRenderer() fyne.WidgetRenderer {
 	bg := canvas.NewRectangle(color.Transparent)
+	row := container.NewBorder(nil, e.divider, e.star, nil, e.label)
 	return &bucketListEntryRenderer{
 		entry:      e,
 		background: bg,
-		content:    container.NewStack(bg, e.label),
+		content:    container.NewStack(bg, row),
 	}
 }
 
@@ -74,11 +83,21 @@ type BucketsView struct {
 	buckets          []string
 	selectedBucketID widget.ListItemID
 	deleteButton     *widget.Button
+	emptyButton      *widget.Button // 清空存储桶按钮
 	loadingIndicator *ThinProgressBar
+	createButton     *widget.Button
+	connecting       bool              // 正在创建服务的客户端
 	animationManager *AnimationManager // 添加动画管理器
 	bucketContainer  *fyne.Container   // 添加存储桶容器引用
+	serviceAlias     string            // 当前服务的别名，用于保存置顶存储桶
+	pinnedBuckets    map[string]bool   // 当前服务置顶的存储桶
+	pinnedCount      int               // 列表开头置顶存储桶的数量
 
 	OnBucketSelected func(bucketName string)
+	// OnBucketsLoaded 在存储桶列表加载成功后触发
+	OnBucketsLoaded func(buckets []string)
+	// OnCredentialsExpired 在操作因临时凭证过期失败时触发，重新认证成功后应调用 retry 重试
+	OnCredentialsExpired func(retry func())
 }
 
 
@@ -102,6 +121,7 @@ func NewBucketsView(w fyne.Window, am *AnimationManager) *BucketsView { // 修
 		selectedBucketID: -1,
 		loadingIndicator: NewThinProgressBar(),
 		animationManager: am, // 初始化动画管理器
+		pinnedBuckets:    make(map[string]bool),
 	}
 	bv.loadingIndicator.Hide()
 	return bv
@@ -150,17 +170,47 @@ func (bv *BucketsView) loadBuckets() {
 			bv.loadingIndicator.Hide()
 			if err != nil {
 				log.Printf("列出存储桶失败: %v", err)
-				dialog.ShowError(fmt.Errorf("列出存储桶失败: %v", err), bv.window)
+				if bv.OnCredentialsExpired != nil && s3client.IsExpiredTokenError(err) {
+					bv.OnCredentialsExpired(bv.loadBuckets)
+				} else if bv.S3Client.IsAnonymous() {
+					// 匿名访问通常无权列出存储桶，改为让用户输入要浏览的公开存储桶
+					bv.showOpenPublicBucketDialog()
+				} else {
+					dialog.ShowError(fmt.Errorf(T("列出存储桶失败: %v"), err), bv.window)
+				}
 				bv.buckets = []string{}
 			} else {
-				bv.buckets = buckets
+				bv.setBuckets(buckets)
 			}
 			bv.refreshBucketList()
 			bv.checkDeleteButtonState()
+			if err == nil && bv.OnBucketsLoaded != nil {
+				bv.OnBucketsLoaded(bv.Buckets())
+			}
 		})
 	}()
 }
 
+// Buckets 返回当前显示的存储桶名称
+func (bv *BucketsView) Buckets() []string {
+	return append([]string(nil), bv.buckets...)
+}
+
+// SelectBucket 在列表中选中指定的存储桶，不触发 OnBucketSelected。存储桶不在列表中时取消选中。
+func (bv *BucketsView) SelectBucket(bucketName string) {
+	bv.selectedBucketID = -1
+	for i, name := range bv.buckets {
+		if name == bucketName {
+			bv.selectedBucketID = i
+			break
+		}
+	}
+	if bv.bucketList != nil {
+		bv.bucketList.Refresh()
+	}
+	bv.checkDeleteButtonState()
+}
+
 // refreshBucketList 刷新存储桶列表显示
 func (bv *BucketsView) refreshBucketList() {
 	if bv.bucketList == nil {
@@ -196,10 +246,16 @@ func (bv *BucketsView) checkDeleteButtonState() {
 	}
 
 	bv.deleteButton.Disable()
+	if bv.emptyButton != nil {
+		bv.emptyButton.Disable()
+	}
 
-	if bv.S3Client == nil || bv.selectedBucketID == -1 || bv.selectedBucketID >= len(bv.buckets) {
+	if bv.connecting || bv.S3Client == nil || bv.selectedBucketID == -1 || bv.selectedBucketID >= len(bv.buckets) {
 		return
 	}
+	if bv.emptyButton != nil {
+		bv.emptyButton.Enable()
+	}
 
 	selectedBucket := bv.buckets[bv.selectedBucketID]
 
@@ -228,8 +284,10 @@ func (bv *BucketsView) GetContent() fyne.CanvasObject {
 		},
 		func() fyne.CanvasObject {
 			entry := &bucketListEntry{
-				label: widget.NewLabel("存储桶名称"),
-				bv:    bv,
+				label:   widget.NewLabel(T("存储桶名称")),
+				star:    widget.NewIcon(starIcon),
+				divider: widget.NewSeparator(),
+				bv:      bv,
 			}
 			entry.ExtendBaseWidget(entry)
 			return entry
@@ -238,55 +296,77 @@ func (bv *BucketsView) GetContent() fyne.CanvasObject {
 			entry := obj.(*bucketListEntry)
 			entry.id = id
 			entry.label.SetText(bv.buckets[id])
+			if id < bv.pinnedCount {
+				entry.star.Show()
+			} else {
+				entry.star.Hide()
+			}
+			if id == bv.pinnedCount-1 && bv.pinnedCount < len(bv.buckets) {
+				entry.divider.Show()
+			} else {
+				entry.divider.Hide()
+			}
 			entry.selected = bv.selectedBucketID == id
 			entry.Refresh()
 		},
 	)
 
 	// 创建存储桶按钮
-	createBucketButton := widget.NewButtonWithIcon("创建", theme.ContentAddIcon(), func() {
+	createBucketButton := widget.NewButtonWithIcon(T("创建"), theme.ContentAddIcon(), func() {
 		// 动画结束后执行的逻辑
 		if bv.S3Client == nil {
-			dialog.ShowInformation("提示", "请先选择一个 S3 服务。", bv.window)
+			dialog.ShowInformation(T("提示"), T("请先选择一个 S3 服务。"), bv.window)
 			return
 		}
 		
 		// 创建自定义弹窗以更好地控制尺寸
 		bucketNameEntry := widget.NewEntry()
-		bucketNameEntry.SetPlaceHolder("请输入存储桶名称")
+		bucketNameEntry.SetPlaceHolder(T("请输入存储桶名称"))
 		
 		// 创建一个更宽的输入框
 		wideEntry := container.NewPadded(bucketNameEntry)
 		wideEntry.Objects[0].(*widget.Entry).Wrapping = fyne.TextWrapOff
 		
+		// 区域默认为服务配置的区域，也可以输入列表之外的区域；us-east-1 表示不指定区域，适用于 MinIO 等服务
+		regionSelect := widget.NewSelectEntry(s3client.BucketRegions)
+		regionSelect.SetText(bv.S3Client.Region())
+
 		formContent := container.NewVBox(
-			widget.NewLabel("存储桶名称:"),
+			widget.NewLabel(T("存储桶名称:")),
 			bucketNameEntry,
+			widget.NewLabel(T("区域:")),
+			regionSelect,
 			layout.NewSpacer(),
 		)
 		
 		// 创建自定义对话框
-		createBucketDialog := dialog.NewCustomConfirm("创建存储桶", "创建", "取消", formContent, func(confirmed bool) {
+		createBucketDialog := dialog.NewCustomConfirm(T("创建存储桶"), T("创建"), T("取消"), formContent, func(confirmed bool) {
 			if confirmed {
 				bucketName := bucketNameEntry.Text
 				if bucketName == "" {
-					dialog.ShowInformation("提示", "存储桶名称不能为空。", bv.window)
+					dialog.ShowInformation(T("提示"), T("存储桶名称不能为空。"), bv.window)
 					return
 				}
+				// 在调用 API 前检查命名规则，给出比服务端错误更明确的提示
+				if err := s3client.ValidateBucketName(bucketName); err != nil {
+					ShowToast(bv.window, T(err.Error()))
+					return
+				}
+				region := regionSelect.Text
 				go func() {
-					err := bv.S3Client.CreateBucket(bucketName)
+					err := bv.S3Client.CreateBucket(bucketName, region)
 					fyne.Do(func() {
 						if err != nil {
-							dialog.ShowError(fmt.Errorf("创建存储桶失败: %v", err), bv.window)
+							dialog.ShowError(fmt.Errorf(T("创建存储桶失败: %v"), err), bv.window)
 						} else {
-							dialog.ShowInformation("成功", fmt.Sprintf("存储桶 \"%s\" 创建成功！", bucketName), bv.window)
+							dialog.ShowInformation(T("成功"), fmt.Sprintf(T("存储桶 \"%s\" 创建成功！"), bucketName), bv.window)
 							bv.loadBuckets()
 						}
 					})
 				}()
 			}
 		}, bv.window)
-		createBucketDialog.Resize(fyne.NewSize(400, 200)) // 增大弹窗尺寸
+		createBucketDialog.Resize(fyne.NewSize(400, 260)) // 增大弹窗尺寸
 		createBucketDialog.Show()
 	})
 	
@@ -303,23 +383,23 @@ func (bv *BucketsView) GetContent() fyne.CanvasObject {
 	}
 
 	// 删除存储桶按钮
-	bv.deleteButton = widget.NewButtonWithIcon("删除", theme.DeleteIcon(), func() {
+	bv.deleteButton = widget.NewButtonWithIcon(T("删除"), theme.DeleteIcon(), func() {
 		// 动画结束后执行的逻辑
 		if bv.S3Client == nil || bv.selectedBucketID == -1 || bv.selectedBucketID >= len(bv.buckets) {
-			dialog.ShowInformation("提示", "请先选择一个要删除的存储桶。", bv.window)
+			dialog.ShowInformation(T("提示"), T("请先选择一个要删除的存储桶。"), bv.window)
 			return
 		}
 		selectedBucket := bv.buckets[bv.selectedBucketID]
 
-		dialog.ShowConfirm("确认删除", fmt.Sprintf("确定要删除存储桶 \"%s\" 吗？", selectedBucket), func(confirmed bool) {
+		dialog.ShowConfirm(T("确认删除"), fmt.Sprintf(T("确定要删除存储桶 \"%s\" 吗？"), selectedBucket), func(confirmed bool) {
 			if confirmed {
 				go func() {
 					err := bv.S3Client.DeleteBucket(selectedBucket)
 					fyne.Do(func() {
 						if err != nil {
-							dialog.ShowError(fmt.Errorf("删除存储桶失败: %v", err), bv.window)
+							dialog.ShowError(fmt.Errorf(T("删除存储桶失败: %v"), err), bv.window)
 						} else {
-							dialog.ShowInformation("成功", fmt.Sprintf("存储桶 \"%s\" 删除成功！", selectedBucket), bv.window)
+							dialog.ShowInformation(T("成功"), fmt.Sprintf(T("存储桶 \"%s\" 删除成功！"), selectedBucket), bv.window)
 							bv.loadBuckets()
 						}
 					})
@@ -340,11 +420,36 @@ func (bv *BucketsView) GetContent() fyne.CanvasObject {
 		}
 	}
 	bv.deleteButton.Disable()
+	bv.createButton = createBucketButton
+
+	// 清空存储桶按钮：删除所有对象但保留存储桶
+	bv.emptyButton = widget.NewButtonWithIcon(T("清空"), theme.ContentClearIcon(), func() {
+		if bv.S3Client == nil || bv.selectedBucketID == -1 || bv.selectedBucketID >= len(bv.buckets) {
+			dialog.ShowInformation(T("提示"), T("请先选择一个要清空的存储桶。"), bv.window)
+			return
+		}
+		bv.showEmptyBucketDialog(bv.buckets[bv.selectedBucketID])
+	})
+
+	// 为按钮添加点击动画
+	if bv.animationManager != nil {
+		originalEmptyButtonOnTapped := bv.emptyButton.OnTapped
+		bv.emptyButton.OnTapped = func() {
+			bv.animationManager.AnimateButtonClick(bv.emptyButton, func() {
+				if originalEmptyButtonOnTapped != nil {
+					originalEmptyButtonOnTapped()
+				}
+			})
+		}
+	}
+	bv.emptyButton.Disable()
 
 	buttonBox := container.NewHBox(
 		layout.NewSpacer(),
 		createBucketButton,
 		layout.NewSpacer(),
+		bv.emptyButton,
+		layout.NewSpacer(),
 		bv.deleteButton,
 		layout.NewSpacer(),
 	)
@@ -358,3 +463,25 @@ func (bv *BucketsView) GetContent() fyne.CanvasObject {
 
 	return container.NewBorder(topContent, nil, nil, nil, bv.bucketContainer)
 }
+
+// showOpenPublicBucketDialog 在匿名访问无法列出存储桶时，让用户输入要浏览的公开存储桶名称
+func (bv *BucketsView) showOpenPublicBucketDialog() {
+	bucketNameEntry := widget.NewEntry()
+	bucketNameEntry.SetPlaceHolder(T("请输入存储桶名称"))
+	content := container.NewVBox(
+		widget.NewLabel(T("匿名访问无法列出存储桶，请输入要浏览的公开存储桶名称:")),
+		bucketNameEntry,
+	)
+	d := dialog.NewCustomConfirm(T("打开公开存储桶"), T("打开"), T("取消"), content, func(confirmed bool) {
+		bucketName := strings.TrimSpace(bucketNameEntry.Text)
+		if !confirmed || bucketName == "" {
+			return
+		}
+		bv.selectedBucketID = -1
+		bv.setBuckets([]string{bucketName})
+		bv.refreshBucketList()
+		bv.handleBucketTapped(0)
+	}, bv.window)
+	d.Resize(fyne.NewSize(400, 180))
+	d.Show()
+}
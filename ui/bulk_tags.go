@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+const (
+	bulkTagWorkers          = 8   // 并发设置标签的 worker 数量
+	bulkTagConfirmThreshold = 100 // 超过此数量的对象时，设置前再次确认
+	maxTagKeyLength         = 128 // S3 标签键的最大长度
+	maxTagValueLength       = 256 // S3 标签值的最大长度
+)
+
+// parseTagLines 解析每行一个 "键=值" 的标签文本，忽略空行，值可以为空
+func parseTagLines(text string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case key == "":
+			return nil, fmt.Errorf(T("第 %d 行缺少标签键"), i+1)
+		case len([]rune(key)) > maxTagKeyLength:
+			return nil, fmt.Errorf(T("第 %d 行的标签键超过 %d 个字符"), i+1, maxTagKeyLength)
+		case len([]rune(value)) > maxTagValueLength:
+			return nil, fmt.Errorf(T("第 %d 行的标签值超过 %d 个字符"), i+1, maxTagValueLength)
+		}
+		tags[key] = value
+	}
+	if len(tags) > s3client.MaxObjectTags {
+		return nil, fmt.Errorf(T("每个对象最多只能设置 %d 个标签"), s3client.MaxObjectTags)
+	}
+	return tags, nil
+}
+
+// mergeTags 将 tags 合并到对象现有的标签中，同名标签以 tags 为准；replace 为 true 时直接使用 tags
+func mergeTags(existing, tags map[string]string, replace bool) (map[string]string, error) {
+	merged := make(map[string]string, len(existing)+len(tags))
+	if !replace {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	if len(merged) > s3client.MaxObjectTags {
+		return nil, fmt.Errorf(T("合并后的标签超过 %d 个"), s3client.MaxObjectTags)
+	}
+	return merged, nil
+}
+
+// showBulkTagDialog 让用户输入标签并选择合并或替换，然后为所有选中的对象（包括文件夹中的对象）设置标签
+func (ov *ObjectsView) showBulkTagDialog() {
+	selected := ov.getSelectedObjects()
+	if len(selected) == 0 {
+		ShowToast(ov.window, T("请先选择要设置标签的对象。"))
+		return
+	}
+
+	tagsEntry := widget.NewMultiLineEntry()
+	tagsEntry.SetPlaceHolder(T("每行一个标签，例如：\nproject=alpha\ncost-center=1024"))
+	tagsEntry.SetMinRowsVisible(6)
+	modeRadio := widget.NewRadioGroup([]string{T("合并到现有标签"), T("替换全部标签")}, nil)
+	modeRadio.SetSelected(T("合并到现有标签"))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("标签"), tagsEntry),
+		widget.NewFormItem(T("方式"), modeRadio),
+	}
+	d := dialog.NewForm(T("批量设置标签"), T("应用"), T("取消"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		tags, err := parseTagLines(tagsEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, ov.window)
+			return
+		}
+		replace := modeRadio.Selected == T("替换全部标签")
+		if len(tags) == 0 && !replace {
+			dialog.ShowError(errors.New(T("请至少输入一个标签。")), ov.window)
+			return
+		}
+		ov.runOperation(T("设置标签"), func() {
+			ov.applyTagsToSelection(ov.currentBucket, selected, tags, replace)
+		})
+	}, ov.window)
+	d.Resize(fyne.NewSize(450, 350))
+	d.Show()
+}
+
+// applyTagsToSelection 展开选中的文件夹，然后为其中的所有对象设置标签。在后台 goroutine 中调用。
+func (ov *ObjectsView) applyTagsToSelection(bucket string, selected []s3client.S3Object, tags map[string]string, replace bool) {
+	scanDialog := dialog.NewProgressInfinite(T("批量设置标签"), T("正在扫描选中的对象..."), ov.window)
+	fyne.Do(scanDialog.Show)
+	plan, err := buildDeletePlan(selected, func(prefix string) ([]string, error) {
+		return ov.s3Client.ListAllKeysUnderPrefix(bucket, prefix)
+	})
+	fyne.Do(scanDialog.Hide)
+	if err != nil {
+		fyne.Do(func() {
+			dialog.ShowError(fmt.Errorf(T("扫描部分项目失败: %v"), err), ov.window)
+		})
+		return
+	}
+
+	// 文件夹占位对象只用于显示目录结构，不设置标签
+	keys := make([]string, 0, len(plan))
+	for _, key := range plan {
+		if !strings.HasSuffix(key, "/") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		fyne.Do(func() {
+			dialog.ShowInformation(T("提示"), T("选中的项目中没有对象。"), ov.window)
+		})
+		return
+	}
+
+	if len(keys) > bulkTagConfirmThreshold {
+		confirmed := make(chan bool)
+		fyne.Do(func() {
+			dialog.ShowConfirm(T("批量设置标签"), fmt.Sprintf(T("将为 %d 个对象设置标签，确定继续吗？"), len(keys)), func(ok bool) {
+				confirmed <- ok
+			}, ov.window)
+		})
+		if !<-confirmed {
+			return
+		}
+	}
+
+	failures := ov.putTagsWithProgress(bucket, keys, tags, replace)
+	fyne.Do(func() {
+		if len(failures) > 0 {
+			showTransferFailures(ov.window, T("部分对象设置标签失败"), failures)
+			return
+		}
+		ShowToast(ov.window, fmt.Sprintf(T("已为 %d 个对象设置标签。"), len(keys)))
+	})
+}
+
+// putTagsWithProgress 使用 worker 池为 keys 设置标签并显示进度，返回失败的对象
+func (ov *ObjectsView) putTagsWithProgress(bucket string, keys []string, tags map[string]string, replace bool) []transferFailure {
+	progressDialog := dialog.NewProgress(T("批量设置标签"), T("正在设置标签..."), ov.window)
+	fyne.Do(progressDialog.Show)
+	defer fyne.Do(progressDialog.Hide)
+
+	keyChannel := make(chan string, len(keys))
+	for _, key := range keys {
+		keyChannel <- key
+	}
+	close(keyChannel)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	processed := 0
+	var failures []transferFailure
+
+	for i := 0; i < bulkTagWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keyChannel {
+				err := ov.putObjectTags(bucket, key, tags, replace)
+				mu.Lock()
+				if err != nil {
+					log.Printf("设置对象 '%s' 的标签失败: %v", key, err)
+					failures = append(failures, transferFailure{name: key, errors: []error{err}})
+				}
+				processed++
+				progress := float64(processed) / float64(len(keys))
+				mu.Unlock()
+				fyne.Do(func() { progressDialog.SetValue(progress) })
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(failures, func(i, j int) bool { return failures[i].name < failures[j].name })
+	return failures
+}
+
+// putObjectTags 为单个对象设置标签，合并模式下先读取对象现有的标签
+func (ov *ObjectsView) putObjectTags(bucket, key string, tags map[string]string, replace bool) error {
+	var existing map[string]string
+	if !replace {
+		var err error
+		existing, err = ov.s3Client.GetObjectTags(bucket, key)
+		if err != nil {
+			return err
+		}
+	}
+	merged, err := mergeTags(existing, tags, replace)
+	if err != nil {
+		return err
+	}
+	return ov.s3Client.PutObjectTags(bucket, key, merged)
+}
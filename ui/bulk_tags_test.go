@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTagLines(t *testing.T) {
+	tags, err := parseTagLines("project = alpha\n\n  cost-center=1024\nempty=\nurl=a=b\n")
+	if err != nil {
+		t.Fatalf("parseTagLines 返回错误: %v", err)
+	}
+	want := map[string]string{"project": "alpha", "cost-center": "1024", "empty": "", "url": "a=b"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("parseTagLines = %v, 期望 %v", tags, want)
+	}
+
+	invalid := []string{
+		"=value",
+		strings.Repeat("k", maxTagKeyLength+1) + "=v",
+		"k=" + strings.Repeat("v", maxTagValueLength+1),
+		"a=1\nb=2\nc=3\nd=4\ne=5\nf=6\ng=7\nh=8\ni=9\nj=10\nk=11",
+	}
+	for _, text := range invalid {
+		if _, err := parseTagLines(text); err == nil {
+			t.Errorf("parseTagLines(%.20q...) 应返回错误", text)
+		}
+	}
+}
+
+func TestPutObjectTagsMergeAndReplace(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "a.txt", "a")
+	store.tags["a.txt"] = map[string]string{"owner": "bob", "project": "old"}
+	ov := newTestObjectsView(store, "")
+
+	if err := ov.putObjectTags(testBucket, "a.txt", map[string]string{"project": "alpha"}, false); err != nil {
+		t.Fatalf("合并标签返回错误: %v", err)
+	}
+	if want := map[string]string{"owner": "bob", "project": "alpha"}; !reflect.DeepEqual(store.tags["a.txt"], want) {
+		t.Errorf("合并后的标签 = %v, 期望 %v", store.tags["a.txt"], want)
+	}
+
+	if err := ov.putObjectTags(testBucket, "a.txt", map[string]string{"env": "prod"}, true); err != nil {
+		t.Fatalf("替换标签返回错误: %v", err)
+	}
+	if want := map[string]string{"env": "prod"}; !reflect.DeepEqual(store.tags["a.txt"], want) {
+		t.Errorf("替换后的标签 = %v, 期望 %v", store.tags["a.txt"], want)
+	}
+}
+
+func TestMergeTagsLimit(t *testing.T) {
+	existing := map[string]string{}
+	for _, k := range strings.Split("a b c d e f g h i", " ") {
+		existing[k] = "1"
+	}
+	if _, err := mergeTags(existing, map[string]string{"j": "1"}, false); err != nil {
+		t.Errorf("合并到 10 个标签不应报错: %v", err)
+	}
+	if _, err := mergeTags(existing, map[string]string{"j": "1", "k": "1"}, false); err == nil {
+		t.Error("合并后超过 10 个标签应返回错误")
+	}
+	if _, err := mergeTags(existing, map[string]string{"j": "1", "k": "1"}, true); err != nil {
+		t.Errorf("替换模式只计算新标签: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+)
+
+// 上传、下载、删除、复制等耗时操作在后台运行，期间这些操作会读取当前的存储桶、前缀和选择状态。
+// 为避免与之冲突的操作（再次删除、粘贴、切换目录）在中途修改这些状态，同一时间只允许一个耗时操作运行。
+// 上传和下载只在扫描和确认期间占用耗时操作，之后使用开始时的存储桶在传输面板中后台进行，不阻止其它操作。
+// busyOperation 只在 UI 线程中读写。
+
+// runOperation 在后台执行名为 name 的耗时操作，如果已有操作在运行则提示用户并放弃。
+// 必须在 UI 线程中调用。
+func (ov *ObjectsView) runOperation(name string, fn func()) {
+	if ov.rejectIfBusy() {
+		return
+	}
+	ov.busyOperation = name
+	ov.updateButtonsState()
+
+	go func() {
+		defer fyne.Do(func() {
+			ov.busyOperation = ""
+			ov.updateButtonsState()
+		})
+		fn()
+	}()
+}
+
+// isBusy 返回是否有耗时操作正在运行
+func (ov *ObjectsView) isBusy() bool {
+	return ov.busyOperation != ""
+}
+
+// rejectIfBusy 如果有耗时操作正在运行，则提示用户并返回 true
+func (ov *ObjectsView) rejectIfBusy() bool {
+	if ov.connecting {
+		ShowToast(ov.window, T("正在连接服务，请等待完成后再操作。"))
+		return true
+	}
+	if !ov.isBusy() {
+		return false
+	}
+	ShowToast(ov.window, fmt.Sprintf(T("正在%s，请等待完成后再操作。"), ov.busyOperation))
+	return true
+}
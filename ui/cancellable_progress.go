@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// cancellableProgress 是带有“取消”按钮的进度对话框，点击取消会取消 ctx，正在进行的传输随之中止
+type cancellableProgress struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	bar    *widget.ProgressBar
+	status *widget.Label // 显示传输速度和剩余时间
+	dialog *dialog.CustomDialog
+}
+
+// newCancellableProgress 创建一个带有取消按钮的进度对话框，调用 Show 后显示
+func newCancellableProgress(title, message string, w fyne.Window) *cancellableProgress {
+	ctx, cancel := context.WithCancel(context.Background())
+	bar := widget.NewProgressBar()
+	status := widget.NewLabel("")
+	d := dialog.NewCustom(title, T("取消"), container.NewVBox(widget.NewLabel(message), bar, status), w)
+	d.SetOnClosed(cancel)
+	d.Resize(fyne.NewSize(400, 180))
+	return &cancellableProgress{ctx: ctx, cancel: cancel, bar: bar, status: status, dialog: d}
+}
+
+// SetValue 更新进度条，需要在 UI 线程中调用
+func (p *cancellableProgress) SetValue(value float64) {
+	p.bar.SetValue(value)
+}
+
+// SetStatus 更新进度条下方的状态文字，例如传输速度和剩余时间，需要在 UI 线程中调用
+func (p *cancellableProgress) SetStatus(text string) {
+	p.status.SetText(text)
+}
+
+// Show 显示进度对话框，需要在 UI 线程中调用
+func (p *cancellableProgress) Show() {
+	p.dialog.Show()
+}
+
+// done 关闭进度对话框并释放 ctx，返回操作是否已被用户取消。需要在 UI 线程中调用。
+// 关闭对话框同样会触发 cancel，因此必须在关闭前判断是否已取消。
+func (p *cancellableProgress) done() bool {
+	cancelled := p.ctx.Err() != nil
+	p.dialog.Hide()
+	return cancelled
+}
@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"log"
+	"strings"
+
+	"s3-explorer/common"
+)
+
+// parseURIList 解析 text/uri-list 格式的剪贴板内容，返回其中的本地文件路径。
+// 会忽略注释行以及 GNOME 文件管理器附加的 "copy"/"cut" 操作标记。
+func parseURIList(data string) []string {
+	var paths []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || !strings.HasPrefix(line, "file://") {
+			continue
+		}
+		path, err := common.FileURIToPath(line)
+		if err != nil {
+			log.Printf("解析剪贴板中的文件 URI 失败: %v", err)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
@@ -0,0 +1,39 @@
+//go:build darwin
+
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// readFileURLsScript 使用 JXA 从 NSPasteboard 读取文件 URL，每行输出一个 POSIX 路径
+const readFileURLsScript = `
+ObjC.import('AppKit');
+var pb = $.NSPasteboard.generalPasteboard;
+var urls = pb.readObjectsForClassesOptions($([$.NSURL]), $({NSPasteboardURLReadingFileURLsOnlyKey: true}));
+var paths = [];
+if (urls && !urls.isNil()) {
+	for (var i = 0; i < urls.count; i++) {
+		paths.push(urls.objectAtIndex(i).path.js);
+	}
+}
+paths.join("\n");
+`
+
+// getFilePathsFromClipboard 从 macOS 剪贴板 (NSPasteboard) 读取复制的文件路径
+func getFilePathsFromClipboard() ([]string, error) {
+	output, err := exec.Command("osascript", "-l", "JavaScript", "-e", readFileURLsScript).Output()
+	if err != nil {
+		return nil, fmt.Errorf("读取剪贴板文件失败: %w", err)
+	}
+
+	var paths []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
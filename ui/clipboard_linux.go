@@ -0,0 +1,42 @@
+//go:build linux
+
+package ui
+
+import (
+	"os"
+	"os/exec"
+)
+
+// clipboardCommands 按顺序尝试的剪贴板读取命令，Wayland 下优先使用 wl-paste
+func clipboardCommands() [][]string {
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands,
+			[]string{"wl-paste", "--no-newline", "--type", "text/uri-list"},
+			[]string{"wl-paste", "--no-newline", "--type", "x-special/gnome-copied-files"},
+		)
+	}
+	commands = append(commands,
+		[]string{"xclip", "-selection", "clipboard", "-t", "text/uri-list", "-o"},
+		[]string{"xclip", "-selection", "clipboard", "-t", "x-special/gnome-copied-files", "-o"},
+	)
+	return commands
+}
+
+// getFilePathsFromClipboard 通过 wl-paste 或 xclip 读取剪贴板中的 text/uri-list 并转换为本地路径。
+// 两个工具都不可用或剪贴板中没有文件时返回空列表。
+func getFilePathsFromClipboard() ([]string, error) {
+	for _, args := range clipboardCommands() {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		output, err := exec.Command(args[0], args[1:]...).Output()
+		if err != nil {
+			continue // 剪贴板中没有该类型的数据
+		}
+		if paths := parseURIList(string(output)); len(paths) > 0 {
+			return paths, nil
+		}
+	}
+	return nil, nil
+}
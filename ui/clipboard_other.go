@@ -1,9 +1,9 @@
-//go:build !windows
+//go:build !windows && !darwin && !linux
 
 package ui
 
-// getFilePathsFromClipboard 在非Windows平台上返回空列表
-// 因为非Windows平台可能有不同的剪贴板处理机制
+// getFilePathsFromClipboard 在没有专门实现的平台上返回空列表
+// 此时粘贴上传依赖对剪贴板文本内容的解析
 func getFilePathsFromClipboard() ([]string, error) {
 	return nil, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxHighlightSize 超过该大小的文件不做语法高亮，直接显示纯文本，避免渲染大量片段时界面卡顿
+const maxHighlightSize = 1 << 20
+
+// codeTokenKind 是语法高亮中片段的类别
+type codeTokenKind int
+
+const (
+	tokenPlain codeTokenKind = iota
+	tokenKeyword
+	tokenString
+	tokenComment
+	tokenNumber
+)
+
+// codeToken 是高亮后的一段文本
+type codeToken struct {
+	kind codeTokenKind
+	text string
+}
+
+// codeLanguage 描述一种语言的词法规则，只覆盖关键字、字符串、注释和数字，足够用于预览
+type codeLanguage struct {
+	keywords     map[string]struct{}
+	lineComment  string    // 行注释的开始标记，为空表示没有行注释
+	blockComment [2]string // 块注释的开始和结束标记，为空表示没有块注释
+	quotes       string    // 可以开始字符串的引号
+	rawQuote     byte      // 不处理转义、可以跨行的字符串引号，如 Go 的反引号，为 0 表示没有
+	tripleQuotes bool      // 是否支持 Python 的三引号字符串
+}
+
+func keywordSet(words string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range strings.Fields(words) {
+		set[w] = struct{}{}
+	}
+	return set
+}
+
+// codeLanguages 按文件扩展名列出支持语法高亮的语言
+var codeLanguages = map[string]*codeLanguage{
+	".go": {
+		keywords: keywordSet(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var
+			true false nil iota`),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `"'`,
+		rawQuote:     '`',
+	},
+	".js": {
+		keywords: keywordSet(`async await break case catch class const continue debugger default delete do else
+			export extends finally for function if import in instanceof let new of return static super
+			switch this throw try typeof var void while yield true false null undefined`),
+		lineComment:  "//",
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `"'`,
+		rawQuote:     '`',
+	},
+	".json": {
+		keywords: keywordSet(`true false null`),
+		quotes:   `"`,
+	},
+	".py": {
+		keywords: keywordSet(`and as assert async await break class continue def del elif else except finally
+			for from global if import in is lambda nonlocal not or pass raise return try while with yield
+			True False None self`),
+		lineComment:  "#",
+		quotes:       `"'`,
+		tripleQuotes: true,
+	},
+}
+
+// highlightCode 将 text 按 ext 对应的语言切分为高亮片段，相邻的同类片段会被合并。
+// 不支持的扩展名返回 nil。
+func highlightCode(ext, text string) []codeToken {
+	lang := codeLanguages[strings.ToLower(ext)]
+	if lang == nil {
+		return nil
+	}
+
+	var tokens []codeToken
+	emit := func(kind codeTokenKind, s string) {
+		if n := len(tokens); n > 0 && tokens[n-1].kind == kind {
+			tokens[n-1].text += s
+			return
+		}
+		tokens = append(tokens, codeToken{kind: kind, text: s})
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		c := text[i]
+		switch {
+		case lang.lineComment != "" && strings.HasPrefix(rest, lang.lineComment):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			emit(tokenComment, rest[:end])
+			i += end
+		case lang.blockComment[0] != "" && strings.HasPrefix(rest, lang.blockComment[0]):
+			end := strings.Index(rest[len(lang.blockComment[0]):], lang.blockComment[1])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(lang.blockComment[0]) + len(lang.blockComment[1])
+			}
+			emit(tokenComment, rest[:end])
+			i += end
+		case lang.rawQuote != 0 && c == lang.rawQuote:
+			end := strings.IndexByte(rest[1:], c)
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += 2
+			}
+			emit(tokenString, rest[:end])
+			i += end
+		case strings.IndexByte(lang.quotes, c) >= 0:
+			n := stringLiteralLength(rest, lang.tripleQuotes)
+			emit(tokenString, rest[:n])
+			i += n
+		case isDigit(c) && (i == 0 || !isIdentByte(text[i-1])):
+			n := 1
+			for n < len(rest) && (isIdentByte(rest[n]) || rest[n] == '.') {
+				n++
+			}
+			emit(tokenNumber, rest[:n])
+			i += n
+		case isIdentByte(c):
+			n := 1
+			for n < len(rest) && isIdentByte(rest[n]) {
+				n++
+			}
+			if _, ok := lang.keywords[rest[:n]]; ok {
+				emit(tokenKeyword, rest[:n])
+			} else {
+				emit(tokenPlain, rest[:n])
+			}
+			i += n
+		default:
+			emit(tokenPlain, rest[:1])
+			i++
+		}
+	}
+	return tokens
+}
+
+// stringLiteralLength 返回以引号开头的 s 中字符串字面量的字节长度。普通字符串处理反斜杠转义，
+// 在换行处结束；三引号字符串可以跨行。没有结束引号时到行尾（三引号时到文本末尾）为止。
+func stringLiteralLength(s string, tripleQuotes bool) int {
+	quote := s[0]
+	if triple := strings.Repeat(string(quote), 3); tripleQuotes && strings.HasPrefix(s, triple) {
+		if end := strings.Index(s[3:], triple); end >= 0 {
+			return end + 6
+		}
+		return len(s)
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '\n':
+			return i
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentByte 报告 c 是否可以出现在标识符中。非 ASCII 字节都视为标识符的一部分，避免切开多字节字符。
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || c >= 0x80 || unicode.IsLetter(rune(c))
+}
+
+// codeTokenColors 是各类片段使用的主题颜色
+var codeTokenColors = map[codeTokenKind]fyne.ThemeColorName{
+	tokenPlain:   theme.ColorNameForeground,
+	tokenKeyword: theme.ColorNamePrimary,
+	tokenString:  theme.ColorNameSuccess,
+	tokenComment: theme.ColorNamePlaceHolder,
+	tokenNumber:  theme.ColorNameWarning,
+}
+
+// codeSegments 将高亮片段转换为等宽字体的 RichText 片段
+func codeSegments(tokens []codeToken) []widget.RichTextSegment {
+	segments := make([]widget.RichTextSegment, 0, len(tokens))
+	for _, tok := range tokens {
+		segments = append(segments, &widget.TextSegment{
+			Text: tok.text,
+			Style: widget.RichTextStyle{
+				ColorName: codeTokenColors[tok.kind],
+				Inline:    true,
+				SizeName:  theme.SizeNameText,
+				TextStyle: fyne.TextStyle{Monospace: true},
+			},
+		})
+	}
+	return segments
+}
+
+// newCodeView 返回文本的语法高亮视图。扩展名不支持或文本超过 maxHighlightSize 时返回 nil，由调用方显示纯文本。
+func newCodeView(ext, text string) *widget.RichText {
+	if len(text) > maxHighlightSize {
+		return nil
+	}
+	tokens := highlightCode(ext, text)
+	if tokens == nil {
+		return nil
+	}
+	return widget.NewRichText(codeSegments(tokens)...)
+}
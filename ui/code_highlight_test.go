@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHighlightCode(t *testing.T) {
+	tests := []struct {
+		ext, text string
+		want      []codeToken
+	}{
+		{".go", "func f() { return 42 } // done", []codeToken{
+			{tokenKeyword, "func"}, {tokenPlain, " f() { "}, {tokenKeyword, "return"}, {tokenPlain, " "},
+			{tokenNumber, "42"}, {tokenPlain, " } "}, {tokenComment, "// done"},
+		}},
+		{".go", "s := `a\nb` + \"x\\\"y\"", []codeToken{
+			{tokenPlain, "s := "}, {tokenString, "`a\nb`"}, {tokenPlain, " + "}, {tokenString, `"x\"y"`},
+		}},
+		{".JS", "/* c */ const v2 = 'x'", []codeToken{
+			{tokenComment, "/* c */"}, {tokenPlain, " "}, {tokenKeyword, "const"}, {tokenPlain, " v2 = "}, {tokenString, "'x'"},
+		}},
+		{".json", `{"a": true, "b": -1.5}`, []codeToken{
+			{tokenPlain, "{"}, {tokenString, `"a"`}, {tokenPlain, ": "}, {tokenKeyword, "true"}, {tokenPlain, ", "},
+			{tokenString, `"b"`}, {tokenPlain, ": -"}, {tokenNumber, "1.5"}, {tokenPlain, "}"},
+		}},
+		{".py", "def f():\n    \"\"\"doc\nmore\"\"\"  # note", []codeToken{
+			{tokenKeyword, "def"}, {tokenPlain, " f():\n    "}, {tokenString, "\"\"\"doc\nmore\"\"\""}, {tokenPlain, "  "}, {tokenComment, "# note"},
+		}},
+		// 未结束的字符串在行尾结束，不影响下一行
+		{".go", "x := \"open\nif", []codeToken{
+			{tokenPlain, "x := "}, {tokenString, `"open`}, {tokenPlain, "\n"}, {tokenKeyword, "if"},
+		}},
+	}
+	for _, tt := range tests {
+		got := highlightCode(tt.ext, tt.text)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("highlightCode(%q, %q) = %v, 期望 %v", tt.ext, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightCodeKeepsText(t *testing.T) {
+	text := "package main\n\n// 中文注释\nvar 名字 = \"值\" /* 未结束"
+	var b strings.Builder
+	for _, tok := range highlightCode(".go", text) {
+		b.WriteString(tok.text)
+	}
+	if b.String() != text {
+		t.Errorf("高亮片段拼接后 = %q, 期望与原文相同", b.String())
+	}
+	if highlightCode(".txt", text) != nil {
+		t.Error("不支持的扩展名应返回 nil")
+	}
+}
+
+func TestNewCodeViewSkipsLargeFiles(t *testing.T) {
+	if newCodeView(".go", strings.Repeat("a", maxHighlightSize+1)) != nil {
+		t.Error("超过大小限制的文件不应高亮")
+	}
+}
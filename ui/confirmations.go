@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// confirmDeleteSetting 返回删除对象前是否确认，默认开启
+func confirmDeleteSetting() bool {
+	return fyne.CurrentApp().Preferences().BoolWithFallback(prefConfirmDelete, true)
+}
+
+// confirmOverwriteSetting 返回覆盖已有对象前是否确认，默认开启
+func confirmOverwriteSetting() bool {
+	return fyne.CurrentApp().Preferences().BoolWithFallback(prefConfirmOverwrite, true)
+}
+
+// confirmPasteSetting 返回粘贴复制的对象前是否确认，默认开启
+func confirmPasteSetting() bool {
+	return fyne.CurrentApp().Preferences().BoolWithFallback(prefConfirmPaste, true)
+}
+
+// confirmIfEnabled 在 enabled 为 true 时显示确认对话框，确认后执行 proceed；关闭确认时直接执行 proceed
+func confirmIfEnabled(enabled bool, title, message string, w fyne.Window, proceed func()) {
+	if !enabled {
+		proceed()
+		return
+	}
+	dialog.ShowConfirm(title, message, func(confirmed bool) {
+		if confirmed {
+			proceed()
+		}
+	}, w)
+}
+
+// suppressiblePrompts 是可以在对话框中勾选“不再提示”的提示，只包含不会造成数据丢失的提示
+var suppressiblePrompts = []string{prefSuppressPastePrompt, prefSuppressLargeUploadPrompt, prefSuppressMovePrompt}
+
+// promptSuppressed 返回用户是否已对该提示勾选“不再提示”
+func promptSuppressed(pref string) bool {
+	return fyne.CurrentApp().Preferences().Bool(pref)
+}
+
+// suppressedPromptCount 返回已勾选“不再提示”的提示数量
+func suppressedPromptCount() int {
+	count := 0
+	for _, pref := range suppressiblePrompts {
+		if promptSuppressed(pref) {
+			count++
+		}
+	}
+	return count
+}
+
+// resetSuppressedPrompts 重新显示所有勾选过“不再提示”的提示
+func resetSuppressedPrompts() {
+	prefs := fyne.CurrentApp().Preferences()
+	for _, pref := range suppressiblePrompts {
+		prefs.RemoveValue(pref)
+	}
+}
+
+// newDontAskAgainCheck 创建“不再提示”复选框，返回的函数在用户确认后调用，勾选时保存设置
+func newDontAskAgainCheck(pref string) (*widget.Check, func()) {
+	check := widget.NewCheck(T("不再提示"), nil)
+	return check, func() {
+		if check.Checked {
+			fyne.CurrentApp().Preferences().SetBool(pref, true)
+		}
+	}
+}
+
+// confirmSuppressible 显示带“不再提示”复选框的确认对话框并通过 callback 返回用户的选择，
+// 用户已勾选过“不再提示”时不显示对话框，直接以 true 调用 callback
+func confirmSuppressible(pref, title, message string, w fyne.Window, callback func(confirmed bool)) {
+	if promptSuppressed(pref) {
+		callback(true)
+		return
+	}
+	label := widget.NewLabel(message)
+	label.Wrapping = fyne.TextWrapWord
+	check, remember := newDontAskAgainCheck(pref)
+	d := dialog.NewCustomConfirm(title, T("继续"), T("取消"), container.NewVBox(label, check), func(confirmed bool) {
+		if confirmed {
+			remember()
+		}
+		callback(confirmed)
+	}, w)
+	d.Resize(fyne.NewSize(420, 200))
+	d.Show()
+}
+
+// ShowConfirmationSettingsDialog 显示操作确认设置对话框，可以分别关闭删除、覆盖和粘贴前的确认
+func ShowConfirmationSettingsDialog(w fyne.Window) {
+	showSettingsPages(w, T("操作确认"), fyne.NewSize(400, 300), confirmationSettingsPage(w))
+}
+
+// confirmationSettingsPage 创建操作确认设置页，w 用于显示重新显示提示后的通知
+func confirmationSettingsPage(w fyne.Window) settingsPage {
+	deleteCheck := widget.NewCheck(T("删除对象前确认"), nil)
+	deleteCheck.SetChecked(confirmDeleteSetting())
+	overwriteCheck := widget.NewCheck(T("覆盖已有对象前确认"), nil)
+	overwriteCheck.SetChecked(confirmOverwriteSetting())
+	pasteCheck := widget.NewCheck(T("粘贴复制的对象前确认"), nil)
+	pasteCheck.SetChecked(confirmPasteSetting())
+
+	// 重新显示在对话框中勾选过“不再提示”的提示
+	var resetButton *widget.Button
+	resetButton = widget.NewButton(fmt.Sprintf(T("重新显示已关闭的提示 (%d)"), suppressedPromptCount()), func() {
+		resetSuppressedPrompts()
+		resetButton.SetText(fmt.Sprintf(T("重新显示已关闭的提示 (%d)"), 0))
+		resetButton.Disable()
+		ShowToast(w, T("所有提示都将重新显示。"))
+	})
+	if suppressedPromptCount() == 0 {
+		resetButton.Disable()
+	}
+
+	return settingsPage{
+		title: T("操作确认"),
+		content: container.NewVBox(
+			deleteCheck,
+			overwriteCheck,
+			pasteCheck,
+			widget.NewLabel(T("关闭确认后操作将立即执行，删除和覆盖无法撤销。")),
+			widget.NewSeparator(),
+			resetButton,
+		),
+		save: func() {
+			prefs := fyne.CurrentApp().Preferences()
+			prefs.SetBool(prefConfirmDelete, deleteCheck.Checked)
+			prefs.SetBool(prefConfirmOverwrite, overwriteCheck.Checked)
+			prefs.SetBool(prefConfirmPaste, pasteCheck.Checked)
+		},
+	}
+}
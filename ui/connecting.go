@@ -0,0 +1,41 @@
+package ui
+
+// 选择服务后在后台创建客户端，创建完成前存储桶和对象视图显示连接中的状态并禁用工具栏，
+// 避免连接较慢的服务时界面看起来像卡住了。以下方法只能在 UI 线程中调用。
+
+// SetConnecting 设置是否正在连接服务。连接期间对象区域显示"正在连接服务…"，依赖服务的按钮被禁用。
+func (ov *ObjectsView) SetConnecting(connecting bool) {
+	ov.connecting = connecting
+	if connecting {
+		ov.loadingIndicator.Show()
+	} else {
+		ov.loadingIndicator.Hide()
+	}
+	for _, button := range ov.toolbarButtons {
+		if connecting {
+			button.Disable()
+		} else {
+			button.Enable()
+		}
+	}
+	ov.refreshObjectView()
+	ov.updateButtonsState()
+}
+
+// SetConnecting 设置是否正在连接服务，连接期间显示加载指示器并禁用存储桶操作按钮
+func (bv *BucketsView) SetConnecting(connecting bool) {
+	bv.connecting = connecting
+	if connecting {
+		bv.loadingIndicator.Show()
+	} else {
+		bv.loadingIndicator.Hide()
+	}
+	if bv.createButton != nil {
+		if connecting {
+			bv.createButton.Disable()
+		} else {
+			bv.createButton.Enable()
+		}
+	}
+	bv.checkDeleteButtonState()
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// copySummary 是对已复制对象的统计结果。复制操作本身立即完成，文件夹中的对象数量和总大小在后台统计，
+// 统计完成后关闭 done，粘贴确认对话框随之更新。
+type copySummary struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	objects int   // 实际要复制的对象数量，文件夹按其中的对象计算
+	bytes   int64 // 对象的总大小
+	err     error
+}
+
+// copiedSummary 是最近一次复制的统计，受 copiedObjectsLock 保护
+var copiedSummary *copySummary
+
+// summarizeCopy 统计复制 items 时实际涉及的对象数量和总大小，文件夹会展开为其前缀下的所有对象
+func summarizeCopy(ctx context.Context, store ObjectStore, bucket string, items []s3client.S3Object) (int, int64, error) {
+	var count int
+	var size int64
+	for _, item := range items {
+		if !item.IsFolder {
+			count++
+			size += item.Size
+			continue
+		}
+		err := store.WalkObjects(ctx, bucket, item.Key, func(obj s3client.S3Object) error {
+			count++
+			size += obj.Size
+			return nil
+		})
+		if err != nil {
+			return count, size, fmt.Errorf("统计文件夹 '%s' 失败: %w", item.Name, err)
+		}
+	}
+	return count, size, nil
+}
+
+// startCopySummary 在后台统计刚复制的对象，并取消上一次尚未完成的统计
+func (ov *ObjectsView) startCopySummary(items []s3client.S3Object) {
+	ctx, cancel := context.WithCancel(context.Background())
+	summary := &copySummary{done: make(chan struct{}), cancel: cancel}
+
+	copiedObjectsLock.Lock()
+	if copiedSummary != nil {
+		copiedSummary.cancel()
+	}
+	copiedSummary = summary
+	copiedObjectsLock.Unlock()
+
+	client, bucket := ov.s3Client, ov.currentBucket
+	go func() {
+		defer close(summary.done)
+		defer cancel()
+		summary.objects, summary.bytes, summary.err = summarizeCopy(ctx, client, bucket, items)
+		if summary.err != nil && ctx.Err() == nil {
+			log.Printf("统计复制的对象失败: %v", summary.err)
+			Notify(T("统计复制的对象失败"), summary.err.Error())
+		}
+	}()
+}
+
+// pasteSummaryText 返回粘贴确认对话框中的说明文字。统计尚未完成或失败时只显示选中的项目数量。
+func pasteSummaryText(itemCount int, summary *copySummary) string {
+	if summary != nil {
+		select {
+		case <-summary.done:
+			if summary.err == nil {
+				return fmt.Sprintf(T("将粘贴 %d 个对象，共 %s，是否继续？"), summary.objects, formatBytes(summary.bytes))
+			}
+		default:
+			return fmt.Sprintf(T("是否要粘贴 %d 个已复制的对象到当前目录？"), itemCount) + "\n" + T("正在统计对象数量和大小...")
+		}
+	}
+	return fmt.Sprintf(T("是否要粘贴 %d 个已复制的对象到当前目录？"), itemCount)
+}
+
+// confirmPasteS3Objects 显示粘贴确认对话框，后台统计完成后更新对话框中的对象数量和总大小。
+// 关闭粘贴确认或勾选过“不再提示”时直接开始粘贴。
+func (ov *ObjectsView) confirmPasteS3Objects(items []s3client.S3Object) {
+	if !confirmPasteSetting() || promptSuppressed(prefSuppressPastePrompt) {
+		ov.runOperation(T("复制"), func() { ov.pasteS3Objects(items) })
+		return
+	}
+
+	copiedObjectsLock.RLock()
+	summary := copiedSummary
+	copiedObjectsLock.RUnlock()
+
+	message := widget.NewLabel(pasteSummaryText(len(items), summary))
+	message.Wrapping = fyne.TextWrapWord
+	dontAskCheck, remember := newDontAskAgainCheck(prefSuppressPastePrompt)
+	d := dialog.NewCustomConfirm(T("确认粘贴"), T("粘贴"), T("取消"), container.NewVBox(message, dontAskCheck), func(confirmed bool) {
+		if confirmed {
+			remember()
+			ov.runOperation(T("复制"), func() { ov.pasteS3Objects(items) })
+		}
+	}, ov.window)
+	d.Resize(fyne.NewSize(400, 210))
+	d.Show()
+
+	if summary != nil {
+		go func() {
+			<-summary.done
+			fyne.Do(func() { message.SetText(pasteSummaryText(len(items), summary)) })
+		}()
+	}
+}
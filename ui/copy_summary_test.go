@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestSummarizeCopyExpandsFolders(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/", "")
+	store.put(testBucket, "docs/a.txt", "12345")
+	store.put(testBucket, "docs/sub/b.txt", "123")
+	store.put(testBucket, "readme.md", "1234567")
+
+	items := []s3client.S3Object{
+		{Name: "docs", Key: "docs/", IsFolder: true},
+		{Name: "readme.md", Key: "readme.md", Size: 7},
+	}
+	count, size, err := summarizeCopy(context.Background(), store, testBucket, items)
+	if err != nil {
+		t.Fatalf("summarizeCopy 返回错误: %v", err)
+	}
+	if count != 3 || size != 15 {
+		t.Errorf("summarizeCopy = %d 个对象、%d 字节，期望 3 个对象、15 字节", count, size)
+	}
+}
+
+func TestPasteSummaryTextUpdatesWhenDone(t *testing.T) {
+	summary := &copySummary{done: make(chan struct{})}
+	pending := pasteSummaryText(2, summary)
+
+	summary.objects, summary.bytes = 3, 15
+	close(summary.done)
+	if done := pasteSummaryText(2, summary); done == pending {
+		t.Errorf("统计完成后说明文字应包含统计结果，实际为 %q", done)
+	}
+}
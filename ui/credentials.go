@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/config"
+)
+
+// ShowReauthenticateDialog 在当前服务的临时凭证过期时提示用户重新输入凭证或重新加载配置。
+// 重新认证成功后调用 onUpdated，参数为更新后的服务配置；对话框显示期间的重复请求会合并，
+// 所有回调都会在认证成功后依次执行。
+func (sv *ServicesView) ShowReauthenticateDialog(onUpdated func(svc config.S3ServiceConfig)) {
+	if sv.configStore == nil || sv.selectedServiceID == -1 || sv.selectedServiceID >= len(sv.configStore.Services) {
+		return
+	}
+
+	if sv.pendingReauth != nil {
+		sv.pendingReauth.add(onUpdated)
+		return // 对话框已经显示或正在重新加载配置，等待处理完成
+	}
+	req := &reauthRequest{}
+	req.add(onUpdated)
+	sv.pendingReauth = req
+
+	selectedService := sv.configStore.Services[sv.selectedServiceID]
+	alias := selectedService.Alias
+
+	// finish 执行所有等待中的回调；svc 为 nil 表示用户取消
+	finish := func(svc *config.S3ServiceConfig) {
+		sv.pendingReauth = nil
+		req.finish(svc)
+	}
+
+	accessKeyEntry := widget.NewEntry()
+	accessKeyEntry.SetText(selectedService.AccessKey)
+	secretKeyEntry := widget.NewPasswordEntry()
+	secretKeyEntry.SetText(selectedService.SecretKey)
+	tokenEntry := widget.NewPasswordEntry()
+	tokenEntry.SetPlaceHolder(T("可选，使用临时凭证时填写"))
+	tokenEntry.SetText(selectedService.SessionToken)
+
+	var d dialog.Dialog
+	// 从数据库重新加载配置，适用于凭证已被外部工具更新的情况
+	reloadButton := widget.NewButton(T("重新加载配置"), func() {
+		// 隐藏对话框会以未确认的结果调用回调，先标记为重新加载，避免丢弃等待中的回调
+		req.reloading = true
+		d.Hide()
+		sv.loadConfig(func() {
+			for _, svc := range sv.configStore.Services {
+				if svc.Alias == alias {
+					finish(&svc)
+					return
+				}
+			}
+			log.Printf("重新加载配置后未找到服务 '%s'", alias)
+			finish(nil)
+		})
+	})
+
+	formContent := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf(T("服务 \"%s\" 的临时凭证已过期，请重新输入凭证或重新加载配置。"), alias)),
+		container.New(layout.NewFormLayout(),
+			widget.NewLabel("Access Key:"), accessKeyEntry,
+			widget.NewLabel("Secret Key:"), secretKeyEntry,
+			widget.NewLabel("Session Token:"), tokenEntry,
+		),
+		reloadButton,
+	)
+
+	d = dialog.NewCustomConfirm(T("凭证已过期"), T("保存并重试"), T("取消"), formContent, func(confirmed bool) {
+		if !confirmed {
+			if req.dismiss() {
+				sv.pendingReauth = nil
+			}
+			return
+		}
+		if accessKeyEntry.Text == "" || secretKeyEntry.Text == "" {
+			dialog.ShowInformation(T("提示"), T("Access Key 和 Secret Key 不能为空！"), sv.window)
+			finish(nil)
+			return
+		}
+		updatedService := selectedService
+		updatedService.AccessKey = accessKeyEntry.Text
+		updatedService.SecretKey = secretKeyEntry.Text
+		updatedService.SessionToken = strings.TrimSpace(tokenEntry.Text)
+		if err := sv.configStore.UpdateService(alias, updatedService); err != nil {
+			dialog.ShowError(fmt.Errorf(T("更新服务失败: %v"), err), sv.window)
+			finish(nil)
+			return
+		}
+		sv.loadConfig(func() {
+			finish(&updatedService)
+		})
+	}, sv.window)
+	d.Resize(fyne.NewSize(450, 290))
+	d.Show()
+}
+
+// reauthRequest 记录一次重新认证期间等待执行的回调。对话框显示期间的重复请求合并到同一个 reauthRequest。
+// 只在 UI 线程中使用。
+type reauthRequest struct {
+	callbacks []func(svc config.S3ServiceConfig)
+	reloading bool // 用户选择了重新加载配置，对话框关闭不表示取消
+}
+
+// add 添加一个等待重新认证完成的回调
+func (r *reauthRequest) add(cb func(svc config.S3ServiceConfig)) {
+	r.callbacks = append(r.callbacks, cb)
+}
+
+// dismiss 处理对话框未确认就关闭的情况。由“重新加载配置”关闭时保留回调，等待加载完成后执行，返回 false；
+// 用户取消时丢弃回调并返回 true。
+func (r *reauthRequest) dismiss() bool {
+	if r.reloading {
+		return false
+	}
+	r.finish(nil)
+	return true
+}
+
+// finish 依次执行所有等待中的回调并清空；svc 为 nil 表示用户取消，只丢弃回调
+func (r *reauthRequest) finish(svc *config.S3ServiceConfig) {
+	callbacks := r.callbacks
+	r.callbacks = nil
+	if svc == nil {
+		return
+	}
+	for _, cb := range callbacks {
+		if cb != nil {
+			cb(*svc)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"s3-explorer/config"
+)
+
+// 点击“重新加载配置”会先关闭对话框，关闭时不应丢弃等待中的回调，加载完成后所有回调都要重试
+func TestReauthReloadKeepsPendingCallbacks(t *testing.T) {
+	var retried []string
+	req := &reauthRequest{}
+	req.add(func(svc config.S3ServiceConfig) { retried = append(retried, "list:"+svc.Alias) })
+	req.add(func(svc config.S3ServiceConfig) { retried = append(retried, "upload:"+svc.Alias) })
+
+	req.reloading = true
+	if req.dismiss() {
+		t.Fatal("重新加载配置时关闭对话框不应视为取消")
+	}
+	req.finish(&config.S3ServiceConfig{Alias: "minio"})
+
+	if len(retried) != 2 || retried[0] != "list:minio" || retried[1] != "upload:minio" {
+		t.Errorf("重新加载后执行的回调 = %v, 期望 [list:minio upload:minio]", retried)
+	}
+}
+
+func TestReauthCancelDropsCallbacks(t *testing.T) {
+	called := false
+	req := &reauthRequest{}
+	req.add(func(config.S3ServiceConfig) { called = true })
+
+	if !req.dismiss() {
+		t.Fatal("用户取消时应结束重新认证")
+	}
+	req.finish(&config.S3ServiceConfig{Alias: "minio"})
+	if called {
+		t.Error("取消后不应再执行回调")
+	}
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"s3-explorer/s3client"
+)
+
+// buildDeletePlan 根据选中的文件和文件夹生成待删除的对象键列表。
+// 文件夹会展开为其前缀下的所有键，并包含文件夹占位对象本身；
+// 重复的键（例如同时选中了文件夹和其中的文件）只会出现一次，
+// 因此返回列表的长度就是实际执行的删除次数，可直接作为进度的分母。
+func buildDeletePlan(selected []s3client.S3Object, listKeys func(prefix string) ([]string, error)) ([]string, error) {
+	keySet := make(map[string]struct{})
+	for _, item := range selected {
+		if !item.IsFolder {
+			keySet[item.Key] = struct{}{}
+			continue
+		}
+
+		prefix := item.Key
+		if !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		keys, err := listKeys(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("扫描文件夹 '%s' 失败: %w", item.Name, err)
+		}
+		for _, key := range keys {
+			keySet[key] = struct{}{}
+		}
+		// 文件夹占位对象可能不存在，删除不存在的键在 S3 中同样会成功
+		keySet[prefix] = struct{}{}
+	}
+
+	plan := make([]string, 0, len(keySet))
+	for key := range keySet {
+		plan = append(plan, key)
+	}
+	sort.Strings(plan)
+	return plan, nil
+}
+
+// deleteBatchWorkers 是同时发送的批量删除请求的最大数量，传输并发数更小时使用传输并发数
+const deleteBatchWorkers = 4
+
+// deleteKeysInBatches 使用 DeleteObjects 每批删除最多 s3client.MaxDeleteBatchSize 个对象，返回删除失败的键。
+// 每个批次完成后以该批实际删除的数量调用 onBatch（可为 nil），onBatch 可能被多个 goroutine 同时调用。
+// ctx 被取消后不再发送新的批次，因取消而中止的批次不计为失败。
+func (ov *ObjectsView) deleteKeysInBatches(ctx context.Context, bucket string, keys []string, onBatch func(deleted int)) []string {
+	batches := make(chan []string, len(keys)/s3client.MaxDeleteBatchSize+1)
+	for start := 0; start < len(keys); start += s3client.MaxDeleteBatchSize {
+		end := start + s3client.MaxDeleteBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batches <- keys[start:end]
+	}
+	close(batches)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed []string
+	for i := 0; i < min(deleteBatchWorkers, transferWorkers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if ctx.Err() != nil {
+					continue // 已取消，丢弃剩余批次
+				}
+				batchFailed, err := ov.s3Client.DeleteObjects(ctx, bucket, batch)
+				if err != nil && ctx.Err() != nil {
+					continue
+				}
+				if err != nil {
+					// 整个请求失败时无法确定哪些对象已删除，整批视为失败
+					log.Printf("批量删除 %d 个对象失败: %v", len(batch), err)
+					batchFailed = batch
+				}
+				for _, key := range batchFailed {
+					log.Printf("删除对象 '%s' 失败", key)
+				}
+				mu.Lock()
+				failed = append(failed, batchFailed...)
+				mu.Unlock()
+				if onBatch != nil {
+					onBatch(len(batch) - len(batchFailed))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	sort.Strings(failed)
+	return failed
+}
+
+// getSelectedObjects 返回当前选中的对象
+func (ov *ObjectsView) getSelectedObjects() []s3client.S3Object {
+	items := ov.getDisplayedObjects()
+	var selected []s3client.S3Object
+	for id := range ov.selectedObjectIDs {
+		if id < len(items) {
+			selected = append(selected, items[id])
+		}
+	}
+	return selected
+}
+
+// confirmDeleteSelected 确认后删除当前选中的文件和文件夹
+func (ov *ObjectsView) confirmDeleteSelected() {
+	if ov.rejectIfBusy() {
+		return
+	}
+	selected := ov.getSelectedObjects()
+	if len(selected) == 0 {
+		ShowToast(ov.window, T("请先选择要删除的文件或文件夹。"))
+		return
+	}
+
+	bucket := ov.currentBucket
+	confirmIfEnabled(confirmDeleteSetting(), T("确认删除"), fmt.Sprintf(T("确定要删除选中的 %d 个项目吗？"), len(selected)), ov.window, func() {
+		ov.runOperation(T("删除"), func() { ov.deleteObjectsWithProgress(bucket, selected) })
+	})
+}
+
+// deleteObjectsWithProgress 先扫描生成删除计划，再分批删除并显示总进度
+func (ov *ObjectsView) deleteObjectsWithProgress(bucket string, selected []s3client.S3Object) {
+	// --- 为删除操作进行初步扫描以获取项目总数 ---
+	scanProgressDialog := dialog.NewProgressInfinite(T("正在准备删除"), T("正在扫描待删除项目..."), ov.window)
+	fyne.Do(scanProgressDialog.Show)
+
+	plan, err := buildDeletePlan(selected, func(prefix string) ([]string, error) {
+		return ov.s3Client.ListAllKeysUnderPrefix(bucket, prefix)
+	})
+	fyne.Do(scanProgressDialog.Hide)
+	if err != nil {
+		fyne.Do(func() {
+			dialog.ShowError(fmt.Errorf(T("扫描部分项目失败: %v"), err), ov.window)
+		})
+		return
+	}
+	if len(plan) == 0 {
+		fyne.Do(func() {
+			dialog.ShowInformation(T("提示"), T("没有可删除的项目。"), ov.window)
+		})
+		return
+	}
+
+	// --- 执行实际删除操作并显示可取消的进度条 ---
+	deleteProgressDialog := newCancellableProgress(T("正在删除"), T("正在删除项目..."), ov.window)
+	fyne.Do(deleteProgressDialog.Show)
+
+	var mu sync.Mutex
+	deleted := 0
+	failedDeletions := ov.deleteKeysInBatches(deleteProgressDialog.ctx, bucket, plan, func(n int) {
+		mu.Lock()
+		deleted += n
+		progress := float64(deleted) / float64(len(plan))
+		mu.Unlock()
+		fyne.Do(func() { deleteProgressDialog.SetValue(progress) })
+	})
+
+	fyne.Do(func() {
+		cancelled := deleteProgressDialog.done()
+		if cancelled {
+			ShowToast(ov.window, fmt.Sprintf(T("已取消删除，已删除 %d / %d 个对象。"), deleted, len(plan)))
+		}
+		if len(failedDeletions) > 0 {
+			dialog.ShowError(fmt.Errorf(T("部分项目删除失败: %s"), strings.Join(failedDeletions, ", ")), ov.window)
+		} else if !cancelled {
+			ShowToast(ov.window, fmt.Sprintf(T("%d 个项目已成功删除。"), len(selected)))
+		}
+		ov.resetPagingAndSelection()
+		ov.loadObjects()
+	})
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+// fakeKeyLister 模拟 ListAllKeysUnderPrefix，从固定的键集合中按前缀筛选
+func fakeKeyLister(allKeys []string) func(prefix string) ([]string, error) {
+	return func(prefix string) ([]string, error) {
+		var keys []string
+		for _, key := range allKeys {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	}
+}
+
+func TestBuildDeletePlanMixedSelection(t *testing.T) {
+	allKeys := []string{
+		"a.txt",
+		"docs/", // 有占位对象的文件夹
+		"docs/readme.md",
+		"docs/img/", // 嵌套文件夹的占位对象
+		"docs/img/logo.png",
+		"photos/2024/beach.jpg", // 没有占位对象的文件夹
+		"photos/2024/sea.jpg",
+		"other.txt",
+	}
+	selected := []s3client.S3Object{
+		{Name: "a.txt", Key: "a.txt"},
+		{Name: "docs", Key: "docs/", IsFolder: true},
+		{Name: "photos", Key: "photos", IsFolder: true}, // 缺少结尾斜杠
+		{Name: "readme.md", Key: "docs/readme.md"},      // 已包含在 docs/ 中
+		{Name: "img", Key: "docs/img/", IsFolder: true}, // 嵌套文件夹，与 docs/ 重叠
+	}
+
+	plan, err := buildDeletePlan(selected, fakeKeyLister(allKeys))
+	if err != nil {
+		t.Fatalf("buildDeletePlan returned error: %v", err)
+	}
+
+	expected := []string{
+		"a.txt",
+		"docs/",
+		"docs/img/",
+		"docs/img/logo.png",
+		"docs/readme.md",
+		"photos/",
+		"photos/2024/beach.jpg",
+		"photos/2024/sea.jpg",
+	}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("buildDeletePlan = %v; expected %v", plan, expected)
+	}
+}
+
+func TestBuildDeletePlanListError(t *testing.T) {
+	selected := []s3client.S3Object{{Name: "docs", Key: "docs/", IsFolder: true}}
+	_, err := buildDeletePlan(selected, func(prefix string) ([]string, error) {
+		return nil, errors.New("access denied")
+	})
+	if err == nil {
+		t.Errorf("buildDeletePlan should return error when listing fails")
+	}
+}
+
+func TestDeleteKeysInBatches(t *testing.T) {
+	store := newMemStore()
+	var keys []string
+	for i := 0; i < 2500; i++ {
+		key := fmt.Sprintf("logs/%04d.txt", i)
+		store.put(testBucket, key, "x")
+		keys = append(keys, key)
+	}
+	store.put(testBucket, "keep.txt", "k")
+	store.deleteErrors["logs/0007.txt"] = errors.New("拒绝访问")
+	ov := newTestObjectsView(store, "")
+
+	var mu sync.Mutex
+	deleted := 0
+	failed := ov.deleteKeysInBatches(context.Background(), testBucket, keys, func(n int) {
+		mu.Lock()
+		deleted += n
+		mu.Unlock()
+	})
+
+	if !reflect.DeepEqual(failed, []string{"logs/0007.txt"}) {
+		t.Errorf("删除失败的键 = %v, 期望只有 logs/0007.txt", failed)
+	}
+	if deleted != 2499 {
+		t.Errorf("进度累计删除 %d 个对象, 期望 2499", deleted)
+	}
+	sort.Ints(store.deleteBatches)
+	if want := []int{500, 1000, 1000}; !reflect.DeepEqual(store.deleteBatches, want) {
+		t.Errorf("批量删除请求大小 = %v, 期望 %v", store.deleteBatches, want)
+	}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, []string{"keep.txt", "logs/0007.txt"}) {
+		t.Errorf("删除后的对象 = %v", got)
+	}
+}
+
+func TestDeleteKeysInBatchesCancelled(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "a.txt", "a")
+	ov := newTestObjectsView(store, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if failed := ov.deleteKeysInBatches(ctx, testBucket, []string{"a.txt"}, nil); len(failed) > 0 {
+		t.Errorf("取消的批次不应计为失败，实际失败 %v", failed)
+	}
+	if len(store.deleteBatches) > 0 {
+		t.Errorf("取消后不应发送批量删除请求，实际发送了 %v", store.deleteBatches)
+	}
+}
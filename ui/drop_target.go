@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"s3-explorer/s3client"
+)
+
+// folderAtPosition 返回拖放位置（窗口坐标）下的文件夹条目，不在任何文件夹上时返回 false
+func (ov *ObjectsView) folderAtPosition(pos fyne.Position) (s3client.S3Object, bool) {
+	folder, _, ok := ov.folderEntryAtPosition(pos)
+	return folder, ok
+}
+
+// folderEntryAtPosition 与 folderAtPosition 相同，同时返回显示该文件夹的条目组件
+func (ov *ObjectsView) folderEntryAtPosition(pos fyne.Position) (s3client.S3Object, fyne.CanvasObject, bool) {
+	if ov.mainContent == nil || !positionInside(pos, ov.mainContent) {
+		return s3client.S3Object{}, nil, false
+	}
+
+	items := ov.getDisplayedObjects()
+	for _, target := range ov.renderedEntries() {
+		obj, id := target.object, target.id
+		if id < 0 || id >= len(items) || !items[id].IsFolder {
+			continue
+		}
+		// 列表会复用条目，已移出界面的条目不在画布中，positionInside 会返回 false
+		if obj.Visible() && positionInside(pos, obj) {
+			return items[id], obj, true
+		}
+	}
+	return s3client.S3Object{}, nil, false
+}
+
+// dropTarget 是当前视图中一个可命中测试的条目
+type dropTarget struct {
+	object fyne.CanvasObject
+	id     int
+}
+
+// renderedEntries 返回当前视图中已渲染的条目
+func (ov *ObjectsView) renderedEntries() []dropTarget {
+	var targets []dropTarget
+	if ov.viewMode == gridViewMode {
+		if len(ov.mainContent.Objects) == 0 {
+			return nil
+		}
+		if scroll, ok := ov.mainContent.Objects[0].(*container.Scroll); ok {
+			if grid, ok := scroll.Content.(*fyne.Container); ok {
+				for _, obj := range grid.Objects {
+					if entry, ok := obj.(*gridEntry); ok {
+						targets = append(targets, dropTarget{object: entry, id: entry.id})
+					}
+				}
+			}
+		}
+		return targets
+	}
+	for _, entry := range ov.listEntries {
+		targets = append(targets, dropTarget{object: entry, id: entry.id})
+	}
+	return targets
+}
+
+// positionInside 判断窗口坐标 pos 是否位于对象的绝对范围内
+func positionInside(pos fyne.Position, obj fyne.CanvasObject) bool {
+	driver := fyne.CurrentApp().Driver()
+	if driver.CanvasForObject(obj) == nil {
+		return false
+	}
+	topLeft := driver.AbsolutePositionForObject(obj)
+	size := obj.Size()
+	return pos.X >= topLeft.X && pos.X < topLeft.X+size.Width &&
+		pos.Y >= topLeft.Y && pos.Y < topLeft.Y+size.Height
+}
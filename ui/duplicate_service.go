@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2/dialog"
+	"s3-explorer/config"
+)
+
+// duplicateAlias 返回复制服务时使用的别名 "X (副本)"，已被占用时依次尝试 "X (副本 2)"、"X (副本 3)"……
+func duplicateAlias(alias string, services []config.S3ServiceConfig) string {
+	taken := make(map[string]struct{}, len(services))
+	for _, svc := range services {
+		taken[svc.Alias] = struct{}{}
+	}
+	candidate := fmt.Sprintf(T("%s (副本)"), alias)
+	for i := 2; ; i++ {
+		if _, exists := taken[candidate]; !exists {
+			return candidate
+		}
+		candidate = fmt.Sprintf(T("%s (副本 %d)"), alias, i)
+	}
+}
+
+// duplicateSelectedService 复制选中的服务（包括 Endpoint、密钥和各项设置），保存后选中新服务，
+// 方便在同一 Endpoint 上配置多个服务时直接修改别名等少量字段。
+func (sv *ServicesView) duplicateSelectedService() {
+	if sv.selectedServiceID == -1 || sv.selectedServiceID >= len(sv.configStore.Services) {
+		dialog.ShowInformation(T("提示"), T("请先选择一个要复制的服务。"), sv.window)
+		return
+	}
+	newService := sv.configStore.Services[sv.selectedServiceID]
+	newService.Alias = duplicateAlias(newService.Alias, sv.configStore.Services)
+	if err := sv.configStore.AddService(newService); err != nil {
+		dialog.ShowError(fmt.Errorf(T("复制服务失败: %v"), err), sv.window)
+		return
+	}
+	sv.loadConfig(func() {
+		// 重新加载后列表顺序可能变化，清除旧的选择后按别名选中新服务
+		sv.selectedServiceID = -1
+		sv.SelectService(newService.Alias)
+	})
+}
@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"testing"
+
+	"s3-explorer/config"
+)
+
+func TestDuplicateAlias(t *testing.T) {
+	services := []config.S3ServiceConfig{{Alias: "minio"}, {Alias: "aws"}}
+	if got := duplicateAlias("minio", services); got != "minio (副本)" {
+		t.Errorf("duplicateAlias = %q, 期望 %q", got, "minio (副本)")
+	}
+
+	services = append(services, config.S3ServiceConfig{Alias: "minio (副本)"}, config.S3ServiceConfig{Alias: "minio (副本 2)"})
+	if got := duplicateAlias("minio", services); got != "minio (副本 3)" {
+		t.Errorf("副本别名已被占用时 duplicateAlias = %q, 期望 %q", got, "minio (副本 3)")
+	}
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"s3-explorer/s3client"
+)
+
+// duplicateGroup 表示一组大小和 ETag 都相同的对象
+type duplicateGroup struct {
+	Size    int64
+	ETag    string
+	Objects []s3client.S3Object
+}
+
+// findDuplicateGroups 按 大小+ETag 对对象分组，返回包含两个及以上对象的分组。
+// 0 字节对象不参与比较，结果按可回收空间从大到小排序。
+func findDuplicateGroups(objects []s3client.S3Object) []duplicateGroup {
+	groups := make(map[string]*duplicateGroup)
+	var order []string
+	for _, obj := range objects {
+		if obj.IsFolder || obj.Size == 0 || obj.ETag == "" {
+			continue
+		}
+		groupKey := fmt.Sprintf("%d|%s", obj.Size, obj.ETag)
+		group, ok := groups[groupKey]
+		if !ok {
+			group = &duplicateGroup{Size: obj.Size, ETag: obj.ETag}
+			groups[groupKey] = group
+			order = append(order, groupKey)
+		}
+		group.Objects = append(group.Objects, obj)
+	}
+
+	var result []duplicateGroup
+	for _, groupKey := range order {
+		if group := groups[groupKey]; len(group.Objects) > 1 {
+			sort.Slice(group.Objects, func(i, j int) bool { return group.Objects[i].Key < group.Objects[j].Key })
+			result = append(result, *group)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Size*int64(len(result[i].Objects)-1) > result[j].Size*int64(len(result[j].Objects)-1)
+	})
+	return result
+}
+
+// fullySelectedGroups 返回所有副本都被选中的分组。删除这些分组中选中的对象会删除数据的最后一份副本。
+func fullySelectedGroups(groups []duplicateGroup, selected map[string]bool) []duplicateGroup {
+	var result []duplicateGroup
+	for _, group := range groups {
+		all := true
+		for _, obj := range group.Objects {
+			if !selected[obj.Key] {
+				all = false
+				break
+			}
+		}
+		if all {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// copyETag 将对象的 ETag 复制到剪贴板
+func (ov *ObjectsView) copyETag(item s3client.S3Object) {
+	if item.ETag == "" {
+		ShowToast(ov.window, T("该对象没有 ETag 信息。"))
+		return
+	}
+	ov.window.Clipboard().SetContent(item.ETag)
+	if s3client.IsMultipartETag(item.ETag) {
+		ShowToast(ov.window, fmt.Sprintf(T("已复制 ETag: %s（分段上传对象，ETag 不是文件的 MD5）"), item.ETag))
+	} else {
+		ShowToast(ov.window, fmt.Sprintf(T("已复制 ETag (MD5): %s"), item.ETag))
+	}
+}
+
+// startDuplicateScan 在后台逐页扫描指定前缀下的所有对象并查找重复文件，显示已扫描的对象数量。
+// 点击取消后停止列举请求。
+func (ov *ObjectsView) startDuplicateScan(prefix string) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		ShowToast(ov.window, T("请先选择一个 S3 服务和存储桶。"))
+		return
+	}
+
+	client := ov.s3Client
+	bucket := ov.currentBucket
+	ctx, cancel := context.WithCancel(context.Background())
+
+	countLabel := widget.NewLabel(fmt.Sprintf(T("已扫描 %d 个对象"), 0))
+	content := container.NewVBox(widget.NewLabel(fmt.Sprintf(T("正在扫描 %s/%s ..."), bucket, prefix)), countLabel)
+	scanDialog := dialog.NewCustom(T("查找重复文件"), T("取消"), content, ov.window)
+	scanDialog.SetOnClosed(cancel)
+	scanDialog.Show()
+
+	go func() {
+		defer cancel()
+		var objects []s3client.S3Object
+		err := client.WalkObjects(ctx, bucket, prefix, func(obj s3client.S3Object) error {
+			objects = append(objects, obj)
+			// 每页最多 1000 个对象，按页更新已扫描的数量
+			if len(objects)%1000 == 0 {
+				n := len(objects)
+				fyne.Do(func() {
+					countLabel.SetText(fmt.Sprintf(T("已扫描 %d 个对象"), n))
+				})
+			}
+			return nil
+		})
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		groups := findDuplicateGroups(objects)
+		fyne.Do(func() {
+			scanDialog.SetOnClosed(nil)
+			scanDialog.Hide()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(T("扫描失败: %v"), err), ov.window)
+				return
+			}
+			if len(groups) == 0 {
+				dialog.ShowInformation(T("查找重复文件"), fmt.Sprintf(T("共扫描 %d 个对象，未发现重复文件。"), len(objects)), ov.window)
+				return
+			}
+			ov.showDuplicateResults(bucket, len(objects), groups)
+		})
+	}()
+}
+
+// showDuplicateResults 显示重复文件分组，允许用户选择并删除
+func (ov *ObjectsView) showDuplicateResults(bucket string, scannedCount int, groups []duplicateGroup) {
+	w := fyne.CurrentApp().NewWindow(T("重复文件"))
+	selected := make(map[string]bool)
+
+	var reclaimable int64
+	rows := container.NewVBox()
+	for _, group := range groups {
+		reclaimable += group.Size * int64(len(group.Objects)-1)
+		header := fmt.Sprintf(T("%s · ETag %s · %d 个"), formatBytes(group.Size), group.ETag, len(group.Objects))
+		if s3client.IsMultipartETag(group.ETag) {
+			header += T("（分段上传 ETag，非 MD5，仅当分段大小相同时可作为判断依据）")
+		}
+		rows.Add(widget.NewLabelWithStyle(header, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for i, obj := range group.Objects {
+			key := obj.Key
+			check := widget.NewCheck(fmt.Sprintf("%s  (%s)", key, obj.LastModified), func(checked bool) {
+				selected[key] = checked
+			})
+			// 默认保留每组的第一个对象，选中其余副本
+			check.SetChecked(i > 0)
+			rows.Add(check)
+		}
+		rows.Add(widget.NewSeparator())
+	}
+
+	summary := widget.NewLabel(fmt.Sprintf(T("共扫描 %d 个对象，发现 %d 组重复文件，可释放约 %s。"), scannedCount, len(groups), formatBytes(reclaimable)))
+	deleteButton := widget.NewButtonWithIcon(T("删除所选"), theme.DeleteIcon(), func() {
+		var keys []string
+		for key, checked := range selected {
+			if checked {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			ShowToast(w, T("请先选择要删除的文件。"))
+			return
+		}
+		// 每组至少保留一个副本，否则删除后数据将不复存在
+		if full := fullySelectedGroups(groups, selected); len(full) > 0 {
+			dialog.ShowError(fmt.Errorf(T("%d 组重复文件的所有副本都被选中，每组至少需要保留一个文件，例如: %s"), len(full), full[0].Objects[0].Key), w)
+			return
+		}
+		sort.Strings(keys)
+		confirmIfEnabled(confirmDeleteSetting(), T("确认删除"), fmt.Sprintf(T("确定要删除选中的 %d 个重复文件吗？"), len(keys)), w, func() {
+			ov.runOperation(T("删除"), func() { ov.deleteDuplicateKeys(bucket, keys, w) })
+		})
+	})
+	deleteButton.Importance = widget.DangerImportance
+
+	w.SetContent(container.NewBorder(summary, container.NewHBox(deleteButton), nil, nil, container.NewScroll(rows)))
+	w.Resize(fyne.NewSize(800, 600))
+	w.Show()
+}
+
+// deleteDuplicateKeys 使用批量删除删除选中的重复文件并报告结果。全部删除成功时关闭结果窗口，提示显示在主窗口中。
+func (ov *ObjectsView) deleteDuplicateKeys(bucket string, keys []string, resultWindow fyne.Window) {
+	var progressDialog *dialog.ProgressDialog
+	fyne.DoAndWait(func() {
+		progressDialog = dialog.NewProgress(T("正在删除"), T("正在删除重复文件..."), resultWindow)
+		progressDialog.Show()
+	})
+
+	var deleted atomic.Int64
+	failed := ov.deleteKeysInBatches(context.Background(), bucket, keys, func(n int) {
+		progress := float64(deleted.Add(int64(n))) / float64(len(keys))
+		fyne.Do(func() { progressDialog.SetValue(progress) })
+	})
+
+	fyne.Do(func() {
+		progressDialog.Hide()
+		if len(failed) > 0 {
+			dialog.ShowError(fmt.Errorf(T("部分文件删除失败: %s"), strings.Join(failed, ", ")), resultWindow)
+		} else {
+			resultWindow.Close()
+			ShowToast(ov.window, fmt.Sprintf(T("已删除 %d 个重复文件。"), len(keys)))
+		}
+		ov.loadObjects()
+	})
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestFullySelectedGroups(t *testing.T) {
+	groups := []duplicateGroup{
+		{Size: 10, ETag: "a", Objects: []s3client.S3Object{{Key: "a1"}, {Key: "a2"}}},
+		{Size: 20, ETag: "b", Objects: []s3client.S3Object{{Key: "b1"}, {Key: "b2"}, {Key: "b3"}}},
+	}
+	selected := map[string]bool{"a2": true, "b1": true, "b2": true, "b3": true}
+	full := fullySelectedGroups(groups, selected)
+	if len(full) != 1 || full[0].ETag != "b" {
+		t.Errorf("fullySelectedGroups() = %+v, 期望只包含 ETag 为 b 的分组", full)
+	}
+
+	selected["b2"] = false
+	if full := fullySelectedGroups(groups, selected); len(full) != 0 {
+		t.Errorf("每组都保留了副本时不应返回分组，实际 %+v", full)
+	}
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newEmptyState 创建一个居中的空状态占位内容，包含图标、提示文字以及可选的操作按钮
+func newEmptyState(icon fyne.Resource, message string, action *widget.Button) fyne.CanvasObject {
+	iconImage := canvas.NewImageFromResource(icon)
+	iconImage.FillMode = canvas.ImageFillContain
+	iconImage.SetMinSize(fyne.NewSize(48, 48))
+
+	label := widget.NewLabelWithStyle(message, fyne.TextAlignCenter, fyne.TextStyle{})
+	label.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(container.NewCenter(iconImage), label)
+	if action != nil {
+		content.Add(container.NewCenter(action))
+	}
+	return container.NewCenter(content)
+}
@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"s3-explorer/s3client"
+)
+
+// memStore 是 ObjectStore 的内存实现，模拟 S3 的键空间语义（文件夹只是键的前缀），用于测试对象视图的逻辑
+type memStore struct {
+	mu      sync.Mutex
+	buckets map[string]map[string][]byte
+	tags    map[string]map[string]string // 对象键 -> 标签
+
+	foldersFirst bool
+
+	// deleteErrors 中的键在删除时返回对应的错误，用于模拟部分失败
+	deleteErrors map[string]error
+	// uploadOptions 记录每个对象最近一次上传时的选项
+	uploadOptions map[string]s3client.UploadOptions
+	// deleteBatches 记录每次 DeleteObjects 请求的对象数量
+	deleteBatches []int
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		buckets:       make(map[string]map[string][]byte),
+		tags:          make(map[string]map[string]string),
+		deleteErrors:  make(map[string]error),
+		uploadOptions: make(map[string]s3client.UploadOptions),
+	}
+}
+
+// put 写入对象，测试准备数据时使用
+func (m *memStore) put(bucket, key, content string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.buckets[bucket] == nil {
+		m.buckets[bucket] = make(map[string][]byte)
+	}
+	m.buckets[bucket][key] = []byte(content)
+}
+
+// keys 返回存储桶中所有的对象键（已排序）
+func (m *memStore) keys(bucket string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sortedKeys(bucket, "")
+}
+
+// sortedKeys 返回前缀下的所有对象键，调用方必须持有锁
+func (m *memStore) sortedKeys(bucket, prefix string) []string {
+	var keys []string
+	for key := range m.buckets[bucket] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *memStore) object(bucket, key string) s3client.S3Object {
+	return s3client.S3Object{Key: key, Size: int64(len(m.buckets[bucket][key])), LastModified: "2024-01-01 00:00:00"}
+}
+
+func (m *memStore) ListObjects(bucketName, prefix, marker string, pageSize int32) ([]s3client.S3Object, *string, error) {
+	objects, err := m.ListAllObjectsUnderPrefix(bucketName, prefix)
+	return objects, nil, err
+}
+
+func (m *memStore) ListAllObjectsUnderPrefix(bucketName, prefix string) ([]s3client.S3Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var folders, files []s3client.S3Object
+	seenFolders := make(map[string]bool)
+	for _, key := range m.sortedKeys(bucketName, prefix) {
+		rest := strings.TrimPrefix(key, prefix)
+		if i := strings.Index(rest, "/"); i >= 0 {
+			if name := rest[:i]; !seenFolders[name] {
+				seenFolders[name] = true
+				folders = append(folders, s3client.S3Object{Name: name, Key: prefix + name + "/", IsFolder: true})
+			}
+			continue
+		}
+		if rest == "" {
+			continue // 当前文件夹自身的占位对象
+		}
+		obj := m.object(bucketName, key)
+		obj.Name = rest
+		files = append(files, obj)
+	}
+	objects := append(folders, files...)
+	s3client.SortObjects(objects, m.foldersFirst)
+	return objects, nil
+}
+
+func (m *memStore) ListAllObjectsFlat(bucketName, prefix string) ([]s3client.S3Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var objects []s3client.S3Object
+	for _, key := range m.sortedKeys(bucketName, prefix) {
+		if strings.HasSuffix(key, "/") && len(m.buckets[bucketName][key]) == 0 {
+			continue
+		}
+		obj := m.object(bucketName, key)
+		obj.Name = strings.TrimPrefix(key, prefix)
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func (m *memStore) SearchObjects(ctx context.Context, bucketName, prefix, substring string) ([]s3client.S3Object, bool, error) {
+	objects, _ := m.ListAllObjectsFlat(bucketName, prefix)
+	var results []s3client.S3Object
+	for _, obj := range objects {
+		if strings.Contains(strings.ToLower(path.Base(obj.Key)), strings.ToLower(substring)) {
+			if len(results) == s3client.MaxSearchResults {
+				return results, true, nil
+			}
+			results = append(results, obj)
+		}
+	}
+	return results, false, ctx.Err()
+}
+
+func (m *memStore) ListAllKeysUnderPrefix(bucketName, prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sortedKeys(bucketName, prefix), nil
+}
+
+func (m *memStore) WalkObjects(ctx context.Context, bucketName, prefix string, fn func(obj s3client.S3Object) error) error {
+	objects, _ := m.ListAllObjectsFlat(bucketName, prefix)
+	for _, obj := range objects {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memStore) CountObjectsUnderPrefix(bucketName, prefix string) (int, error) {
+	keys, _ := m.ListAllKeysUnderPrefix(bucketName, prefix)
+	count := 0
+	for _, key := range keys {
+		if key != prefix {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *memStore) UploadObject(ctx context.Context, bucketName, key string, reader io.Reader, size int64, opts s3client.UploadOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	m.put(bucketName, key, string(data))
+	m.mu.Lock()
+	m.uploadOptions[key] = opts
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *memStore) UploadLargeObject(ctx context.Context, bucketName, key string, reader io.Reader, size int64, opts s3client.UploadOptions) error {
+	return m.UploadObject(ctx, bucketName, key, reader, size, opts)
+}
+
+func (m *memStore) DownloadObject(ctx context.Context, bucketName, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.buckets[bucketName][key]
+	if !ok {
+		return nil, fmt.Errorf("对象 '%s' 不存在", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memStore) DownloadObjectRange(ctx context.Context, bucketName, key string, start, end int64) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.buckets[bucketName][key]
+	if !ok {
+		return nil, fmt.Errorf("对象 '%s' 不存在", key)
+	}
+	if end < 0 || end >= int64(len(data)) {
+		end = int64(len(data)) - 1 // end 小于 0 表示读取到对象末尾
+	}
+	return io.NopCloser(bytes.NewReader(data[start : end+1])), nil
+}
+
+func (m *memStore) StatObject(bucketName, key string) (*s3client.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.buckets[bucketName][key]
+	if !ok {
+		return nil, fmt.Errorf("对象 '%s' 不存在", key)
+	}
+	sum := md5.Sum(data)
+	return &s3client.ObjectInfo{Size: int64(len(data)), ETag: hex.EncodeToString(sum[:]), LastModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func (m *memStore) ObjectExists(bucketName, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.buckets[bucketName][key]
+	return ok, nil
+}
+
+func (m *memStore) CopyObject(bucketName, sourceKey, targetKey string) error {
+	m.mu.Lock()
+	data, ok := m.buckets[bucketName][sourceKey]
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("源对象 '%s' 不存在", sourceKey)
+	}
+	m.put(bucketName, targetKey, string(data))
+	return nil
+}
+
+func (m *memStore) DeleteObject(ctx context.Context, bucketName, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.deleteErrors[key]; err != nil {
+		return err
+	}
+	// 与 S3 一致，删除不存在的对象不会报错
+	delete(m.buckets[bucketName], key)
+	return nil
+}
+
+func (m *memStore) DeleteObjects(ctx context.Context, bucketName string, keys []string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	m.deleteBatches = append(m.deleteBatches, len(keys))
+	m.mu.Unlock()
+	var failed []string
+	for _, key := range keys {
+		if err := m.DeleteObject(ctx, bucketName, key); err != nil {
+			failed = append(failed, key)
+		}
+	}
+	return failed, nil
+}
+
+func (m *memStore) CreateFolder(bucketName, key string) error {
+	if !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+	m.put(bucketName, key, "")
+	return nil
+}
+
+func (m *memStore) GetObjectTags(bucketName, key string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.buckets[bucketName][key]; !ok {
+		return nil, fmt.Errorf("对象 '%s' 不存在", key)
+	}
+	tags := make(map[string]string)
+	for k, v := range m.tags[key] {
+		tags[k] = v
+	}
+	return tags, nil
+}
+
+func (m *memStore) PutObjectTags(bucketName, key string, tags map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.buckets[bucketName][key]; !ok {
+		return fmt.Errorf("对象 '%s' 不存在", key)
+	}
+	m.tags[key] = tags
+	return nil
+}
+
+func (m *memStore) SetFoldersFirst(foldersFirst bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.foldersFirst = foldersFirst
+}
+
+func (m *memStore) PublicObjectURL(bucketName, key string) string {
+	return "https://example.com/" + bucketName + "/" + key
+}
+
+func (m *memStore) PresignGetObject(bucketName, key string, expiry time.Duration) (string, error) {
+	return fmt.Sprintf("https://example.com/%s/%s?X-Amz-Expires=%d", bucketName, key, int(expiry.Seconds())), nil
+}
+
+func (m *memStore) ConsoleURL(bucketName, prefix string) (string, error) {
+	return "", errors.New("内存存储没有控制台")
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// folderCountSem 限制同时进行的文件夹计数请求数量，避免在大目录中一次发出过多列举请求
+var folderCountSem = make(chan struct{}, 4)
+
+// SetShowFolderCounts 设置是否在文件夹旁显示其中的对象数量，设置会被保存
+func (ov *ObjectsView) SetShowFolderCounts(show bool) {
+	fyne.CurrentApp().Preferences().SetBool(prefShowFolderCounts, show)
+	ov.showFolderCounts = show
+	ov.resetFolderCounts()
+	ov.refreshFolderCountLabels()
+}
+
+// ShowFolderCounts 返回是否显示文件夹对象数量
+func (ov *ObjectsView) ShowFolderCounts() bool {
+	return ov.showFolderCounts
+}
+
+// resetFolderCounts 清空文件夹计数缓存，正在进行的计数结果会被丢弃。在列表重新加载时调用。
+func (ov *ObjectsView) resetFolderCounts() {
+	ov.folderCountGen++
+	ov.folderCounts = make(map[string]int)
+	ov.folderCountPending = make(map[string]bool)
+}
+
+// folderCountText 返回文件夹的对象数量文字。数量未知时在后台开始统计并返回空字符串，
+// 统计完成后刷新显示。只在条目显示时调用，因此只统计可见的文件夹。
+func (ov *ObjectsView) folderCountText(folderKey string) string {
+	if !ov.showFolderCounts {
+		return ""
+	}
+	if count, ok := ov.folderCounts[folderKey]; ok {
+		if count < 0 {
+			return "" // 统计失败
+		}
+		return fmt.Sprintf(T("%d 个对象"), count)
+	}
+	ov.requestFolderCount(folderKey)
+	return ""
+}
+
+// requestFolderCount 在后台统计文件夹下的对象数量
+func (ov *ObjectsView) requestFolderCount(folderKey string) {
+	if ov.folderCountPending[folderKey] || ov.s3Client == nil {
+		return
+	}
+	ov.folderCountPending[folderKey] = true
+
+	gen := ov.folderCountGen
+	client, bucket := ov.s3Client, ov.currentBucket
+	go func() {
+		folderCountSem <- struct{}{}
+		count, err := client.CountObjectsUnderPrefix(bucket, folderKey)
+		<-folderCountSem
+
+		fyne.Do(func() {
+			if gen != ov.folderCountGen {
+				return // 列表已重新加载，丢弃过期的结果
+			}
+			delete(ov.folderCountPending, folderKey)
+			if err != nil {
+				log.Printf("统计文件夹 '%s' 的对象数量失败: %v", folderKey, err)
+				Notify(T("部分文件夹的对象数量统计失败"), fmt.Sprintf("%s: %v", folderKey, err))
+				count = -1
+			}
+			ov.folderCounts[folderKey] = count
+			ov.refreshFolderCountLabels()
+		})
+	}()
+}
+
+// gridFolderLabel 返回网格视图中文件夹的显示名称，已知对象数量时附加在名称后
+func (ov *ObjectsView) gridFolderLabel(name, folderKey string) string {
+	label := formatFileNameForDisplay(name, 20)
+	if countText := ov.folderCountText(folderKey); countText != "" {
+		label += " (" + countText + ")"
+	}
+	return label
+}
+
+// refreshFolderCountLabels 刷新已显示条目中的文件夹对象数量
+func (ov *ObjectsView) refreshFolderCountLabels() {
+	if ov.viewMode != gridViewMode {
+		if ov.objectList != nil {
+			ov.objectList.Refresh()
+		}
+		return
+	}
+	if ov.mainContent == nil || len(ov.mainContent.Objects) == 0 {
+		return
+	}
+	scroll, ok := ov.mainContent.Objects[0].(*container.Scroll)
+	if !ok {
+		return
+	}
+	grid, ok := scroll.Content.(*fyne.Container)
+	if !ok {
+		return
+	}
+	items := ov.getDisplayedObjects()
+	for _, obj := range grid.Objects {
+		if entry, ok := obj.(*gridEntry); ok && entry.id < len(items) && items[entry.id].IsFolder {
+			entry.nameLabel.SetText(ov.gridFolderLabel(items[entry.id].Name, items[entry.id].Key))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// gridTooltipDelay 是鼠标在网格条目上停留多久后显示提示
+const gridTooltipDelay = 500 * time.Millisecond
+
+// gridTooltipOffset 是提示相对鼠标位置的偏移，避免遮挡光标
+var gridTooltipOffset = fyne.NewPos(12, 16)
+
+// gridTooltipText 返回网格视图中条目的提示文字：完整名称，文件另外显示大小和修改时间，文件夹显示对象数量
+func gridTooltipText(item s3client.S3Object, folderCount string) string {
+	lines := []string{item.Name}
+	if item.IsFolder {
+		info := T("文件夹")
+		if folderCount != "" {
+			info += " · " + folderCount
+		}
+		lines = append(lines, info)
+	} else {
+		lines = append(lines, fmt.Sprintf("%s: %s", T("大小"), formatBytes(item.Size)))
+		if item.LastModified != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", T("修改时间"), item.LastModified))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tooltipPosition 返回提示的位置：显示在鼠标右下方，超出窗口时向左、向上移动，使提示完整显示在窗口内
+func tooltipPosition(mouse fyne.Position, size, canvasSize fyne.Size) fyne.Position {
+	pos := mouse.Add(gridTooltipOffset)
+	if pos.X+size.Width > canvasSize.Width {
+		pos.X = max(canvasSize.Width-size.Width, 0)
+	}
+	if pos.Y+size.Height > canvasSize.Height {
+		pos.Y = max(mouse.Y-size.Height-4, 0)
+	}
+	return pos
+}
+
+// MouseIn 鼠标进入网格条目后，停留 gridTooltipDelay 再显示提示
+func (e *gridEntry) MouseIn(ev *desktop.MouseEvent) {
+	e.hovered = true
+	e.hoverPos = ev.AbsolutePosition
+	e.restartTooltipTimer()
+}
+
+// MouseMoved 鼠标移动时重新计时，提示在鼠标停下后显示在鼠标附近
+func (e *gridEntry) MouseMoved(ev *desktop.MouseEvent) {
+	e.hoverPos = ev.AbsolutePosition
+	if e.tooltip == nil || !e.tooltip.Visible() {
+		e.restartTooltipTimer()
+	}
+}
+
+// restartTooltipTimer 重新开始显示提示的计时
+func (e *gridEntry) restartTooltipTimer() {
+	if e.tooltipTimer != nil {
+		e.tooltipTimer.Stop()
+	}
+	e.tooltipTimer = time.AfterFunc(gridTooltipDelay, func() {
+		fyne.Do(e.showTooltip)
+	})
+}
+
+// MouseOut 鼠标离开网格条目时取消尚未显示的提示并隐藏已显示的提示。
+// 提示是覆盖整个窗口的弹出层，显示后移动鼠标同样会触发 MouseOut，因此提示在鼠标移动后隐藏，停下后重新显示。
+func (e *gridEntry) MouseOut() {
+	e.hovered = false
+	e.hideTooltip()
+}
+
+// showTooltip 在鼠标附近显示条目的完整名称、大小和修改时间。计时结束前鼠标已离开时不显示。
+func (e *gridEntry) showTooltip() {
+	if !e.hovered || e.ov == nil || e.ov.window == nil {
+		return
+	}
+	items := e.ov.getDisplayedObjects()
+	if e.id < 0 || e.id >= len(items) {
+		return
+	}
+	item := items[e.id]
+	folderCount := ""
+	if item.IsFolder {
+		folderCount = e.ov.folderCountText(item.Key)
+	}
+
+	c := e.ov.window.Canvas()
+	if e.tooltip == nil {
+		e.tooltip = widget.NewPopUp(widget.NewLabel(""), c)
+	}
+	e.tooltip.Content.(*widget.Label).SetText(gridTooltipText(item, folderCount))
+	e.tooltip.Resize(e.tooltip.MinSize())
+	e.tooltip.ShowAtPosition(tooltipPosition(e.hoverPos, e.tooltip.MinSize(), c.Size()))
+}
+
+// hideTooltip 取消尚未显示的提示并隐藏已显示的提示，点击或双击条目时同样调用
+func (e *gridEntry) hideTooltip() {
+	if e.tooltipTimer != nil {
+		e.tooltipTimer.Stop()
+		e.tooltipTimer = nil
+	}
+	if e.tooltip != nil {
+		e.tooltip.Hide()
+	}
+}
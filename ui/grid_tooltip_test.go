@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"s3-explorer/s3client"
+)
+
+func TestGridTooltipText(t *testing.T) {
+	tests := []struct {
+		item        s3client.S3Object
+		folderCount string
+		want        string
+	}{
+		{
+			s3client.S3Object{Name: "a-very-long-report-name-2024.pdf", Size: 2048, LastModified: "2024-05-01 10:00:00"},
+			"",
+			"a-very-long-report-name-2024.pdf\n大小: " + formatBytes(2048) + "\n修改时间: 2024-05-01 10:00:00",
+		},
+		{s3client.S3Object{Name: "bare.txt"}, "", "bare.txt\n大小: " + formatBytes(0)},
+		{s3client.S3Object{Name: "photos", IsFolder: true}, "12 个对象", "photos\n文件夹 · 12 个对象"},
+		{s3client.S3Object{Name: "photos", IsFolder: true}, "", "photos\n文件夹"},
+	}
+	for _, tt := range tests {
+		if got := gridTooltipText(tt.item, tt.folderCount); got != tt.want {
+			t.Errorf("gridTooltipText(%q) = %q, 期望 %q", tt.item.Name, got, tt.want)
+		}
+	}
+}
+
+func TestTooltipPositionStaysInsideCanvas(t *testing.T) {
+	canvasSize := fyne.NewSize(800, 600)
+	size := fyne.NewSize(200, 80)
+	tests := []struct {
+		mouse fyne.Position
+		want  fyne.Position
+	}{
+		{fyne.NewPos(100, 100), fyne.NewPos(112, 116)},
+		{fyne.NewPos(700, 100), fyne.NewPos(600, 116)}, // 靠近右边缘时向左移动
+		{fyne.NewPos(100, 550), fyne.NewPos(112, 466)}, // 靠近下边缘时显示在鼠标上方
+	}
+	for _, tt := range tests {
+		if got := tooltipPosition(tt.mouse, size, canvasSize); got != tt.want {
+			t.Errorf("tooltipPosition(%v) = %v, 期望 %v", tt.mouse, got, tt.want)
+		}
+	}
+}
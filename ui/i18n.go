@@ -0,0 +1,540 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+)
+
+// 支持的界面语言
+const (
+	languageAuto = "auto" // 跟随系统
+	languageZH   = "zh"
+	languageEN   = "en"
+)
+
+// currentLanguage 是当前界面语言，在创建界面之前由 ApplySavedSettings 设置
+var currentLanguage = languageZH
+
+// translations 按语言保存界面文字，消息 ID 即中文原文。
+// 中文表为空，T 找不到翻译时直接返回消息 ID。
+var translations = map[string]map[string]string{
+	languageZH: {},
+	languageEN: {
+		"使用说明":      "Help",
+		"关闭":        "Close",
+		"版本: 1.0.0": "Version: 1.0.0",
+		"一个简单的 S3 兼容对象存储桌面浏览器。": "A simple desktop browser for S3-compatible object storage.",
+		"GitHub 仓库":       "GitHub repository",
+		"Gitee 仓库":        "Gitee repository",
+		"关于 S3 Explorer":  "About S3 Explorer",
+		"S3 资源管理器":        "S3 Explorer",
+		"设置":              "Settings",
+		"新服务默认设置":         "New service defaults",
+		"超时设置":            "Timeout settings",
+		"帮助":              "Help",
+		"关于":              "About",
+		"创建 S3 客户端失败: %v": "Failed to create S3 client: %v",
+		"此操作将永久删除存储桶 \"%s\" 中的所有对象（包括历史版本和删除标记），但保留存储桶本身。": "This will permanently delete all objects in bucket \"%s\" (including old versions and delete markers) but keep the bucket itself.",
+		"请输入存储桶名称以确认:": "Type the bucket name to confirm:",
+		"清空存储桶":        "Empty bucket",
+		"清空":           "Empty",
+		"取消":           "Cancel",
+		"提示":           "Notice",
+		"输入的名称与存储桶名称不一致，已取消操作。": "The name does not match the bucket name. Operation cancelled.",
+		"正在列出对象...":                    "Listing objects...",
+		"正在清空存储桶":                      "Emptying bucket",
+		"正在删除存储桶":                      "Deleting bucket",
+		"清空存储桶失败: %v":                  "Failed to empty bucket: %v",
+		"已处理 %d / %d 个对象版本":            "Processed %d / %d object versions",
+		"已取消清空，已处理 %d / %d 个对象版本。":     "Emptying cancelled, processed %d / %d object versions.",
+		"%d 个对象删除失败，例如: %s":            "%d objects could not be deleted, e.g. %s",
+		"存储桶 \"%s\" 已清空，共删除 %d 个对象版本。": "Bucket \"%s\" emptied, %d object versions deleted.",
+		"列出存储桶失败: %v":                  "Failed to list buckets: %v",
+		"存储桶名称":                        "Bucket name",
+		"创建":                           "Create",
+		"请先选择一个 S3 服务。":                "Please select an S3 service first.",
+		"请输入存储桶名称":                     "Enter a bucket name",
+		"存储桶名称:":                       "Bucket name:",
+		"创建存储桶":                        "Create bucket",
+		"存储桶名称不能为空。":                   "Bucket name cannot be empty.",
+		"创建存储桶失败: %v":                  "Failed to create bucket: %v",
+		"成功":                           "Success",
+		"存储桶 \"%s\" 创建成功！":             "Bucket \"%s\" created!",
+		"删除":                           "Delete",
+		"请先选择一个要删除的存储桶。":               "Please select a bucket to delete first.",
+		"确认删除":                         "Confirm delete",
+		"确定要删除存储桶 \"%s\" 吗？":           "Delete bucket \"%s\"?",
+		"删除存储桶失败: %v":                  "Failed to delete bucket: %v",
+		"存储桶 \"%s\" 删除成功！":             "Bucket \"%s\" deleted!",
+		"请先选择一个要清空的存储桶。":               "Please select a bucket to empty first.",
+		"正在%s，请等待完成后再操作。":              "%s is in progress, please wait until it finishes.",
+		"重新加载配置":                       "Reload configuration",
+		"服务 \"%s\" 的临时凭证已过期，请重新输入凭证或重新加载配置。": "The temporary credentials of service \"%s\" have expired. Enter new credentials or reload the configuration.",
+		"凭证已过期": "Credentials expired",
+		"保存并重试": "Save and retry",
+		"Access Key 和 Secret Key 不能为空！":       "Access Key and Secret Key cannot be empty!",
+		"更新服务失败: %v":                          "Failed to update service: %v",
+		"请先选择要删除的文件或文件夹。":                     "Please select files or folders to delete first.",
+		"确定要删除选中的 %d 个项目吗？":                   "Delete the %d selected items?",
+		"正在准备删除":                              "Preparing to delete",
+		"正在扫描待删除项目...":                        "Scanning items to delete...",
+		"扫描部分项目失败: %v":                        "Failed to scan some items: %v",
+		"没有可删除的项目。":                           "Nothing to delete.",
+		"正在删除":                                "Deleting",
+		"正在删除项目...":                           "Deleting items...",
+		"部分项目删除失败: %s":                        "Failed to delete some items: %s",
+		"%d 个项目已成功删除。":                        "%d items deleted.",
+		"该对象没有 ETag 信息。":                      "This object has no ETag.",
+		"已复制 ETag: %s（分段上传对象，ETag 不是文件的 MD5）": "Copied ETag: %s (multipart object, the ETag is not the file's MD5)",
+		"已复制 ETag (MD5): %s":                  "Copied ETag (MD5): %s",
+		"请先选择一个 S3 服务和存储桶。":                   "Please select an S3 service and bucket first.",
+		"正在扫描 %s/%s ...":                      "Scanning %s/%s ...",
+		"查找重复文件":                              "Find duplicates",
+		"扫描失败: %v":                            "Scan failed: %v",
+		"共扫描 %d 个对象，未发现重复文件。":                 "Scanned %d objects, no duplicates found.",
+		"重复文件":                                "Duplicate files",
+		"%s · ETag %s · %d 个":                 "%s · ETag %s · %d files",
+		"（分段上传 ETag，非 MD5，仅当分段大小相同时可作为判断依据）": " (multipart ETag, not an MD5; only comparable when part sizes match)",
+		"共扫描 %d 个对象，发现 %d 组重复文件，可释放约 %s。":    "Scanned %d objects, found %d groups of duplicates, about %s can be freed.",
+		"删除所选":                "Delete selected",
+		"请先选择要删除的文件。":         "Please select files to delete first.",
+		"确定要删除选中的 %d 个重复文件吗？": "Delete the %d selected duplicate files?",
+		"正在删除重复文件...":         "Deleting duplicate files...",
+		"部分文件删除失败: %s":        "Failed to delete some files: %s",
+		"已删除 %d 个重复文件。":       "Deleted %d duplicate files.",
+		"未选择服务":               "No service selected",
+		"当前服务: %s":            "Current service: %s",
+		"名称":                  "Name",
+		"大小/时间":               "Size/Time",
+		"文件名":                 "File name",
+		"列出对象失败: %v":          "Failed to list objects: %v",
+		"S3 资源管理器 ---> %s":    "S3 Explorer ---> %s",
+		"打开":                  "Open",
+		"在控制台中打开":             "Open in console",
+		"下载":                  "Download",
+		"复制公网地址":              "Copy public URL",
+		"在浏览器中打开":             "Open in browser",
+		"复制 ETag/MD5":         "Copy ETag/MD5",
+		"复制":                  "Copy",
+		"粘贴":                  "Paste",
+		"在控制台中打开当前位置":         "Open current location in console",
+		"已复制: %s":             "Copied: %s",
+		"已复制 %d 个项目":          "Copied %d items",
+		"上传":                  "Upload",
+		"确认粘贴":                "Confirm paste",
+		"是否要粘贴 %d 个已复制的对象到当前目录？": "Paste %d copied objects into the current folder?",
+		"剪贴板中没有可识别的文件路径。":        "No file paths found on the clipboard.",
+		"平铺视图":   "Flat view",
+		"文件夹优先":  "Folders first",
+		"无分页":    "No paging",
+		"第 %d 页": "Page %d",
+		"保存":     "Save",
+		"当前存储桶已切换，无法保存到 '%s'": "The current bucket has changed, cannot save to '%s'",
+		"文件 '%s' 已保存。":        "File '%s' saved.",
+		"编辑":                  "Edit",
+		"取消编辑":                "Cancel edit",
+		"正在准备预览":              "Preparing preview",
+		"正在下载文件...":           "Downloading file...",
+		"下载文件失败: %v":          "Failed to download file: %v",
+		"创建临时文件失败: %v":        "Failed to create temporary file: %v",
+		"写入临时文件失败: %v":        "Failed to write temporary file: %v",
+		"无法使用默认应用打开文件: %v":    "Cannot open file with the default application: %v",
+		"已复制公网地址（仅当对象或存储桶公开可读时可访问）": "Public URL copied (only accessible if the object or bucket is publicly readable)",
+		"生成公网地址失败: %v":  "Failed to generate public URL: %v",
+		"无法在浏览器中打开: %v": "Cannot open in browser: %v",
+		"已在浏览器中打开（仅当对象或存储桶公开可读时可访问）": "Opened in browser (only accessible if the object or bucket is publicly readable)",
+		"请先选择一个 S3 服务和存储桶才能上传。":      "Please select an S3 service and bucket before uploading.",
+		"添加服务": "Add service",
+		"还没有配置服务，点击左上角 + 添加":          "No services yet, click + at the top left to add one",
+		"请在左侧选择一个服务":                  "Select a service on the left",
+		"请选择一个存储桶":                    "Select a bucket",
+		"没有匹配的文件":                     "No matching files",
+		"此文件夹为空":                      "This folder is empty",
+		"文件夹":                         "Folder",
+		"搜索文件...":                     "Search files...",
+		"请输入文件夹名称":                    "Enter a folder name",
+		"文件夹名称:":                      "Folder name:",
+		"创建新文件夹":                      "Create folder",
+		"文件夹名称不能为空。":                  "Folder name cannot be empty.",
+		"创建文件夹失败: %v":                 "Failed to create folder: %v",
+		"文件夹 '%s' 创建成功！":              "Folder '%s' created!",
+		"上传文件":                        "Upload files",
+		"上传文件夹":                       "Upload folder",
+		"请选择上传类型":                     "Choose what to upload",
+		"请至少选择一个要下载的项目。":              "Please select at least one item to download.",
+		"无效的页面大小":                     "Invalid page size",
+		"每页显示:":                       "Per page:",
+		"请先选择一个存储桶。":                  "Please select a bucket first.",
+		"生成控制台地址失败: %v":               "Failed to generate console URL: %v",
+		"检查对象是否存在失败: %v":              "Failed to check whether the object exists: %v",
+		"确认覆盖":                        "Confirm overwrite",
+		"对象 '%s' 已存在，是否覆盖？":           "Object '%s' already exists. Overwrite it?",
+		"例如：notes.txt":                "e.g. notes.txt",
+		"文件内容":                        "File content",
+		"文件名:":                        "File name:",
+		"内容:":                         "Content:",
+		"新建文本文件":                      "New text file",
+		"文件名不能为空，且不能包含 '/'。":          "File name cannot be empty or contain '/'.",
+		"保存文件失败: %v":                  "Failed to save file: %v",
+		"正在准备上传":                      "Preparing upload",
+		"正在扫描文件...":                   "Scanning files...",
+		"扫描部分项目失败: %s":                "Failed to scan some items: %s",
+		"没有可上传的项目。":                   "Nothing to upload.",
+		"正在上传":                        "Uploading",
+		"正在上传项目...":                   "Uploading items...",
+		"所有项目上传完成。":                   "All items uploaded.",
+		"正在准备下载":                      "Preparing download",
+		"正在扫描待下载项目...":                "Scanning items to download...",
+		"没有可下载的项目。":                   "Nothing to download.",
+		"正在下载":                        "Downloading",
+		"正在下载项目...":                   "Downloading items...",
+		"所有项目下载完成。":                   "All items downloaded.",
+		"正在计算下载大小...":                 "Calculating download size...",
+		"未选择S3服务或存储桶":                 "No S3 service or bucket selected",
+		"正在复制":                        "Copying",
+		"正在复制对象...":                   "Copying objects...",
+		"部分对象复制失败 (%d/%d):\n%s":       "Failed to copy some objects (%d/%d):\n%s",
+		"成功复制 %d 个对象。":                "Copied %d objects.",
+		"预览":                          "Preview",
+		"上一个":                         "Previous",
+		"下一个":                         "Next",
+		"预览 - %s":                     "Preview - %s",
+		"加载预览失败":                      "Failed to load preview",
+		"无法解码图片":                      "Cannot decode image",
+		"使用默认应用打开":                    "Open with default application",
+		"此文件类型不支持应用内预览":               "This file type cannot be previewed in the app",
+		"加载配置失败: %v":                  "Failed to load configuration: %v",
+		"例如：我的Minio":                  "e.g. My MinIO",
+		"服务类型:":                       "Provider:",
+		"区域:":                         "Region:",
+		"仅在必需时计算校验和":                  "Only calculate checksums when required",
+		"阿里云 OSS":                     "Alibaba Cloud OSS",
+		"腾讯云 COS":                     "Tencent Cloud COS",
+		"自定义":                         "Custom",
+		"例如：http://localhost:9000":    "e.g. http://localhost:9000",
+		"例如：http://127.0.0.1:7890":    "e.g. http://127.0.0.1:7890",
+		"可选，例如：http://localhost:9001": "Optional, e.g. http://localhost:9001",
+		"别名:":                         "Alias:",
+		"控制台地址:":                      "Console URL:",
+		"除了代理和控制台地址，所有字段都不能为空！": "All fields except proxy and console URL are required!",
+		"添加 S3 服务":   "Add S3 service",
+		"添加":         "Add",
+		"添加服务失败: %v": "Failed to add service: %v",
+		"服务别名":       "Service alias",
+		"请先选择一个要编辑的服务。":                                       "Please select a service to edit first.",
+		"编辑 S3 服务":                                            "Edit S3 service",
+		"请先选择一个要删除的服务。":                                       "Please select a service to delete first.",
+		"确定要删除服务 \"%s\" 吗？":                                   "Delete service \"%s\"?",
+		"删除服务失败: %v":                                          "Failed to delete service: %v",
+		"还没有配置服务":                                             "No services configured yet",
+		"文件名追加时间戳":                                            "Append timestamp to file names",
+		"可用占位符: {name} {ext} {YYYY} {MM} {DD} {HH} {mm} {ss}": "Placeholders: {name} {ext} {YYYY} {MM} {DD} {HH} {mm} {ss}",
+		"列表":    "List",
+		"缩略图":   "Thumbnails",
+		"默认视图:": "Default view:",
+		"以上设置仅应用于新添加的服务，每页显示为 0 表示不分页。": "These settings only apply to newly added services. A page size of 0 disables paging.",
+		"请求超时(秒):": "Request timeout (s):",
+		"列举对象等操作使用该时间的 4 倍；上传和下载在超过该时间没有数据传输时视为超时。": "Listing uses 4x this value; uploads and downloads time out when no data is transferred for this long.",
+		"跟随系统":         "Follow system",
+		"界面语言:":        "Language:",
+		"修改将在重启应用后生效。": "Changes take effect after restarting the app.",
+		"语言":           "Language",
+		"匿名访问（仅浏览公开存储桶）":              "Anonymous access (public buckets only)",
+		"匿名访问无法列出存储桶，请输入要浏览的公开存储桶名称:": "Buckets cannot be listed anonymously. Enter the name of a public bucket to browse:",
+		"打开公开存储桶": "Open public bucket",
+		"失败重试次数:": "Retries on failure:",
+		"上传或下载失败的文件会自动重试，重试次数为 0 表示不重试。": "Failed uploads and downloads are retried automatically; 0 disables retries.",
+		"无效的重试次数":         "Invalid retry count",
+		"第 %d 次尝试: %v":    "Attempt %d: %v",
+		"%d 个项目在重试后仍然失败:": "%d items still failed after retrying:",
+		"%d 个项目失败:":       "%d items failed:",
+		"部分项目上传失败":        "Some items failed to upload",
+		"部分项目下载失败":        "Some items failed to download",
+		"不区分大小写":          "Case-insensitive keys",
+		"路径风格访问":          "Path-style access",
+		"批量设置标签":          "Set tags in bulk",
+		"本地 MinIO":        "Local MinIO",
+		"欢迎":              "Welcome",
+		"使用本地 MinIO 模板":   "Use local MinIO template",
+		"稍后":              "Later",
+		"欢迎使用 S3 资源管理器！窗口分为三栏：\n\n• 左侧：S3 服务列表，添加服务后点击即可连接\n• 中间：所选服务中的存储桶\n• 右侧：存储桶中的文件和文件夹，支持上传、下载、预览和拖拽\n\n首先添加一个 S3 服务。如果在本机运行了 MinIO，可以使用本地 MinIO 模板快速开始。": "Welcome to S3 Explorer! The window has three panels:\n\n• Left: your S3 services; click one to connect after adding it\n• Middle: the buckets of the selected service\n• Right: files and folders in the bucket, with upload, download, preview and drag and drop\n\nStart by adding an S3 service. If MinIO is running on this machine, use the local MinIO template to get started quickly.",
+		"第 %d 行缺少标签键":                                 "Line %d is missing a tag key",
+		"第 %d 行的标签键超过 %d 个字符":                         "The tag key on line %d exceeds %d characters",
+		"第 %d 行的标签值超过 %d 个字符":                         "The tag value on line %d exceeds %d characters",
+		"每个对象最多只能设置 %d 个标签":                           "An object can have at most %d tags",
+		"合并后的标签超过 %d 个":                               "More than %d tags after merging",
+		"请先选择要设置标签的对象。":                               "Please select the objects to tag first.",
+		"每行一个标签，例如：\nproject=alpha\ncost-center=1024": "One tag per line, for example:\nproject=alpha\ncost-center=1024",
+		"合并到现有标签":                                     "Merge with existing tags",
+		"替换全部标签":                                      "Replace all tags",
+		"标签":                                          "Tags",
+		"方式":                                          "Mode",
+		"应用":                                          "Apply",
+		"请至少输入一个标签。":                                  "Please enter at least one tag.",
+		"设置标签":                                        "Tagging",
+		"正在扫描选中的对象...":                                "Scanning selected objects...",
+		"选中的项目中没有对象。":                                 "The selection contains no objects.",
+		"将为 %d 个对象设置标签，确定继续吗？":                        "Tags will be set on %d objects. Continue?",
+		"部分对象设置标签失败":                                  "Failed to tag some objects",
+		"已为 %d 个对象设置标签。":                              "Tagged %d objects.",
+		"正在设置标签...":                                   "Setting tags...",
+		"%d 个对象":                                      "%d objects",
+		"导出列表":                                        "Export listing",
+		"置顶":                                          "Pin to top",
+		"取消置顶":                                        "Unpin",
+		"导出当前位置列表":                                    "Export listing of current location",
+		"位置":                                          "Location",
+		"格式":                                          "Format",
+		"选择保存位置":                                      "Choose location",
+		"已导出 %d 个对象":                                  "Exported %d objects",
+		"正在导出 %s 的对象列表 ...":                           "Exporting object listing of %s ...",
+		"写入文件失败: %w":                                  "Failed to write file: %w",
+		"已取消导出。":                                      "Export cancelled.",
+		"导出列表失败: %w":                                  "Failed to export listing: %w",
+		"已导出 %d 个对象到 %s":                              "Exported %d objects to %s",
+		"显示文件夹对象数量":                                   "Show folder object counts",
+		"无效的超时时间":                                     "Invalid timeout",
+		"上传大文件":                                       "Upload large files",
+		"以下文件较大，上传可能需要较长时间：\n%s\n\n是否继续上传？": "The following files are large and may take a long time to upload:\n%s\n\nContinue uploading?",
+		"以相对时间显示修改时间":                       "Show modification time as relative time",
+		"刚刚":                                "just now",
+		"%d 分钟前":                            "%d min ago",
+		"%d 小时前":                            "%d h ago",
+		"昨天":                                "yesterday",
+		"%d 天前":                             "%d days ago",
+		"正在连接服务…":                           "Connecting to service…",
+		"正在连接服务，请等待完成后再操作。": "Connecting to the service, please wait until it finishes.",
+		"类型:": "Type:",
+		"图片":  "Images",
+		"文档":  "Documents",
+		"音频":  "Audio",
+		"视频":  "Video",
+		"压缩包": "Archives",
+		"其他":  "Other",
+		"将粘贴 %d 个对象，共 %s，是否继续？": "%d objects (%s in total) will be pasted. Continue?",
+		"正在统计对象数量和大小...":        "Counting objects and size...",
+		"读取书签失败: %v":            "Failed to load bookmarks: %v",
+		"收藏当前位置":                "Bookmark current location",
+		"暂无书签":                  "No bookmarks",
+		"管理书签...":               "Manage bookmarks...",
+		"管理书签":                  "Manage bookmarks",
+		"删除书签":                  "Delete bookmark",
+		"确定要删除书签 '%s' 吗？":       "Delete bookmark '%s'?",
+		"重命名书签":                 "Rename bookmark",
+		"共 %d 个未完成的分段上传，%d 个分段，可回收 %s": "%d incomplete multipart uploads, %d parts, %s reclaimable",
+		"正在列出未完成的分段上传...":              "Listing incomplete multipart uploads...",
+		"中止选中的上传":                      "Abort selected uploads",
+		"清理未完成的分段上传 - %s":              "Clean up incomplete multipart uploads - %s",
+		"列出分段上传失败: %v":                 "Failed to list multipart uploads: %v",
+		"%s（开始于 %s，%d 个分段，%s）":         "%s (started %s, %d parts, %s)",
+		"确定要中止选中的 %d 个分段上传吗？已上传的分段将被删除且无法恢复。": "Abort the %d selected multipart uploads? Uploaded parts will be deleted permanently.",
+		"中止分段上传":              "Abort multipart uploads",
+		"正在中止分段上传...":         "Aborting multipart uploads...",
+		"%d 个分段上传中止失败，例如: %s": "Failed to abort %d multipart uploads, e.g.: %s",
+		"已中止 %d 个分段上传":        "Aborted %d multipart uploads",
+		"清理未完成的分段上传":          "Clean up incomplete multipart uploads",
+		"最近":                  "Recent",
+		"存储桶":                 "Bucket",
+		"当前目录":                "Current folder",
+		"搜索存储桶、最近访问的目录和当前列表中的对象": "Search buckets, recent folders and objects in the current listing",
+		"快速打开":            "Quick open",
+		"删除对象前确认":         "Confirm before deleting objects",
+		"覆盖已有对象前确认":       "Confirm before overwriting existing objects",
+		"粘贴复制的对象前确认":      "Confirm before pasting copied objects",
+		"继续":              "Continue",
+		"不再提示":            "Don't ask again",
+		"重新显示已关闭的提示 (%d)": "Show dismissed prompts again (%d)",
+		"所有提示都将重新显示。":     "All prompts will be shown again.",
+		"关闭确认后操作将立即执行，删除和覆盖无法撤销。": "Without confirmation the action runs immediately; deletions and overwrites cannot be undone.",
+		"操作确认": "Confirmations",
+		"%d 个文件的内容与已有对象不同，上传将覆盖这些对象，例如: %s\n\n是否继续上传？": "%d files differ from the existing objects and will overwrite them, e.g.: %s\n\nContinue uploading?",
+		"所有文件均未更改，已跳过 %d 个文件。":                         "No files have changed, skipped %d files.",
+		"所有项目上传完成，跳过了 %d 个未更改的文件。":                     "All items uploaded, skipped %d unchanged files.",
+		"跳过未更改的文件":            "Skip unchanged files",
+		"高级":                  "Advanced",
+		"名称，例如 Cache-Control": "Name, e.g. Cache-Control",
+		"值":                   "Value",
+		"添加请求头":               "Add header",
+		"留空的 Content-Type 会根据文件扩展名自动推断。自定义元数据请使用 x-amz-meta- 前缀。": "An empty Content-Type is inferred from the file extension. Use the x-amz-meta- prefix for custom metadata.",
+		"无效的元数据名称: %s": "Invalid metadata name: %s",
+		"不支持的请求头: %s（仅支持 Content-Type、Content-Disposition、Cache-Control 和 x-amz-meta-*）": "Unsupported header: %s (only Content-Type, Content-Disposition, Cache-Control and x-amz-meta-* are supported)",
+		"共 %d 个文件，解压后 %s": "%d files, %s uncompressed",
+		"提取选中的文件":         "Extract selected file",
+		"提取文件失败: %v":      "Failed to extract file: %v",
+		"已提取 '%s'":        "Extracted '%s'",
+		"无法读取压缩包内容":       "Unable to read the archive contents",
+		"详情":              "Details",
+		"%s（%d 次）":        "%s (%d times)",
+		"另有 %d 条通知":       "%d more notifications",
+		"清除全部":            "Clear all",
+		"通知":              "Notifications",
+		"部分缩略图加载失败":       "Some thumbnails failed to load",
+		"部分文件夹的对象数量统计失败":  "Failed to count objects in some folders",
+		"统计复制的对象失败":       "Failed to count the copied objects",
+		"缩略图并发数:":         "Thumbnail downloads:",
+		"无效的缩略图并发数":       "Invalid number of thumbnail downloads",
+		"加载缩略图":           "Load thumbnails",
+		"下载后自动打开":         "Open after download",
+		"比较两个对象":          "Compare two objects",
+		"比较文件失败: %v":      "Failed to compare files: %v",
+		"比较 - %s ↔ %s":    "Compare - %s ↔ %s",
+		"左: %s    右: %s    删除 %d 行，新增 %d 行":     "Left: %s    Right: %s    %d lines removed, %d lines added",
+		"开启后上传到同名位置：内容相同的文件被跳过，内容不同的文件将覆盖已有对象。": "Uploads keep their original names: identical files are skipped and changed files overwrite the existing objects.",
+		"分段大小(MiB):": "Part size (MiB):",
+		"分段上传并发数:":   "Upload part concurrency:",
+		"超过 64 MiB 的文件使用分段上传，分段大小范围 5–5120 MiB。": "Files larger than 64 MiB use multipart upload. The part size must be between 5 and 5120 MiB.",
+		"无效的分段大小":                               "Invalid part size",
+		"无效的分段上传并发数":                            "Invalid upload part concurrency",
+		"测试连接":                                  "Test Connection",
+		"正在测试...":                               "Testing...",
+		"请先填写 Endpoint、Access Key 和 Secret Key": "Please fill in the endpoint, Access Key and Secret Key first",
+		"连接失败: %v":                              "Connection failed: %v",
+		"连接成功（匿名访问无法列出存储桶）":                     "Connected (anonymous access cannot list buckets)",
+		"连接成功，共 %d 个存储桶":                        "Connected, %d buckets found",
+		"排序:":                                   "Sort:",
+		"大小":                                    "Size",
+		"修改时间":                                  "Modified",
+		"复制下载链接":                                "Copy download link",
+		"有效期:":                                  "Expires in:",
+		"持有链接的人在有效期内无需凭证即可下载该文件。": "Anyone with the link can download the file without credentials until it expires.",
+		"生成下载链接失败: %v":            "Failed to create download link: %v",
+		"已复制下载链接，有效期 %s":          "Download link copied, valid for %s",
+		"15 分钟":                   "15 minutes",
+		"1 小时":                    "1 hour",
+		"24 小时":                   "24 hours",
+		"7 天":                     "7 days",
+		"已取消上传，已完成 %d / %d 个文件。": "Upload cancelled, %d / %d files completed.",
+		"已取消下载，已完成 %d / %d 个文件。": "Download cancelled, %d / %d files completed.",
+		"已取消删除，已删除 %d / %d 个对象。": "Deletion cancelled, %d / %d objects deleted.",
+		"%s · 剩余 %s":             "%s · %s left",
+		"可选，使用临时凭证时填写":           "Optional, for temporary credentials",
+		"存储桶名称长度必须为 3 到 63 个字符":  "Bucket names must be 3 to 63 characters long",
+		"存储桶名称不能包含大写字母":          "Bucket names must not contain uppercase letters",
+		"存储桶名称不能包含下划线":           "Bucket names must not contain underscores",
+		"存储桶名称只能包含小写字母、数字、点和连字符": "Bucket names can only contain lowercase letters, numbers, dots and hyphens",
+		"存储桶名称必须以小写字母或数字开头和结尾":   "Bucket names must begin and end with a lowercase letter or number",
+		"存储桶名称不能包含连续的点":          "Bucket names must not contain two adjacent dots",
+		"存储桶名称不能是 IP 地址格式":       "Bucket names must not be formatted as an IP address",
+		"搜索整个存储桶":                "Search whole bucket",
+		"搜索失败: %v":               "Search failed: %v",
+		"匹配的对象过多，只显示前 %d 个结果。":   "Too many matches, showing the first %d results.",
+		"属性":                    "Properties",
+		"属性 - %s":               "Properties - %s",
+		"获取属性失败: %v":            "Failed to get properties: %v",
+		"键":                     "Key",
+		"最后修改时间":                "Last modified",
+		"存储类别":                  "Storage class",
+		"前缀":                    "Prefix",
+		"文件数":                   "Files",
+		"总大小":                   "Total size",
+		"%d 字节":                 "%d bytes",
+		"视图":                    "View",
+		"跟随系统主题":                "System theme",
+		"浅色主题":                  "Light theme",
+		"深色主题":                  "Dark theme",
+		"移动":                    "Move",
+		"将 %d 个项目移动到 '%s'？":     "Move %d items to '%s'?",
+		"正在移动":                  "Moving",
+		"正在移动对象...":             "Moving objects...",
+		"部分对象移动失败 (%d/%d):\n%s": "Some objects could not be moved (%d/%d):\n%s",
+		"已移动 %d 个项目。":           "Moved %d items.",
+		"重试":                    "Retry",
+		"传输并发数:":                "Transfer concurrency:",
+		"传输并发数是批量上传、下载和删除时同时处理的文件数量，范围 1–32。": "Transfer concurrency is the number of files uploaded, downloaded or deleted at the same time in batch operations (1–32).",
+		"无效的传输并发数":            "Invalid transfer concurrency",
+		"%s (副本)":             "%s (copy)",
+		"%s (副本 %d)":          "%s (copy %d)",
+		"请先选择一个要复制的服务。":       "Please select a service to duplicate first.",
+		"复制服务失败: %v":          "Failed to duplicate service: %v",
+		"包含 Secret Key 和会话令牌": "Include Secret Key and session token",
+		"密钥将以明文写入文件，请妥善保管导出的文件。": "Keys are written to the file in plain text; keep the exported file safe.",
+		"导出 %d 个服务":   "Export %d services",
+		"导出配置失败: %v":  "Failed to export configuration: %v",
+		"已导出 %d 个服务。": "Exported %d services.",
+		"导入配置失败: %v":  "Failed to import configuration: %v",
+		"文件中没有服务配置。":  "The file contains no services.",
+		"跳过同名服务":      "Skip services with the same alias",
+		"覆盖同名服务":      "Overwrite services with the same alias",
+		"以下服务已存在: %s": "These services already exist: %s",
+		"同名服务":        "Existing services",
+		"导入配置":        "Import configuration",
+		"导出配置":        "Export configuration",
+		"导入":          "Import",
+		"已添加 %d 个服务，覆盖 %d 个，跳过 %d 个。": "Added %d services, overwrote %d, skipped %d.",
+		"调整服务顺序失败: %v":                "Failed to reorder services: %v",
+		"清除缩略图缓存":                     "Clear thumbnail cache",
+		"清除缩略图缓存失败: %v":               "Failed to clear thumbnail cache: %v",
+		"已清除缩略图缓存。":                   "Thumbnail cache cleared.",
+		"首选项":                         "Preferences",
+		"传输":                          "Transfers",
+		"主题":                          "Theme",
+		"界面主题:":                       "Theme:",
+		"也可以通过 \"视图\" 菜单切换主题。":               "You can also switch the theme from the \"View\" menu.",
+		"Endpoint 格式无效":                      "Invalid endpoint",
+		"Endpoint 只支持 http:// 或 https:// 协议": "Endpoints must use http:// or https://",
+		"Endpoint 缺少主机名":                     "The endpoint is missing a host name",
+		"Endpoint 不能包含用户名、查询参数或锚点":           "Endpoints must not contain a user name, query or fragment",
+		"添加标签":                "Add tag",
+		"保存标签":                "Save tags",
+		"保存标签失败: %v":          "Failed to save tags: %v",
+		"标签已保存。":              "Tags saved.",
+		"无法读取标签: %v":          "Unable to read tags: %v",
+		"标签键不能为空":             "Tag keys cannot be empty",
+		"标签键 %q 超过 %d 个字符":    "Tag key %q is longer than %d characters",
+		"标签 %q 的值超过 %d 个字符":   "The value of tag %q is longer than %d characters",
+		"标签 %q 重复":            "Tag %q is duplicated",
+		"下载为 ZIP":             "Download as ZIP",
+		"正在打包 %s ...":         "Packing %s ...",
+		"下载为 ZIP 失败: %v":      "Failed to download as ZIP: %v",
+		"已将 %d 个文件打包到 %s":     "Packed %d files into %s",
+		"复制 S3 路径":            "Copy S3 URI",
+		"已复制 S3 路径: %s":       "Copied S3 URI: %s",
+		"已复制 %d 个 S3 路径。":     "Copied %d S3 URIs.",
+		"下载 %s 为 ZIP":         "Download %s as ZIP",
+		"%s 等 %d 个项目":         "%s (%d items)",
+		"正在准备...":             "Preparing...",
+		"正在取消...":             "Cancelling...",
+		"清除已结束":               "Clear finished",
+		"传输: %d 个进行中，%d 个已结束": "Transfers: %d running, %d finished",
+		"此操作将永久删除存储桶 \"%s\" 中的所有对象（包括历史版本和删除标记），然后删除存储桶本身，且无法恢复。": "This permanently deletes every object in bucket \"%s\" (including old versions and delete markers) and then the bucket itself. This cannot be undone.",
+		"强制删除存储桶":    "Force Delete Bucket",
+		"强制删除":       "Force Delete",
+		"强制删除（含内容）":  "Force delete (with contents)",
+		"正在删除存储桶...": "Deleting bucket...",
+		"存储桶 \"%s\" 及其中的 %d 个对象版本已删除。": "Bucket \"%s\" and its %d object versions were deleted.",
+		"已扫描 %d 个对象": "Scanned %d objects",
+		"%d 组重复文件的所有副本都被选中，每组至少需要保留一个文件，例如: %s": "All copies are selected in %d duplicate groups; keep at least one file in each group, e.g. %s",
+	},
+}
+
+// T 返回消息 ID 在当前界面语言下的文字，没有翻译时返回消息 ID 本身
+func T(id string) string {
+	if text, ok := translations[currentLanguage][id]; ok {
+		return text
+	}
+	return id
+}
+
+// Language 返回当前界面语言，"zh" 或 "en"
+func Language() string {
+	return currentLanguage
+}
+
+// languageSetting 返回保存的语言设置，默认跟随系统
+func languageSetting() string {
+	return fyne.CurrentApp().Preferences().StringWithFallback(prefLanguage, languageAuto)
+}
+
+// resolveLanguage 将语言设置解析为实际使用的语言，跟随系统时中文系统使用中文，其他使用英文
+func resolveLanguage(setting string) string {
+	switch setting {
+	case languageZH, languageEN:
+		return setting
+	}
+	if strings.HasPrefix(strings.ToLower(lang.SystemLocale().LanguageString()), "zh") {
+		return languageZH
+	}
+	return languageEN
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTFallsBackToMessageID(t *testing.T) {
+	old := currentLanguage
+	defer func() { currentLanguage = old }()
+
+	currentLanguage = languageEN
+	if got := T("上传"); got != "Upload" {
+		t.Errorf("T(上传) = %q, 期望 %q", got, "Upload")
+	}
+	if got := T("没有翻译的文字"); got != "没有翻译的文字" {
+		t.Errorf("缺少翻译时应返回消息 ID，实际为 %q", got)
+	}
+
+	currentLanguage = languageZH
+	if got := T("上传"); got != "上传" {
+		t.Errorf("中文界面下 T(上传) = %q", got)
+	}
+}
+
+// 翻译必须保留与消息 ID 相同的格式化占位符，否则 fmt.Sprintf 的结果会错位
+func TestTranslationsKeepFormatVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[vsdw]`)
+	for id, text := range translations[languageEN] {
+		want := verbs.FindAllString(id, -1)
+		got := verbs.FindAllString(text, -1)
+		if len(want) != len(got) {
+			t.Errorf("翻译 %q -> %q 的占位符不一致", id, text)
+			continue
+		}
+		for i := range want {
+			if want[i] != got[i] {
+				t.Errorf("翻译 %q -> %q 的占位符不一致", id, text)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// nextSelectionIndex 返回方向键移动后的索引。cursor 为 -1（没有当前项）时，向下从第一项开始、向上从最后一项开始；
+// 结果限制在 [0, count) 内。列表为空时返回 -1。
+func nextSelectionIndex(cursor, delta, count int) int {
+	if count == 0 {
+		return -1
+	}
+	if cursor < 0 || cursor >= count {
+		if delta < 0 {
+			return count - 1
+		}
+		return 0
+	}
+	return min(max(cursor+delta, 0), count-1)
+}
+
+// selectionRange 返回 anchor 与 cursor 之间（含两端）的所有索引
+func selectionRange(anchor, cursor int) map[widget.ListItemID]struct{} {
+	start, end := min(anchor, cursor), max(anchor, cursor)
+	ids := make(map[widget.ListItemID]struct{}, end-start+1)
+	for i := start; i <= end; i++ {
+		ids[i] = struct{}{}
+	}
+	return ids
+}
+
+// shiftPressed 报告当前是否按住了 Shift 键。画布的按键回调不带修饰键，需要向驱动查询。
+func shiftPressed() bool {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return false
+	}
+	if drv, ok := app.Driver().(desktop.Driver); ok {
+		return drv.CurrentKeyModifiers()&fyne.KeyModifierShift != 0
+	}
+	return false
+}
+
+// handleObjectsKey 处理没有控件获得焦点时的按键：上下方向键移动选择（按住 Shift 扩展选择），
+// 回车打开选中的文件夹或预览文件，退格返回上一级目录。
+func (ov *ObjectsView) handleObjectsKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeyDelete:
+		ov.deleteSelectedFromKeyboard()
+	case fyne.KeyUp:
+		ov.moveSelection(-1, shiftPressed())
+	case fyne.KeyDown:
+		ov.moveSelection(1, shiftPressed())
+	case fyne.KeyReturn, fyne.KeyEnter:
+		ov.openSelectedFromKeyboard()
+	case fyne.KeyBackspace:
+		ov.goToParentFolder()
+	}
+}
+
+// selectionCursor 返回键盘选择的当前项。上次用键盘或鼠标移动到的项目已不再选中时，退回到最后点击的项目。
+func (ov *ObjectsView) selectionCursor() widget.ListItemID {
+	if _, ok := ov.selectedObjectIDs[ov.keyboardCursor]; ok && ov.keyboardCursor >= 0 {
+		return ov.keyboardCursor
+	}
+	return ov.lastSelectedID
+}
+
+// moveSelection 将选择移动 delta 项。extend 为 true 时与 Shift+点击一样，
+// 从最后点击的项目选择到新的当前项；否则只选中新的当前项。
+func (ov *ObjectsView) moveSelection(delta int, extend bool) {
+	items := ov.getDisplayedObjects()
+	if ov.currentBucket == "" || len(items) == 0 {
+		return
+	}
+	cursor := ov.selectionCursor()
+	next := nextSelectionIndex(cursor, delta, len(items))
+
+	if extend {
+		anchor := ov.lastSelectedID
+		if anchor < 0 || anchor >= len(items) {
+			anchor = next
+			ov.lastSelectedID = next
+		}
+		ov.selectedObjectIDs = selectionRange(anchor, next)
+	} else {
+		ov.selectedObjectIDs = map[widget.ListItemID]struct{}{next: {}}
+		ov.lastSelectedID = next
+	}
+	ov.keyboardCursor = next
+
+	ov.refreshSelection()
+	ov.updateButtonsState()
+	ov.updateSelectionTitle()
+	if ov.viewMode != gridViewMode && ov.objectList != nil {
+		ov.objectList.ScrollTo(next)
+	}
+}
+
+// openSelectedFromKeyboard 响应回车键：与双击一样打开选中的文件夹或预览选中的文件。只在选中一个项目时生效。
+func (ov *ObjectsView) openSelectedFromKeyboard() {
+	items := ov.getDisplayedObjects()
+	if ov.currentBucket == "" || len(ov.selectedObjectIDs) != 1 {
+		return
+	}
+	for id := range ov.selectedObjectIDs {
+		if id >= len(items) {
+			return
+		}
+		item := items[id]
+		if item.IsFolder {
+			ov.SetBucketAndPrefix(ov.s3Client, ov.currentBucket, item.Key)
+		} else {
+			ov.showPreviewWindow(item)
+		}
+	}
+}
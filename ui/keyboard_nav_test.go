@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestNextSelectionIndex(t *testing.T) {
+	tests := []struct {
+		name                 string
+		cursor, delta, count int
+		want                 int
+	}{
+		{"empty list", -1, 1, 0, -1},
+		{"down without cursor starts at first", -1, 1, 5, 0},
+		{"up without cursor starts at last", -1, -1, 5, 4},
+		{"stale cursor treated as none", 7, 1, 5, 0},
+		{"down", 1, 1, 5, 2},
+		{"up", 1, -1, 5, 0},
+		{"clamped at top", 0, -1, 5, 0},
+		{"clamped at bottom", 4, 1, 5, 4},
+	}
+	for _, tt := range tests {
+		if got := nextSelectionIndex(tt.cursor, tt.delta, tt.count); got != tt.want {
+			t.Errorf("%s: nextSelectionIndex(%d, %d, %d) = %d, want %d", tt.name, tt.cursor, tt.delta, tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestSelectionRange(t *testing.T) {
+	want := map[widget.ListItemID]struct{}{2: {}, 3: {}, 4: {}}
+	if got := selectionRange(2, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("selectionRange(2, 4) = %v, want %v", got, want)
+	}
+	if got := selectionRange(4, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("selectionRange(4, 2) = %v, want %v", got, want)
+	}
+	if got := selectionRange(3, 3); len(got) != 1 {
+		t.Errorf("selectionRange(3, 3) = %v, want single item", got)
+	}
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+var (
+	// streamUploadThreshold 以上的文件直接从磁盘流式上传，不再整个读入内存
+	streamUploadThreshold int64 = 256 << 20
+	// largeUploadConfirmThreshold 以上的文件在上传前需要用户确认，并显示文件大小
+	largeUploadConfirmThreshold int64 = 1 << 30
+)
+
+// maxLargeUploadsListed 是确认对话框中最多列出的大文件数量
+const maxLargeUploadsListed = 5
+
+// openUploadSource 打开待上传的本地文件，返回可寻址的数据流、实际大小和关闭函数。
+// 较小的文件读入内存以保持原有行为，超过 streamUploadThreshold 的文件直接使用文件句柄，避免占满内存。
+func openUploadSource(localPath string, fileSize int64) (io.ReadSeeker, int64, func() error, error) {
+	if fileSize <= streamUploadThreshold {
+		data, err := ioutil.ReadFile(localPath)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("无法读取文件 '%s' 到内存: %w", filepath.Base(localPath), err)
+		}
+		return bytes.NewReader(data), int64(len(data)), func() error { return nil }, nil
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("无法打开文件 '%s': %w", filepath.Base(localPath), err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, nil, fmt.Errorf("无法获取文件信息 '%s': %w", filepath.Base(localPath), err)
+	}
+	return file, info.Size(), file.Close, nil
+}
+
+// largeUploadsMessage 返回超过确认阈值的文件说明，没有大文件时返回空字符串
+func largeUploadsMessage(files []uploadFile) string {
+	var lines []string
+	for _, f := range files {
+		if f.Size < largeUploadConfirmThreshold {
+			continue
+		}
+		if len(lines) < maxLargeUploadsListed {
+			lines = append(lines, fmt.Sprintf("%s (%s)", filepath.Base(f.LocalPath), formatBytes(f.Size)))
+		} else {
+			lines = append(lines, "…")
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(T("以下文件较大，上传可能需要较长时间：\n%s\n\n是否继续上传？"), strings.Join(lines, "\n"))
+}
+
+// confirmLargeUploads 在后台上传流程中请求用户确认大文件上传，阻塞直到用户作出选择。
+// 用户勾选过“不再提示”时直接继续上传。
+func (ov *ObjectsView) confirmLargeUploads(message string) bool {
+	answer := make(chan bool, 1)
+	fyne.Do(func() {
+		confirmSuppressible(prefSuppressLargeUploadPrompt, T("上传大文件"), message, ov.window, func(confirmed bool) {
+			answer <- confirmed
+		})
+	})
+	return <-answer
+}
+
+// confirmBlocking 在 UI 线程中显示确认对话框并等待用户选择，只能在后台 goroutine 中调用
+func (ov *ObjectsView) confirmBlocking(title, message string) bool {
+	answer := make(chan bool, 1)
+	fyne.Do(func() {
+		dialog.ShowConfirm(title, message, func(confirmed bool) {
+			answer <- confirmed
+		}, ov.window)
+	})
+	return <-answer
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenUploadSourceStreamsLargeFiles(t *testing.T) {
+	oldThreshold := streamUploadThreshold
+	defer func() { streamUploadThreshold = oldThreshold }()
+	streamUploadThreshold = 4
+
+	path := filepath.Join(t.TempDir(), "big.bin")
+	if err := ioutil.WriteFile(path, []byte("0123456789"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, size, closeSource, err := openUploadSource(path, 10)
+	if err != nil {
+		t.Fatalf("打开文件失败: %v", err)
+	}
+	defer closeSource()
+	if _, ok := reader.(*os.File); !ok {
+		t.Errorf("超过阈值的文件应直接从磁盘读取，实际为 %T", reader)
+	}
+	if size != 10 {
+		t.Errorf("size = %d, 期望 10", size)
+	}
+	data, _ := ioutil.ReadAll(reader)
+	if string(data) != "0123456789" {
+		t.Errorf("读取的内容为 %q", data)
+	}
+
+	reader, _, closeSource, err = openUploadSource(path, 3)
+	if err != nil {
+		t.Fatalf("打开文件失败: %v", err)
+	}
+	defer closeSource()
+	if _, ok := reader.(*os.File); ok {
+		t.Error("未超过阈值的文件应读入内存")
+	}
+}
+
+func TestLargeUploadsMessage(t *testing.T) {
+	oldThreshold := largeUploadConfirmThreshold
+	defer func() { largeUploadConfirmThreshold = oldThreshold }()
+	largeUploadConfirmThreshold = 1024
+
+	if msg := largeUploadsMessage([]uploadFile{{LocalPath: "/tmp/a.txt", Size: 10}}); msg != "" {
+		t.Errorf("没有大文件时不应要求确认，实际为 %q", msg)
+	}
+
+	msg := largeUploadsMessage([]uploadFile{
+		{LocalPath: "/tmp/a.txt", Size: 10},
+		{LocalPath: "/tmp/b.iso", Size: 2048},
+	})
+	if !strings.Contains(msg, "b.iso ("+formatBytes(2048)+")") {
+		t.Errorf("确认信息应包含大文件名称和大小，实际为 %q", msg)
+	}
+	if strings.Contains(msg, "a.txt") {
+		t.Errorf("确认信息不应包含小文件，实际为 %q", msg)
+	}
+}
@@ -0,0 +1,28 @@
+package ui
+
+import "fyne.io/fyne/v2"
+
+// 上次打开的服务、存储桶和目录保存在 Fyne Preferences 中，下次启动时恢复
+const (
+	prefLastService = "lastService"
+	prefLastBucket  = "lastBucket"
+	prefLastPrefix  = "lastPrefix"
+)
+
+// saveLastLocation 记录当前打开的位置，alias 为空表示没有选中服务
+func saveLastLocation(alias, bucket, prefix string) {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return
+	}
+	prefs := app.Preferences()
+	prefs.SetString(prefLastService, alias)
+	prefs.SetString(prefLastBucket, bucket)
+	prefs.SetString(prefLastPrefix, prefix)
+}
+
+// LastLocation 返回上次退出时打开的服务别名、存储桶和目录，没有记录时均为空
+func LastLocation() (alias, bucket, prefix string) {
+	prefs := fyne.CurrentApp().Preferences()
+	return prefs.String(prefLastService), prefs.String(prefLastBucket), prefs.String(prefLastPrefix)
+}
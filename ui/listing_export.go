@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// 导出格式
+const (
+	exportFormatCSV  = "CSV"
+	exportFormatJSON = "JSON"
+)
+
+// manifestEntry 是导出清单中的一行
+type manifestEntry struct {
+	Key          string `json:"key"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"lastModified"`
+	StorageClass string `json:"storageClass"`
+	ETag         string `json:"etag"`
+}
+
+// manifestWriter 逐条写入导出清单，Close 写入结尾并刷新缓冲，但不关闭底层文件
+type manifestWriter interface {
+	Write(entry manifestEntry) error
+	Close() error
+}
+
+// newManifestWriter 按格式创建清单写入器
+func newManifestWriter(format string, w io.Writer) (manifestWriter, error) {
+	if format == exportFormatJSON {
+		return newJSONManifestWriter(w)
+	}
+	return newCSVManifestWriter(w)
+}
+
+// csvManifestWriter 写入带表头的 CSV 清单
+type csvManifestWriter struct {
+	w *csv.Writer
+}
+
+func newCSVManifestWriter(w io.Writer) (*csvManifestWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"key", "size", "last_modified", "storage_class", "etag"}); err != nil {
+		return nil, err
+	}
+	return &csvManifestWriter{w: cw}, nil
+}
+
+func (m *csvManifestWriter) Write(entry manifestEntry) error {
+	return m.w.Write([]string{entry.Key, strconv.FormatInt(entry.Size, 10), entry.LastModified, entry.StorageClass, entry.ETag})
+}
+
+func (m *csvManifestWriter) Close() error {
+	m.w.Flush()
+	return m.w.Error()
+}
+
+// jsonManifestWriter 以 JSON 数组的形式逐条写入清单，不需要在内存中保存整个数组
+type jsonManifestWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newJSONManifestWriter(w io.Writer) (*jsonManifestWriter, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("["); err != nil {
+		return nil, err
+	}
+	return &jsonManifestWriter{w: bw}, nil
+}
+
+func (m *jsonManifestWriter) Write(entry manifestEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if m.count == 0 {
+		sep = "\n  "
+	}
+	m.count++
+	if _, err := m.w.WriteString(sep); err != nil {
+		return err
+	}
+	_, err = m.w.Write(data)
+	return err
+}
+
+func (m *jsonManifestWriter) Close() error {
+	end := "\n]\n"
+	if m.count == 0 {
+		end = "]\n"
+	}
+	if _, err := m.w.WriteString(end); err != nil {
+		return err
+	}
+	return m.w.Flush()
+}
+
+// exportFileName 返回导出清单的默认文件名，如 "bucket-photos-2024.csv"
+func exportFileName(bucket, prefix, format string) string {
+	name := bucket
+	if p := strings.Trim(prefix, "/"); p != "" {
+		name += "-" + strings.ReplaceAll(p, "/", "-")
+	}
+	return name + "." + strings.ToLower(format)
+}
+
+// startListingExport 询问导出格式和保存位置，然后将前缀下所有对象的清单导出到文件
+func (ov *ObjectsView) startListingExport(prefix string) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		ShowToast(ov.window, T("请先选择一个 S3 服务和存储桶。"))
+		return
+	}
+	client, bucket := ov.s3Client, ov.currentBucket
+
+	formatRadio := widget.NewRadioGroup([]string{exportFormatCSV, exportFormatJSON}, nil)
+	formatRadio.Horizontal = true
+	formatRadio.SetSelected(exportFormatCSV)
+	location := "/" + path.Join(bucket, prefix)
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("位置"), widget.NewLabel(location)),
+		widget.NewFormItem(T("格式"), formatRadio),
+	}
+	dialog.ShowForm(T("导出列表"), T("选择保存位置"), T("取消"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		format := formatRadio.Selected
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, ov.window)
+				return
+			}
+			if writer == nil {
+				return // 用户取消
+			}
+			ov.runListingExport(client, bucket, prefix, format, writer)
+		}, ov.window)
+		saveDialog.SetFileName(exportFileName(bucket, prefix, format))
+		saveDialog.Show()
+	}, ov.window)
+}
+
+// runListingExport 在后台逐页列举对象并写入清单，显示进度并允许取消。取消或失败时删除未完成的文件。
+func (ov *ObjectsView) runListingExport(client ObjectStore, bucket, prefix, format string, writer fyne.URIWriteCloser) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	countLabel := widget.NewLabel(fmt.Sprintf(T("已导出 %d 个对象"), 0))
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf(T("正在导出 %s 的对象列表 ..."), "/"+path.Join(bucket, prefix))),
+		widget.NewProgressBarInfinite(),
+		countLabel,
+	)
+	progressDialog := dialog.NewCustom(T("导出列表"), T("取消"), content, ov.window)
+	progressDialog.SetOnClosed(cancel)
+	progressDialog.Show()
+
+	go func() {
+		defer cancel()
+		count := 0
+		manifest, err := newManifestWriter(format, writer)
+		if err == nil {
+			err = client.WalkObjects(ctx, bucket, prefix, func(obj s3client.S3Object) error {
+				if err := manifest.Write(manifestEntry{
+					Key:          obj.Key,
+					Size:         obj.Size,
+					LastModified: obj.LastModified,
+					StorageClass: obj.StorageClass,
+					ETag:         obj.ETag,
+				}); err != nil {
+					return fmt.Errorf(T("写入文件失败: %w"), err)
+				}
+				count++
+				if count%1000 == 0 {
+					n := count
+					fyne.Do(func() {
+						countLabel.SetText(fmt.Sprintf(T("已导出 %d 个对象"), n))
+					})
+				}
+				return nil
+			})
+			if err == nil {
+				err = manifest.Close()
+			}
+		}
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			if removeErr := storage.Delete(writer.URI()); removeErr != nil {
+				log.Printf("删除未完成的导出文件失败: %v", removeErr)
+			}
+		}
+
+		fyne.Do(func() {
+			progressDialog.SetOnClosed(nil)
+			progressDialog.Hide()
+			switch {
+			case errors.Is(err, context.Canceled):
+				ShowToast(ov.window, T("已取消导出。"))
+			case err != nil:
+				dialog.ShowError(fmt.Errorf(T("导出列表失败: %w"), err), ov.window)
+			default:
+				dialog.ShowInformation(T("导出列表"), fmt.Sprintf(T("已导出 %d 个对象到 %s"), count, writer.URI().Path()), ov.window)
+			}
+		})
+	}()
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var testManifestEntries = []manifestEntry{
+	{Key: "docs/a.txt", Size: 12, LastModified: "2024-05-01T10:00:00Z", StorageClass: "STANDARD", ETag: "abc"},
+	{Key: "docs/b,\"c\".txt", Size: 0, LastModified: "2024-05-02T10:00:00Z", StorageClass: "GLACIER", ETag: "def-2"},
+}
+
+func writeManifest(t *testing.T, format string, entries []manifestEntry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	m, err := newManifestWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("创建写入器失败: %v", err)
+	}
+	for _, entry := range entries {
+		if err := m.Write(entry); err != nil {
+			t.Fatalf("写入失败: %v", err)
+		}
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("关闭失败: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCSVManifestWriter(t *testing.T) {
+	got := writeManifest(t, exportFormatCSV, testManifestEntries)
+	want := "key,size,last_modified,storage_class,etag\n" +
+		"docs/a.txt,12,2024-05-01T10:00:00Z,STANDARD,abc\n" +
+		"\"docs/b,\"\"c\"\".txt\",0,2024-05-02T10:00:00Z,GLACIER,def-2\n"
+	if got != want {
+		t.Errorf("CSV 输出 = %q, 期望 %q", got, want)
+	}
+}
+
+func TestJSONManifestWriter(t *testing.T) {
+	for _, entries := range [][]manifestEntry{nil, testManifestEntries[:1], testManifestEntries} {
+		out := writeManifest(t, exportFormatJSON, entries)
+		var decoded []manifestEntry
+		if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+			t.Fatalf("输出不是有效的 JSON: %v\n%s", err, out)
+		}
+		if len(entries) == 0 && len(decoded) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(decoded, entries) {
+			t.Errorf("JSON 解码结果 = %+v, 期望 %+v", decoded, entries)
+		}
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	if got := exportFileName("bucket", "", exportFormatCSV); got != "bucket.csv" {
+		t.Errorf("got %q", got)
+	}
+	if got := exportFileName("bucket", "photos/2024/", exportFormatJSON); got != "bucket-photos-2024.json" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// errMoveIntoSelf 表示把文件夹移动到它自身或它的子文件夹中
+var errMoveIntoSelf = errors.New("不能将文件夹移动到它自身或它的子文件夹中")
+
+// parentKeyPrefix 返回对象所在目录的前缀，文件夹的键以 / 结尾
+func parentKeyPrefix(key string) string {
+	trimmed := strings.TrimSuffix(key, "/")
+	return trimmed[:strings.LastIndex(trimmed, "/")+1]
+}
+
+// movableObjects 返回移动到 folderKey 时需要移动的对象，已经位于该文件夹中的对象被跳过。
+// 任何一个文件夹是 folderKey 本身或其上级时返回 errMoveIntoSelf。
+func movableObjects(objects []s3client.S3Object, folderKey string) ([]s3client.S3Object, error) {
+	var result []s3client.S3Object
+	for _, obj := range objects {
+		if obj.IsFolder && strings.HasPrefix(folderKey, obj.Key) {
+			return nil, errMoveIntoSelf
+		}
+		if parentKeyPrefix(obj.Key) != folderKey {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// dropHighlighter 是可以显示为拖放目标的条目
+type dropHighlighter interface {
+	setDropHighlight(on bool)
+}
+
+func (e *listEntry) setDropHighlight(on bool) {
+	e.dropHover = on
+	e.Refresh()
+}
+
+func (e *gridEntry) setDropHighlight(on bool) {
+	e.dropHover = on
+	e.Refresh()
+}
+
+func (e *listEntry) Dragged(ev *fyne.DragEvent) {
+	e.ov.dragEntry(e.id, ev.AbsolutePosition)
+}
+
+func (e *listEntry) DragEnd() {
+	e.ov.endEntryDrag()
+}
+
+func (e *gridEntry) Dragged(ev *fyne.DragEvent) {
+	e.ov.dragEntry(e.id, ev.AbsolutePosition)
+}
+
+func (e *gridEntry) DragEnd() {
+	e.ov.endEntryDrag()
+}
+
+// dragEntry 在拖动条目 id 时调用。拖动已选中的条目时移动所有选中的项目，否则只移动该条目。
+// 光标下的文件夹可以接收这些项目时高亮显示。
+func (ov *ObjectsView) dragEntry(id widget.ListItemID, pos fyne.Position) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	if ov.dragObjects == nil {
+		items := ov.getDisplayedObjects()
+		if id < 0 || id >= len(items) {
+			return
+		}
+		if _, selected := ov.selectedObjectIDs[id]; selected {
+			ov.dragObjects = ov.getSelectedObjects()
+		} else {
+			ov.dragObjects = []s3client.S3Object{items[id]}
+		}
+	}
+
+	var highlight dropHighlighter
+	folder, entry, ok := ov.folderEntryAtPosition(pos)
+	if ok {
+		if objects, err := movableObjects(ov.dragObjects, folder.Key); err == nil && len(objects) > 0 {
+			highlight, _ = entry.(dropHighlighter)
+		}
+	}
+	if highlight == nil {
+		ov.dropFolder = nil
+	} else {
+		ov.dropFolder = &folder
+	}
+	if highlight != ov.dropHighlight {
+		if ov.dropHighlight != nil {
+			ov.dropHighlight.setDropHighlight(false)
+		}
+		if highlight != nil {
+			highlight.setDropHighlight(true)
+		}
+		ov.dropHighlight = highlight
+	}
+}
+
+// endEntryDrag 在拖动结束时调用，松开在可接收的文件夹上时确认后移动拖动的项目
+func (ov *ObjectsView) endEntryDrag() {
+	objects, folder := ov.dragObjects, ov.dropFolder
+	ov.dragObjects, ov.dropFolder = nil, nil
+	if ov.dropHighlight != nil {
+		ov.dropHighlight.setDropHighlight(false)
+		ov.dropHighlight = nil
+	}
+	if folder == nil {
+		return
+	}
+	objects, err := movableObjects(objects, folder.Key)
+	if err != nil || len(objects) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf(T("将 %d 个项目移动到 '%s'？"), len(objects), folder.Name)
+	confirmSuppressible(prefSuppressMovePrompt, T("移动"), message, ov.window, func(confirmed bool) {
+		if confirmed {
+			bucket, folderKey := ov.currentBucket, folder.Key
+			ov.runOperation(T("移动"), func() { ov.moveObjects(bucket, objects, folderKey) })
+		}
+	})
+}
+
+// moveObjects 将对象移动到 folderKey 文件夹中，完成后显示结果并刷新列表。在后台 goroutine 中调用。
+func (ov *ObjectsView) moveObjects(bucket string, objects []s3client.S3Object, folderKey string) {
+	var progressDialog dialog.Dialog
+	fyne.Do(func() {
+		progressDialog = dialog.NewProgressInfinite(T("正在移动"), T("正在移动对象..."), ov.window)
+		progressDialog.Show()
+	})
+
+	var failures []string
+	for _, obj := range objects {
+		if err := ov.moveObject(bucket, obj, folderKey); err != nil {
+			log.Printf("移动 '%s' 失败: %v", obj.Key, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", obj.Name, err))
+		}
+	}
+
+	fyne.Do(func() {
+		progressDialog.Hide()
+		if len(failures) > 0 {
+			dialog.ShowError(fmt.Errorf(T("部分对象移动失败 (%d/%d):\n%s"), len(failures), len(objects), strings.Join(failures, "\n")), ov.window)
+		} else {
+			ShowToast(ov.window, fmt.Sprintf(T("已移动 %d 个项目。"), len(objects)))
+		}
+		ov.loadObjects()
+	})
+}
+
+// moveObject 通过服务端复制和删除将一个文件或文件夹移动到 folderKey 文件夹中，目标已存在同名项目时自动重命名。
+// 文件夹中有任何对象复制失败时不删除源文件夹，避免丢失数据。
+func (ov *ObjectsView) moveObject(bucket string, obj s3client.S3Object, folderKey string) error {
+	if !obj.IsFolder {
+		target, err := ov.findAvailableObjectKey(folderKey + path.Base(obj.Key))
+		if err != nil {
+			return err
+		}
+		if err := ov.s3Client.CopyObject(bucket, obj.Key, target); err != nil {
+			return err
+		}
+		log.Printf("已移动文件: %s -> %s", obj.Key, target)
+		return ov.s3Client.DeleteObject(context.Background(), bucket, obj.Key)
+	}
+
+	if strings.HasPrefix(folderKey, obj.Key) {
+		return errMoveIntoSelf
+	}
+	name, err := ov.findAvailableFolderNameIn(folderKey, path.Base(strings.TrimSuffix(obj.Key, "/")))
+	if err != nil {
+		return err
+	}
+	targetPrefix := folderKey + name + "/"
+	keys, err := ov.s3Client.ListAllKeysUnderPrefix(bucket, obj.Key)
+	if err != nil {
+		return fmt.Errorf("列出文件夹 '%s' 内容失败: %w", obj.Key, err)
+	}
+	for _, key := range keys {
+		if err := ov.s3Client.CopyObject(bucket, key, targetPrefix+strings.TrimPrefix(key, obj.Key)); err != nil {
+			return fmt.Errorf("复制 '%s' 失败，源文件夹未删除: %w", key, err)
+		}
+	}
+	log.Printf("已复制文件夹: %s -> %s", obj.Key, targetPrefix)
+	return ov.deleteFolderAndContents(bucket, obj.Key)
+}
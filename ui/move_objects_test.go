@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestMovableObjects(t *testing.T) {
+	objects := []s3client.S3Object{
+		{Key: "docs/a.txt"},
+		{Key: "docs/archive/", IsFolder: true},
+		{Key: "docs/photos/", IsFolder: true},
+	}
+
+	got, err := movableObjects(objects, "docs/archive/")
+	if !errors.Is(err, errMoveIntoSelf) {
+		t.Errorf("移动到自身应返回 errMoveIntoSelf，实际为 %v, %v", got, err)
+	}
+	if _, err := movableObjects(objects, "docs/photos/2024/"); !errors.Is(err, errMoveIntoSelf) {
+		t.Errorf("移动到子文件夹应返回 errMoveIntoSelf，实际为 %v", err)
+	}
+
+	// 已经位于目标文件夹中的项目被跳过
+	got, err = movableObjects(objects[:1], "docs/")
+	if err != nil || len(got) != 0 {
+		t.Errorf("movableObjects 到所在文件夹 = %v, %v，期望没有需要移动的项目", got, err)
+	}
+
+	got, err = movableObjects([]s3client.S3Object{objects[0], objects[2]}, "docs/archive/")
+	if err != nil || !reflect.DeepEqual(got, []s3client.S3Object{objects[0], objects[2]}) {
+		t.Errorf("movableObjects = %v, %v", got, err)
+	}
+}
+
+func TestMoveObject(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/a.txt", "a")
+	store.put(testBucket, "docs/photos/", "")
+	store.put(testBucket, "docs/photos/1.jpg", "1")
+	store.put(testBucket, "docs/photos/2024/2.jpg", "2")
+	store.put(testBucket, "docs/archive/", "")
+	store.put(testBucket, "docs/archive/a.txt", "old")
+	ov := newTestObjectsView(store, "docs/")
+
+	if err := ov.moveObject(testBucket, s3client.S3Object{Name: "a.txt", Key: "docs/a.txt"}, "docs/archive/"); err != nil {
+		t.Fatalf("移动文件返回错误: %v", err)
+	}
+	if err := ov.moveObject(testBucket, s3client.S3Object{Name: "photos", Key: "docs/photos/", IsFolder: true}, "docs/archive/"); err != nil {
+		t.Fatalf("移动文件夹返回错误: %v", err)
+	}
+
+	want := []string{
+		"docs/archive/",
+		"docs/archive/a(1).txt",
+		"docs/archive/a.txt",
+		"docs/archive/photos/",
+		"docs/archive/photos/1.jpg",
+		"docs/archive/photos/2024/2.jpg",
+	}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, want) {
+		t.Errorf("移动后的对象 = %v, 期望 %v", got, want)
+	}
+}
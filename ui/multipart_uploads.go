@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// multipartUploadsSummary 返回未完成分段上传的数量、分段总数和可回收的空间
+func multipartUploadsSummary(uploads []s3client.MultipartUpload) string {
+	parts := 0
+	var size int64
+	for _, u := range uploads {
+		parts += u.Parts
+		size += u.Size
+	}
+	return fmt.Sprintf(T("共 %d 个未完成的分段上传，%d 个分段，可回收 %s"), len(uploads), parts, formatBytes(size))
+}
+
+// showMultipartUploadsDialog 列出存储桶中未完成的分段上传，用户可以选择并中止其中的上传以回收空间
+func (bv *BucketsView) showMultipartUploadsDialog(bucket string) {
+	client := bv.S3Client
+	if client == nil {
+		return
+	}
+
+	statusLabel := widget.NewLabel(T("正在列出未完成的分段上传..."))
+	rows := container.NewVBox()
+	selected := make(map[int]bool)
+	var uploads []s3client.MultipartUpload
+
+	abortButton := widget.NewButton(T("中止选中的上传"), nil)
+	abortButton.Importance = widget.DangerImportance
+	abortButton.Disable()
+	updateAbortButton := func() {
+		if len(selected) > 0 {
+			abortButton.Enable()
+		} else {
+			abortButton.Disable()
+		}
+	}
+
+	content := container.NewBorder(statusLabel, container.NewHBox(abortButton), nil, nil, container.NewVScroll(rows))
+	d := dialog.NewCustom(fmt.Sprintf(T("清理未完成的分段上传 - %s"), bucket), T("关闭"), content, bv.window)
+	d.Resize(fyne.NewSize(600, 450))
+	d.Show()
+
+	var load func()
+	load = func() {
+		rows.RemoveAll()
+		statusLabel.SetText(T("正在列出未完成的分段上传..."))
+		for i := range selected {
+			delete(selected, i)
+		}
+		updateAbortButton()
+		go func() {
+			result, err := client.ListMultipartUploads(bucket)
+			fyne.Do(func() {
+				if err != nil {
+					statusLabel.SetText(fmt.Sprintf(T("列出分段上传失败: %v"), err))
+					return
+				}
+				uploads = result
+				statusLabel.SetText(multipartUploadsSummary(uploads))
+				for i, u := range uploads {
+					i := i
+					initiated := "-"
+					if !u.Initiated.IsZero() {
+						initiated = u.Initiated.Local().Format("2006-01-02 15:04:05")
+					}
+					check := widget.NewCheck(fmt.Sprintf(T("%s（开始于 %s，%d 个分段，%s）"), u.Key, initiated, u.Parts, formatBytes(u.Size)), func(checked bool) {
+						if checked {
+							selected[i] = true
+						} else {
+							delete(selected, i)
+						}
+						updateAbortButton()
+					})
+					rows.Add(check)
+				}
+			})
+		}()
+	}
+
+	abortButton.OnTapped = func() {
+		var targets []s3client.MultipartUpload
+		for i := range uploads {
+			if selected[i] {
+				targets = append(targets, uploads[i])
+			}
+		}
+		message := fmt.Sprintf(T("确定要中止选中的 %d 个分段上传吗？已上传的分段将被删除且无法恢复。"), len(targets))
+		dialog.ShowConfirm(T("中止分段上传"), message, func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			abortButton.Disable()
+			statusLabel.SetText(T("正在中止分段上传..."))
+			go func() {
+				var failed []string
+				for _, u := range targets {
+					if err := client.AbortMultipartUpload(bucket, u.Key, u.UploadID); err != nil {
+						log.Printf("中止分段上传 '%s' 失败: %v", u.Key, err)
+						failed = append(failed, u.Key)
+					}
+				}
+				fyne.Do(func() {
+					if len(failed) > 0 {
+						dialog.ShowError(fmt.Errorf(T("%d 个分段上传中止失败，例如: %s"), len(failed), failed[0]), bv.window)
+					} else {
+						ShowToast(bv.window, fmt.Sprintf(T("已中止 %d 个分段上传"), len(targets)))
+					}
+					load()
+				})
+			}()
+		}, bv.window)
+	}
+
+	load()
+}
@@ -0,0 +1,18 @@
+package ui
+
+import (
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestMultipartUploadsSummary(t *testing.T) {
+	uploads := []s3client.MultipartUpload{
+		{Key: "a.bin", Parts: 2, Size: 2048},
+		{Key: "b.bin", Parts: 1, Size: 1024},
+	}
+	want := "共 2 个未完成的分段上传，3 个分段，可回收 " + formatBytes(3072)
+	if got := multipartUploadsSummary(uploads); got != want {
+		t.Errorf("multipartUploadsSummary = %q, 期望 %q", got, want)
+	}
+}
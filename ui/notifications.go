@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxNotices 是通知栏保留的最大通知数量，超过时丢弃最早的通知
+const maxNotices = 20
+
+// maxNoticeDetails 是单条通知保留的最大详情条数
+const maxNoticeDetails = 100
+
+// notice 是通知栏中的一条通知，相同消息的连续通知合并为一条，详情依次追加
+type notice struct {
+	message string
+	details []string
+	count   int // 合并的通知次数
+}
+
+// addNotice 将一条通知加入列表。与最后一条通知的消息相同时合并，否则追加为新通知。
+func addNotice(notices []notice, message, detail string) []notice {
+	if n := len(notices); n > 0 && notices[n-1].message == message {
+		last := &notices[n-1]
+		last.count++
+		if detail != "" && len(last.details) < maxNoticeDetails {
+			last.details = append(last.details, detail)
+		}
+		return notices
+	}
+	n := notice{message: message, count: 1}
+	if detail != "" {
+		n.details = []string{detail}
+	}
+	notices = append(notices, n)
+	if len(notices) > maxNotices {
+		notices = notices[len(notices)-maxNotices:]
+	}
+	return notices
+}
+
+// NotificationBar 是窗口底部的通知栏，用于显示后台任务的非致命问题（如缩略图加载失败），
+// 不像对话框那样打断用户操作。与 ShowToast 不同，通知会一直保留到用户关闭。
+type NotificationBar struct {
+	window  fyne.Window
+	notices []notice
+	label   *widget.Label
+	content *fyne.Container
+}
+
+// notificationBar 是 Notify 使用的通知栏，由 NewNotificationBar 设置
+var notificationBar *NotificationBar
+
+// NewNotificationBar 创建通知栏，之后 Notify 发出的通知显示在其中
+func NewNotificationBar(window fyne.Window) *NotificationBar {
+	b := &NotificationBar{window: window, label: widget.NewLabel("")}
+	b.label.Truncation = fyne.TextTruncateEllipsis
+
+	detailsButton := widget.NewButton(T("详情"), b.showDetails)
+	detailsButton.Importance = widget.LowImportance
+	closeButton := widget.NewButtonWithIcon("", theme.CancelIcon(), b.clear)
+	closeButton.Importance = widget.LowImportance
+
+	b.content = container.NewVBox(
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, widget.NewIcon(theme.WarningIcon()), container.NewHBox(detailsButton, closeButton), b.label),
+	)
+	b.content.Hide()
+	notificationBar = b
+	return b
+}
+
+// GetContent 返回通知栏的界面，没有通知时隐藏
+func (b *NotificationBar) GetContent() fyne.CanvasObject {
+	return b.content
+}
+
+// Notify 在通知栏中显示一条非致命的通知，detail 可以为空，用户点击“详情”时查看。
+// 可以在任意 goroutine 中调用；尚未创建通知栏时只写入日志。
+func Notify(message, detail string) {
+	b := notificationBar
+	if b == nil {
+		log.Printf("%s: %s", message, detail)
+		return
+	}
+	fyne.Do(func() {
+		b.notices = addNotice(b.notices, message, detail)
+		b.refresh()
+	})
+}
+
+// refresh 根据当前的通知更新通知栏文字和可见性
+func (b *NotificationBar) refresh() {
+	if len(b.notices) == 0 {
+		b.content.Hide()
+		return
+	}
+	last := b.notices[len(b.notices)-1]
+	text := last.message
+	if last.count > 1 {
+		text = fmt.Sprintf(T("%s（%d 次）"), text, last.count)
+	}
+	if len(b.notices) > 1 {
+		text += "  " + fmt.Sprintf(T("另有 %d 条通知"), len(b.notices)-1)
+	}
+	b.label.SetText(text)
+	b.content.Show()
+}
+
+// clear 关闭通知栏并清除所有通知
+func (b *NotificationBar) clear() {
+	b.notices = nil
+	b.refresh()
+}
+
+// showDetails 在对话框中显示所有通知及其详情
+func (b *NotificationBar) showDetails() {
+	var sb strings.Builder
+	for i := len(b.notices) - 1; i >= 0; i-- {
+		n := b.notices[i]
+		sb.WriteString(n.message)
+		sb.WriteString("\n")
+		for _, detail := range n.details {
+			sb.WriteString("  " + detail + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	text := widget.NewLabel(strings.TrimSpace(sb.String()))
+	text.Wrapping = fyne.TextWrapWord
+
+	clearButton := widget.NewButton(T("清除全部"), nil)
+	d := dialog.NewCustom(T("通知"), T("关闭"), container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), clearButton), nil, nil, container.NewVScroll(text)), b.window)
+	clearButton.OnTapped = func() {
+		b.clear()
+		d.Hide()
+	}
+	d.Resize(fyne.NewSize(600, 400))
+	d.Show()
+}
@@ -0,0 +1,27 @@
+package ui
+
+import "testing"
+
+func TestAddNoticeMergesRepeatedMessages(t *testing.T) {
+	var notices []notice
+	notices = addNotice(notices, "部分缩略图加载失败", "a.png: 超时")
+	notices = addNotice(notices, "部分缩略图加载失败", "b.png: 超时")
+	notices = addNotice(notices, "统计失败", "")
+
+	if len(notices) != 2 {
+		t.Fatalf("期望 2 条通知，实际 %d 条", len(notices))
+	}
+	if notices[0].count != 2 || len(notices[0].details) != 2 || notices[0].details[1] != "b.png: 超时" {
+		t.Errorf("相同消息的通知应合并，实际为 %+v", notices[0])
+	}
+	if notices[1].details != nil {
+		t.Errorf("没有详情的通知不应记录空详情，实际为 %v", notices[1].details)
+	}
+
+	for i := 0; i < maxNotices+5; i++ {
+		notices = addNotice(notices, string(rune('a'+i%2)), "")
+	}
+	if len(notices) != maxNotices {
+		t.Errorf("通知数量应限制为 %d，实际 %d", maxNotices, len(notices))
+	}
+}
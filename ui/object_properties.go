@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// objectPropertyLines 将 HeadObject 返回的对象信息整理为“名称: 值”的文本行，空值不显示
+func objectPropertyLines(key string, info *s3client.ObjectInfo) []string {
+	storageClass := info.StorageClass
+	if storageClass == "" {
+		storageClass = "STANDARD" // HeadObject 对标准存储类别不返回该字段
+	}
+	lines := []string{
+		fmt.Sprintf("%s: %s", T("键"), key),
+		fmt.Sprintf("%s: %s (%s)", T("大小"), formatBytes(info.Size), fmt.Sprintf(T("%d 字节"), info.Size)),
+		fmt.Sprintf("%s: %s", T("最后修改时间"), info.LastModified.Local().Format("2006-01-02 15:04:05")),
+		fmt.Sprintf("ETag: %s", info.ETag),
+		fmt.Sprintf("%s: %s", T("存储类别"), storageClass),
+	}
+	headers := []struct{ name, value string }{
+		{"Content-Type", info.ContentType},
+		{"Cache-Control", info.CacheControl},
+		{"Content-Disposition", info.ContentDisposition},
+		{"Content-Encoding", info.ContentEncoding},
+		{"Content-Language", info.ContentLanguage},
+	}
+	for _, h := range headers {
+		if h.value != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", h.name, h.value))
+		}
+	}
+
+	// 用户元数据按名称排序，使每次显示的顺序一致
+	names := make([]string, 0, len(info.Metadata))
+	for name := range info.Metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("x-amz-meta-%s: %s", name, info.Metadata[name]))
+	}
+	return lines
+}
+
+// folderPropertyLines 汇总文件夹下所有文件的数量和总大小
+func folderPropertyLines(prefix string, objects []s3client.S3Object) []string {
+	var total int64
+	for _, obj := range objects {
+		total += obj.Size
+	}
+	return []string{
+		fmt.Sprintf("%s: %s", T("前缀"), prefix),
+		fmt.Sprintf("%s: %d", T("文件数"), len(objects)),
+		fmt.Sprintf("%s: %s (%s)", T("总大小"), formatBytes(total), fmt.Sprintf(T("%d 字节"), total)),
+	}
+}
+
+// showPropertiesDialog 在后台获取对象的属性后显示在可复制的文本框中。
+// 文件显示 HeadObject 返回的信息，文件夹显示其下所有文件的数量和总大小。
+func (ov *ObjectsView) showPropertiesDialog(obj s3client.S3Object) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	client, bucket := ov.s3Client, ov.currentBucket
+	ov.loadingIndicator.Show()
+
+	go func() {
+		var lines []string
+		var err error
+		var tags map[string]string
+		var tagsErr error
+		if obj.IsFolder {
+			var objects []s3client.S3Object
+			if objects, err = client.ListAllObjectsFlat(bucket, obj.Key); err == nil {
+				lines = folderPropertyLines(obj.Key, objects)
+			}
+		} else {
+			var info *s3client.ObjectInfo
+			if info, err = client.StatObject(bucket, obj.Key); err == nil {
+				lines = objectPropertyLines(obj.Key, info)
+				tags, tagsErr = client.GetObjectTags(bucket, obj.Key)
+			}
+		}
+
+		fyne.Do(func() {
+			ov.loadingIndicator.Hide()
+			if err != nil {
+				log.Printf("获取 '%s' 的属性失败: %v", obj.Key, err)
+				dialog.ShowError(fmt.Errorf(T("获取属性失败: %v"), err), ov.window)
+				return
+			}
+			entry := widget.NewMultiLineEntry()
+			entry.SetText(strings.Join(lines, "\n"))
+			entry.Wrapping = fyne.TextWrapBreak
+
+			// 文件下方显示标签编辑区域；服务端不支持对象标签时不显示
+			var content fyne.CanvasObject = entry
+			size := fyne.NewSize(520, 360)
+			if !obj.IsFolder && !s3client.IsNotImplementedError(tagsErr) {
+				var tagsSection fyne.CanvasObject
+				if tagsErr != nil {
+					log.Printf("获取 '%s' 的标签失败: %v", obj.Key, tagsErr)
+					tagsSection = widget.NewLabel(fmt.Sprintf(T("无法读取标签: %v"), tagsErr))
+				} else {
+					tagsSection = ov.newTagsEditor(client, bucket, obj.Key, tags)
+				}
+				split := container.NewVSplit(entry, container.NewVScroll(tagsSection))
+				split.SetOffset(0.45)
+				content = split
+				size = fyne.NewSize(560, 560)
+			}
+			d := dialog.NewCustom(fmt.Sprintf(T("属性 - %s"), obj.Name), T("关闭"), content, ov.window)
+			d.Resize(size)
+			d.Show()
+		})
+	}()
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"s3-explorer/s3client"
+)
+
+func TestObjectPropertyLines(t *testing.T) {
+	modified := time.Date(2024, 5, 6, 7, 8, 9, 0, time.Local)
+	info := &s3client.ObjectInfo{
+		Size:         2048,
+		ETag:         "abc123",
+		ContentType:  "text/plain",
+		LastModified: modified,
+		Metadata:     map[string]string{"owner": "alice", "author": "bob"},
+	}
+	got := objectPropertyLines("docs/a.txt", info)
+	want := []string{
+		"键: docs/a.txt",
+		"大小: 2.0 KB (2048 字节)",
+		"最后修改时间: 2024-05-06 07:08:09",
+		"ETag: abc123",
+		"存储类别: STANDARD",
+		"Content-Type: text/plain",
+		"x-amz-meta-author: bob",
+		"x-amz-meta-owner: alice",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("objectPropertyLines() = %q, 期望 %q", got, want)
+	}
+}
+
+func TestFolderPropertyLines(t *testing.T) {
+	objects := []s3client.S3Object{{Key: "docs/a.txt", Size: 1000}, {Key: "docs/sub/b.txt", Size: 24}}
+	got := folderPropertyLines("docs/", objects)
+	want := []string{"前缀: docs/", "文件数: 2", "总大小: 1.0 KB (1024 字节)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("folderPropertyLines() = %q, 期望 %q", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// sortColumns 是排序栏中可选的排序字段
+var sortColumns = []struct {
+	label string
+	field s3client.SortField
+}{
+	{"名称", s3client.SortByName},
+	{"大小", s3client.SortBySize},
+	{"修改时间", s3client.SortByModified},
+}
+
+// sortOrder 返回当前对象列表的排序方式
+func (ov *ObjectsView) sortOrder() s3client.SortOrder {
+	return s3client.SortOrder{Field: ov.sortField, Descending: ov.sortDescending, FoldersFirst: ov.foldersFirst}
+}
+
+// SetSortOrder 设置对象列表的排序字段和方向，在下次加载对象列表时生效。无效的字段按名称排序。
+func (ov *ObjectsView) SetSortOrder(field string, descending bool) {
+	ov.sortField = s3client.SortByName
+	for _, c := range sortColumns {
+		if string(c.field) == field {
+			ov.sortField = c.field
+		}
+	}
+	ov.sortDescending = descending
+}
+
+// toggleSort 按 field 排序当前列表：点击当前排序字段时切换升序/降序，点击其它字段时按该字段升序排序。
+// 分页时只对当前页排序，服务端总是按对象键的字典序分页。
+func (ov *ObjectsView) toggleSort(field s3client.SortField) {
+	if ov.sortField == field {
+		ov.sortDescending = !ov.sortDescending
+	} else {
+		ov.sortField = field
+		ov.sortDescending = false
+	}
+	if ov.OnSortChanged != nil && ov.currentServiceAlias != "" {
+		ov.OnSortChanged(ov.currentServiceAlias, string(ov.sortField), ov.sortDescending)
+	}
+	s3client.SortObjectsBy(ov.objects, ov.sortOrder())
+	ov.filterObjects(ov.searchText())
+}
+
+// newSortHeader 创建对象列表上方的排序栏，当前排序字段显示升序或降序箭头
+func (ov *ObjectsView) newSortHeader() fyne.CanvasObject {
+	header := container.NewHBox(widget.NewLabel(T("排序:")))
+	for _, c := range sortColumns {
+		field := c.field
+		button := widget.NewButton(T(c.label), func() { ov.toggleSort(field) })
+		button.Importance = widget.LowImportance
+		if field == ov.sortField {
+			button.Importance = widget.MediumImportance
+			button.Icon = theme.MoveUpIcon()
+			if ov.sortDescending {
+				button.Icon = theme.MoveDownIcon()
+			}
+			button.IconPlacement = widget.ButtonIconTrailingText
+		}
+		header.Add(button)
+	}
+	return header
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestToggleSort(t *testing.T) {
+	ov := newTestObjectsView(newMemStore(), "")
+	ov.currentServiceAlias = "minio"
+	ov.foldersFirst = true
+	ov.SetSortOrder("", false)
+	ov.objects = []s3client.S3Object{
+		{Name: "a.txt", Size: 30},
+		{Name: "b.txt", Size: 10},
+		{Name: "docs", IsFolder: true},
+		{Name: "c.txt", Size: 20},
+	}
+	var saved []string
+	ov.OnSortChanged = func(alias, field string, descending bool) {
+		if descending {
+			field += " desc"
+		}
+		saved = append(saved, field)
+	}
+	names := func() []string {
+		var result []string
+		for _, obj := range ov.getDisplayedObjects() {
+			result = append(result, obj.Name)
+		}
+		return result
+	}
+
+	ov.toggleSort(s3client.SortBySize)
+	if want := []string{"docs", "b.txt", "c.txt", "a.txt"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("按大小升序 = %v, 期望 %v", names(), want)
+	}
+	ov.toggleSort(s3client.SortBySize)
+	if want := []string{"docs", "a.txt", "c.txt", "b.txt"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("按大小降序 = %v, 期望 %v", names(), want)
+	}
+	// 切换到其它字段时从升序开始
+	ov.toggleSort(s3client.SortByName)
+	if want := []string{"docs", "a.txt", "b.txt", "c.txt"}; !reflect.DeepEqual(names(), want) {
+		t.Errorf("按名称升序 = %v, 期望 %v", names(), want)
+	}
+	if want := []string{"size", "size desc", "name"}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("保存的排序 = %v, 期望 %v", saved, want)
+	}
+
+	ov.SetSortOrder("unknown", true)
+	if ov.sortField != s3client.SortByName || !ov.sortDescending {
+		t.Errorf("无效字段应按名称排序，实际为 %q", ov.sortField)
+	}
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"s3-explorer/s3client"
+)
+
+// ObjectStore 是对象视图使用的存储操作集合，由 *s3client.S3Client 实现。
+// 对象视图只依赖此接口，测试中可以替换为内存实现，无需连接真实的 S3 服务。
+type ObjectStore interface {
+	ListObjects(bucketName, prefix, marker string, pageSize int32) ([]s3client.S3Object, *string, error)
+	ListAllObjectsUnderPrefix(bucketName, prefix string) ([]s3client.S3Object, error)
+	ListAllObjectsFlat(bucketName, prefix string) ([]s3client.S3Object, error)
+	ListAllKeysUnderPrefix(bucketName, prefix string) ([]string, error)
+	WalkObjects(ctx context.Context, bucketName, prefix string, fn func(obj s3client.S3Object) error) error
+	SearchObjects(ctx context.Context, bucketName, prefix, substring string) ([]s3client.S3Object, bool, error)
+	CountObjectsUnderPrefix(bucketName, prefix string) (int, error)
+
+	UploadObject(ctx context.Context, bucketName, key string, reader io.Reader, size int64, opts s3client.UploadOptions) error
+	UploadLargeObject(ctx context.Context, bucketName, key string, reader io.Reader, size int64, opts s3client.UploadOptions) error
+	DownloadObject(ctx context.Context, bucketName, key string) (io.ReadCloser, error)
+	DownloadObjectRange(ctx context.Context, bucketName, key string, start, end int64) (io.ReadCloser, error)
+	StatObject(bucketName, key string) (*s3client.ObjectInfo, error)
+	ObjectExists(bucketName, key string) (bool, error)
+	CopyObject(bucketName, sourceKey, targetKey string) error
+	DeleteObject(ctx context.Context, bucketName, key string) error
+	DeleteObjects(ctx context.Context, bucketName string, keys []string) ([]string, error)
+	CreateFolder(bucketName, key string) error
+	GetObjectTags(bucketName, key string) (map[string]string, error)
+	PutObjectTags(bucketName, key string, tags map[string]string) error
+
+	SetFoldersFirst(foldersFirst bool)
+	PublicObjectURL(bucketName, key string) string
+	PresignGetObject(bucketName, key string, expiry time.Duration) (string, error)
+	ConsoleURL(bucketName, prefix string) (string, error)
+}
+
+var _ ObjectStore = (*s3client.S3Client)(nil)
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// tagRow 是标签编辑器中的一行
+type tagRow struct {
+	key, value string
+}
+
+// tagsFromRows 将编辑器中的行转换为标签，忽略键和值都为空的行。
+// 键为空、键重复、超过长度限制或标签数量超过 S3 的限制时返回错误。
+func tagsFromRows(rows []tagRow) (map[string]string, error) {
+	tags := make(map[string]string, len(rows))
+	for _, row := range rows {
+		key, value := strings.TrimSpace(row.key), strings.TrimSpace(row.value)
+		switch {
+		case key == "" && value == "":
+			continue
+		case key == "":
+			return nil, errors.New(T("标签键不能为空"))
+		case len([]rune(key)) > maxTagKeyLength:
+			return nil, fmt.Errorf(T("标签键 %q 超过 %d 个字符"), key, maxTagKeyLength)
+		case len([]rune(value)) > maxTagValueLength:
+			return nil, fmt.Errorf(T("标签 %q 的值超过 %d 个字符"), key, maxTagValueLength)
+		}
+		if _, ok := tags[key]; ok {
+			return nil, fmt.Errorf(T("标签 %q 重复"), key)
+		}
+		tags[key] = value
+	}
+	if len(tags) > s3client.MaxObjectTags {
+		return nil, fmt.Errorf(T("每个对象最多只能设置 %d 个标签"), s3client.MaxObjectTags)
+	}
+	return tags, nil
+}
+
+// sortedTagRows 将标签按键排序转换为编辑器的行，使每次显示的顺序一致
+func sortedTagRows(tags map[string]string) []tagRow {
+	rows := make([]tagRow, 0, len(tags))
+	for k, v := range tags {
+		rows = append(rows, tagRow{key: k, value: v})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].key < rows[j].key })
+	return rows
+}
+
+// tagRowWidgets 是标签编辑器中一行的输入框
+type tagRowWidgets struct {
+	keyEntry, valueEntry *widget.Entry
+}
+
+// newTagsEditor 创建对象标签的编辑区域：每行一个键值对，可以添加、删除行，点击保存后替换对象的全部标签
+func (ov *ObjectsView) newTagsEditor(client ObjectStore, bucket, key string, tags map[string]string) fyne.CanvasObject {
+	var rows []*tagRowWidgets
+	rowsBox := container.NewVBox()
+	addButton := widget.NewButtonWithIcon(T("添加标签"), theme.ContentAddIcon(), nil)
+
+	var refresh func()
+	refresh = func() {
+		objects := make([]fyne.CanvasObject, 0, len(rows))
+		for _, row := range rows {
+			removeButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+				rows = slices.DeleteFunc(rows, func(r *tagRowWidgets) bool { return r == row })
+				refresh()
+			})
+			objects = append(objects, container.NewBorder(nil, nil, nil, removeButton,
+				container.NewGridWithColumns(2, row.keyEntry, row.valueEntry)))
+		}
+		rowsBox.Objects = objects
+		rowsBox.Refresh()
+		if len(rows) >= s3client.MaxObjectTags {
+			addButton.Disable()
+		} else {
+			addButton.Enable()
+		}
+	}
+	addRow := func(r tagRow) {
+		keyEntry := widget.NewEntry()
+		keyEntry.SetPlaceHolder(T("键"))
+		keyEntry.SetText(r.key)
+		valueEntry := widget.NewEntry()
+		valueEntry.SetPlaceHolder(T("值"))
+		valueEntry.SetText(r.value)
+		rows = append(rows, &tagRowWidgets{keyEntry: keyEntry, valueEntry: valueEntry})
+	}
+	for _, r := range sortedTagRows(tags) {
+		addRow(r)
+	}
+	addButton.OnTapped = func() {
+		addRow(tagRow{})
+		refresh()
+	}
+
+	saveButton := widget.NewButtonWithIcon(T("保存标签"), theme.DocumentSaveIcon(), nil)
+	saveButton.OnTapped = func() {
+		current := make([]tagRow, 0, len(rows))
+		for _, row := range rows {
+			current = append(current, tagRow{key: row.keyEntry.Text, value: row.valueEntry.Text})
+		}
+		newTags, err := tagsFromRows(current)
+		if err != nil {
+			dialog.ShowError(err, ov.window)
+			return
+		}
+		saveButton.Disable()
+		go func() {
+			err := client.PutObjectTags(bucket, key, newTags)
+			fyne.Do(func() {
+				saveButton.Enable()
+				if err != nil {
+					log.Printf("保存 '%s' 的标签失败: %v", key, err)
+					dialog.ShowError(fmt.Errorf(T("保存标签失败: %v"), err), ov.window)
+					return
+				}
+				ShowToast(ov.window, T("标签已保存。"))
+			})
+		}()
+	}
+	refresh()
+
+	return container.NewVBox(
+		widget.NewLabelWithStyle(T("标签"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		rowsBox,
+		container.NewHBox(addButton, layout.NewSpacer(), saveButton),
+	)
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTagsFromRows(t *testing.T) {
+	got, err := tagsFromRows([]tagRow{{" project ", " alpha "}, {"", ""}, {"empty", ""}})
+	if err != nil {
+		t.Fatalf("tagsFromRows() 返回错误: %v", err)
+	}
+	if want := map[string]string{"project": "alpha", "empty": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tagsFromRows() = %v, 期望 %v", got, want)
+	}
+
+	invalid := [][]tagRow{
+		{{"", "value"}},
+		{{"a", "1"}, {"a", "2"}},
+		{{strings.Repeat("k", maxTagKeyLength+1), ""}},
+		{{"a", strings.Repeat("v", maxTagValueLength+1)}},
+	}
+	var tooMany []tagRow
+	for i := 0; i < 11; i++ {
+		tooMany = append(tooMany, tagRow{key: string(rune('a' + i))})
+	}
+	invalid = append(invalid, tooMany)
+	for _, rows := range invalid {
+		if _, err := tagsFromRows(rows); err == nil {
+			t.Errorf("tagsFromRows(%v) 期望返回错误", rows)
+		}
+	}
+}
+
+func TestSortedTagRows(t *testing.T) {
+	got := sortedTagRows(map[string]string{"b": "2", "a": "1"})
+	if want := []tagRow{{"a", "1"}, {"b", "2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedTagRows() = %v, 期望 %v", got, want)
+	}
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -14,10 +16,7 @@ import (
 	"log"
 	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -35,6 +34,7 @@ import (
 	"github.com/nfnt/resize"
 
 	"s3-explorer/common"
+	"s3-explorer/config"
 	"s3-explorer/s3client"
 )
 
@@ -56,8 +56,25 @@ var (
 const (
 	listViewMode = "list"
 	gridViewMode = "grid"
+
+	// maxEditableTextSize 允许在预览窗口中编辑的文本文件大小上限
+	maxEditableTextSize = 1 << 20
+
+	// rangeDownloadThreshold 超过该大小的文件使用多线程范围下载
+	rangeDownloadThreshold = 64 << 20
+	// rangeDownloadPartSize 范围下载时每个分段的大小
+	rangeDownloadPartSize = 16 << 20
 )
 
+// rangeDownloadWorkers 单个大文件范围下载时的并发数，由设置中的传输并发数决定
+var rangeDownloadWorkers = rangeWorkersFor(defaultTransferConcurrency)
+
+// transferWorkers 批量上传、下载和删除时同时处理的文件数量，由设置中的传输并发数决定
+var transferWorkers = defaultTransferConcurrency
+
+// maxScanWorkers 下载前扫描选中项目时的最大并发数，传输并发数更小时使用传输并发数
+const maxScanWorkers = 5
+
 // thumbnailResource 实现了 fyne.Resource 接口，用于将 image.Image 包装成资源
 type thumbnailResource struct {
 	name string
@@ -84,42 +101,102 @@ func (t *thumbnailResource) Content() []byte {
 // ObjectsView 结构体用于管理右侧的文件/文件夹列表视图
 type ObjectsView struct {
 	window              fyne.Window
-	s3Client            *s3client.S3Client
+	s3Client            ObjectStore
 	currentBucket       string
 	currentPrefix       string
 	objects             []s3client.S3Object
 	filteredObjects     []s3client.S3Object // 用于存储过滤后的对象
+	loadErr             error               // 最近一次列出对象失败的错误，成功加载后清空，用于显示错误占位
 	objectList          *widget.List
+	busyOperation       string             // 正在运行的耗时操作名称，为空表示空闲
+	transfers           *TransferManager   // 后台进行的上传和下载，显示在对象列表下方的传输面板中
+	connecting          bool               // 正在创建服务的客户端，完成前禁用工具栏
+	toolbarButtons      []fyne.Disableable // 依赖当前服务的工具栏按钮，连接服务期间禁用
+	listEntries         []*listEntry       // 列表创建过的条目，列表会复用条目，用于拖放时的命中测试
 	breadcrumbContainer *fyne.Container
 	selectedObjectIDs   map[widget.ListItemID]struct{}
 	lastSelectedID      widget.ListItemID
+	keyboardCursor      widget.ListItemID // 方向键移动到的当前项，Shift+方向键从 lastSelectedID 扩展到此处
 	loadingIndicator    *ThinProgressBar
 	downloadButton      *widget.Button
 	deleteButton        *widget.Button
+	refreshButton       *widget.Button // 重新加载当前目录，加载期间禁用
+	upButton            *widget.Button // 返回上一级目录，位于存储桶根目录时禁用
 	serviceInfoButton   *widget.Button
-	searchEntry         *widget.Entry // 搜索框
+	searchEntry         *widget.Entry   // 搜索框
+	searchBucketCheck   *widget.Check   // 是否在整个存储桶中搜索
+	typeFilters         map[string]bool // 选中的类型筛选分类，为空时不按类型筛选
 
 	// 分页相关状态
 	currentPage    int
 	pageSize       int
-	pageMarkers    []string
+	pageMarkers    []string // 每页起始的 ContinuationToken，由服务端返回，第一页为空字符串
 	nextPageMarker *string
 	prevButton     *widget.Button
 	nextButton     *widget.Button
 	pageInfoLabel  *widget.Label
 	pageSizeEntry  *minWidthEntry
 
+	// 在视图内拖动项目到文件夹
+	dragObjects   []s3client.S3Object // 正在拖动的项目，nil 表示没有拖动
+	dropFolder    *s3client.S3Object  // 光标下可以接收拖动项目的文件夹
+	dropHighlight dropHighlighter     // 当前高亮显示的文件夹条目
+
+	// 在整个存储桶中递归搜索
+	recursiveSearch  bool
+	searchResults    []s3client.S3Object // 递归搜索的结果，nil 表示没有可用的结果
+	searchedTerm     string              // searchResults 对应的搜索词
+	searchedBucket   string              // searchResults 对应的存储桶
+	searchGeneration int                 // 每次开始或取消搜索时递增，用于丢弃过期的搜索结果
+	searchTimer      *time.Timer
+	searchCancel     context.CancelFunc
+
 	// 视图切换
 	viewMode            string
 	viewSwitchButton    *widget.Button
+	flatView            bool // 平铺视图：不按文件夹分组，显示前缀下的全部文件
+	flatViewCheck       *widget.Check
+	foldersFirst        bool // 排序时文件夹是否排在文件前面
+	foldersFirstCheck   *widget.Check
+	uploadStorageClass  string             // 上传时使用的存储类别，为空时使用标准存储
+	sortField           s3client.SortField // 对象列表的排序字段
+	sortDescending      bool               // 对象列表是否降序排序
+	showFolderCounts    bool               // 是否显示文件夹中的对象数量
+	showRelativeTime    bool               // 是否以相对时间显示修改时间
+	thumbnailsEnabled   bool               // 是否为图片加载缩略图
+	thumbnailCancel     context.CancelFunc // 取消正在进行的缩略图加载
+	folderCounts        map[string]int     // 文件夹键 -> 对象数量，-1 表示统计失败
+	folderCountPending  map[string]bool
+	folderCountGen      int // 每次重新加载列表时递增，用于丢弃过期的统计结果
+	loadGeneration      int // 每次调用 loadObjects 时递增，只应用最新一次加载的结果
 	mainContent         *fyne.Container
 	currentServiceAlias string
+	recentLocations     []quickOpenLocation // 本次运行中最近访问的位置，最新的在前
 
 	// 动画管理器
 	animationManager *AnimationManager
 
+	// hasServices 表示是否已配置任何服务，用于显示引导占位内容
+	hasServices bool
+
 	// OnViewModeChanged 是一个回调函数，当视图模式改变时触发
 	OnViewModeChanged func(alias, newMode string)
+	// OnPageSizeChanged 在用户修改每页显示数量时触发，用于保存到服务配置
+	OnPageSizeChanged func(alias string, pageSize int)
+	// OnFoldersFirstChanged 在用户切换"文件夹优先"时触发，用于保存到服务配置
+	OnFoldersFirstChanged func(alias string, foldersFirst bool)
+	// OnUploadStorageClassChanged 在用户修改上传使用的存储类别时触发，用于保存到服务配置
+	OnUploadStorageClassChanged func(alias, storageClass string)
+	// OnSortChanged 在用户修改排序字段或方向时触发，用于保存到服务配置
+	OnSortChanged func(alias, field string, descending bool)
+	// OnLocationOpened 在用户打开书签或快速打开的位置时触发，用于选中对应的存储桶并跳转到该位置
+	OnLocationOpened func(bucket, prefix string)
+	// QuickOpenBuckets 返回当前服务的存储桶列表，供快速打开搜索
+	QuickOpenBuckets func() []string
+	// OnAddServiceRequested 在用户点击空状态中的“添加服务”按钮时触发
+	OnAddServiceRequested func()
+	// OnCredentialsExpired 在操作因临时凭证过期失败时触发，重新认证成功后应调用 retry 重试
+	OnCredentialsExpired func(retry func())
 }
 
 // NewObjectsView 创建并返回一个新的 ObjectsView 实例
@@ -129,19 +206,29 @@ func NewObjectsView(w fyne.Window, am *AnimationManager) *ObjectsView { // 修
 		animationManager:  am, // 初始化动画管理器
 		selectedObjectIDs: make(map[widget.ListItemID]struct{}),
 		lastSelectedID:    -1,
+		keyboardCursor:    -1,
 		loadingIndicator:  NewThinProgressBar(),
-		serviceInfoButton: widget.NewButton("未选择服务", func() {}),
+		transfers:         NewTransferManager(w),
+		serviceInfoButton: widget.NewButton(T("未选择服务"), func() {}),
 		currentPage:       1,
-		pageSize:          100, // 0 表示不限制
+		pageSize:          config.DefaultPageSize, // 0 表示不限制
 		pageMarkers:       []string{""},
 		viewMode:          listViewMode, // 默认是列表视图
-	}
+		hasServices:       true,
+		showFolderCounts:  showFolderCountsSetting(),
+		showRelativeTime:  showRelativeTimeSetting(),
+		thumbnailsEnabled: loadThumbnailsSetting(),
+		foldersFirst:      true,
+		sortField:         s3client.SortByName,
+		typeFilters:       make(map[string]bool),
+	}
+	ov.resetFolderCounts()
 	ov.serviceInfoButton.Importance = widget.LowImportance
 	ov.serviceInfoButton.Disable()
 	ov.loadingIndicator.Hide()
 
-	ov.window.SetOnDropped(func(_ fyne.Position, uris []fyne.URI) {
-		ov.handleDrop(uris)
+	ov.window.SetOnDropped(func(pos fyne.Position, uris []fyne.URI) {
+		ov.handleDrop(pos, uris)
 	})
 
 	// 注册键盘快捷键处理
@@ -153,6 +240,29 @@ func NewObjectsView(w fyne.Window, am *AnimationManager) *ObjectsView { // 修
 		ov.handlePaste()
 	})
 
+	// Ctrl+P 打开快速打开面板
+	ov.window.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyP, Modifier: fyne.KeyModifierShortcutDefault}, func(shortcut fyne.Shortcut) {
+		ov.showQuickOpen()
+	})
+
+	// Ctrl+Shift+V 切换列表/网格视图
+	ov.window.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyV, Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift}, func(shortcut fyne.Shortcut) {
+		ov.toggleViewMode()
+	})
+
+	// F5 重新加载当前目录
+	ov.window.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyF5}, func(shortcut fyne.Shortcut) {
+		ov.refreshCurrentFolder()
+	})
+
+	// Ctrl+A 选中当前显示的所有项目，输入框获得焦点时由输入框自己处理
+	ov.window.Canvas().AddShortcut(&fyne.ShortcutSelectAll{}, func(shortcut fyne.Shortcut) {
+		ov.selectAllObjects()
+	})
+
+	// Delete 键删除选中的项目，与删除按钮相同；方向键、回车和退格用于键盘浏览
+	ov.window.Canvas().SetOnTypedKey(ov.handleObjectsKey)
+
 	return ov
 }
 
@@ -171,14 +281,72 @@ func (ov *ObjectsView) SetViewMode(mode string) {
 	ov.refreshObjectView()
 }
 
+// SetPageSize 设置每页显示数量（0 表示不分页），在下次加载对象列表时生效
+func (ov *ObjectsView) SetPageSize(pageSize int) {
+	if pageSize < 0 {
+		pageSize = config.DefaultPageSize
+	}
+	ov.pageSize = pageSize
+	if ov.pageSizeEntry != nil {
+		ov.pageSizeEntry.SetText(strconv.Itoa(pageSize))
+	}
+}
+
+// SetFoldersFirst 设置排序时文件夹是否排在文件前面，在下次加载对象列表时生效
+func (ov *ObjectsView) SetFoldersFirst(foldersFirst bool) {
+	ov.foldersFirst = foldersFirst
+	if ov.s3Client != nil {
+		ov.s3Client.SetFoldersFirst(foldersFirst)
+	}
+	if ov.foldersFirstCheck != nil {
+		// 直接修改状态而不调用 SetChecked，避免触发保存和重新加载
+		ov.foldersFirstCheck.Checked = foldersFirst
+		ov.foldersFirstCheck.Refresh()
+	}
+}
+
+// SetHasServices 设置是否已配置服务，未配置时对象区域会显示添加服务的引导
+func (ov *ObjectsView) SetHasServices(hasServices bool) {
+	if ov.hasServices == hasServices {
+		return
+	}
+	ov.hasServices = hasServices
+	ov.refreshObjectView()
+}
+
+// toggleViewMode 在列表和网格视图之间切换，并通知父级保存视图偏好
+func (ov *ObjectsView) toggleViewMode() {
+	if ov.viewSwitchButton == nil {
+		return
+	}
+	if ov.viewMode == listViewMode {
+		ov.viewMode = gridViewMode
+		ov.viewSwitchButton.SetIcon(theme.ListIcon())
+	} else {
+		ov.viewMode = listViewMode
+		ov.viewSwitchButton.SetIcon(theme.GridIcon())
+	}
+
+	// 通过回调通知父级保存视图偏好
+	if ov.OnViewModeChanged != nil && ov.currentServiceAlias != "" {
+		go ov.OnViewModeChanged(ov.currentServiceAlias, ov.viewMode)
+	}
+
+	ov.refreshObjectView()
+}
+
 // SetServiceAlias 设置并显示当前服务的别名
 func (ov *ObjectsView) SetServiceAlias(alias string) {
+	if alias != ov.currentServiceAlias {
+		ov.recentLocations = nil
+		saveLastLocation(alias, "", "")
+	}
 	ov.currentServiceAlias = alias
 	fyne.Do(func() {
 		if alias != "" {
-			ov.serviceInfoButton.SetText(fmt.Sprintf("当前服务: %s", alias))
+			ov.serviceInfoButton.SetText(fmt.Sprintf(T("当前服务: %s"), alias))
 		} else {
-			ov.serviceInfoButton.SetText("未选择服务")
+			ov.serviceInfoButton.SetText(T("未选择服务"))
 		}
 		ov.serviceInfoButton.Refresh()
 		if ov.mainContent != nil {
@@ -225,8 +393,12 @@ type listEntry struct {
 	id widget.ListItemID
 	ov *ObjectsView // 指向父视图的引用
 
+	info      string // 信息栏平时显示的文字
+	hoverInfo string // 鼠标悬停时显示的文字，为空时不切换
+
 	doubleTapped func()
 	selected     bool
+	dropHover    bool // 拖动的项目正悬停在该文件夹上
 }
 
 // listEntryRenderer 自定义渲染器
@@ -258,6 +430,13 @@ func (r *listEntryRenderer) Refresh() {
 	} else {
 		r.background.FillColor = color.Transparent
 	}
+	// 拖动的项目悬停在文件夹上时显示边框，提示松开后移动到该文件夹
+	if r.entry.dropHover {
+		r.background.StrokeColor = theme.PrimaryColor()
+		r.background.StrokeWidth = 2
+	} else {
+		r.background.StrokeWidth = 0
+	}
 	r.background.Refresh()
 	canvas.Refresh(r.entry)
 }
@@ -289,11 +468,29 @@ func (e *listEntry) MouseDown(m *desktop.MouseEvent) {
 
 func (e *listEntry) MouseUp(_ *desktop.MouseEvent) {}
 
+// setInfo 设置信息栏文字，hoverInfo 在鼠标悬停时代替 info 显示
+func (e *listEntry) setInfo(info, hoverInfo string) {
+	e.info, e.hoverInfo = info, hoverInfo
+	e.infoLabel.SetText(info)
+}
+
+func (e *listEntry) MouseIn(_ *desktop.MouseEvent) {
+	if e.hoverInfo != "" {
+		e.infoLabel.SetText(e.hoverInfo)
+	}
+}
+
+func (e *listEntry) MouseMoved(_ *desktop.MouseEvent) {}
+
+func (e *listEntry) MouseOut() {
+	e.infoLabel.SetText(e.info)
+}
+
 func newListEntry(ov *ObjectsView) *listEntry {
 	entry := &listEntry{
 		icon:      widget.NewIcon(theme.FileIcon()),
-		nameLabel: widget.NewLabel("名称"),
-		infoLabel: widget.NewLabel("大小/时间"),
+		nameLabel: widget.NewLabel(T("名称")),
+		infoLabel: widget.NewLabel(T("大小/时间")),
 		ov:        ov,
 	}
 	entry.ExtendBaseWidget(entry)
@@ -332,6 +529,13 @@ type gridEntry struct {
 
 	doubleTapped func()
 	selected     bool
+	dropHover    bool // 拖动的项目正悬停在该文件夹上
+
+	// 鼠标悬停时显示完整名称、大小和修改时间的提示
+	hovered      bool
+	hoverPos     fyne.Position
+	tooltipTimer *time.Timer
+	tooltip      *widget.PopUp
 }
 
 type gridEntryRenderer struct {
@@ -361,6 +565,13 @@ func (r *gridEntryRenderer) Refresh() {
 	} else {
 		r.background.FillColor = color.Transparent
 	}
+	// 拖动的项目悬停在文件夹上时显示边框，提示松开后移动到该文件夹
+	if r.entry.dropHover {
+		r.background.StrokeColor = theme.PrimaryColor()
+		r.background.StrokeWidth = 2
+	} else {
+		r.background.StrokeWidth = 0
+	}
 	r.background.Refresh()
 	canvas.Refresh(r.entry)
 }
@@ -377,12 +588,14 @@ func (e *gridEntry) CreateRenderer() fyne.WidgetRenderer {
 }
 
 func (e *gridEntry) DoubleTapped(_ *fyne.PointEvent) {
+	e.hideTooltip()
 	if e.doubleTapped != nil {
 		e.doubleTapped()
 	}
 }
 
 func (e *gridEntry) MouseDown(m *desktop.MouseEvent) {
+	e.hideTooltip()
 	e.ov.handleItemClick(e.id, m)
 }
 
@@ -390,7 +603,7 @@ func (e *gridEntry) MouseUp(_ *desktop.MouseEvent) {}
 
 func newGridEntry(ov *ObjectsView) *gridEntry {
 	icon := widget.NewIcon(theme.FileIcon())
-	nameLabel := widget.NewLabel("文件名")
+	nameLabel := widget.NewLabel(T("文件名"))
 	nameLabel.Wrapping = fyne.TextTruncate // 修改为截断
 	nameLabel.Alignment = fyne.TextAlignCenter
 
@@ -403,17 +616,46 @@ func newGridEntry(ov *ObjectsView) *gridEntry {
 	return entry
 }
 
-// SetBucketAndPrefix 设置当前存储桶和前缀，并加载对象列表
-func (ov *ObjectsView) SetBucketAndPrefix(client *s3client.S3Client, bucket, prefix string) {
+// SetBucketAndPrefix 设置当前存储桶和前缀，并加载对象列表。
+// 有耗时操作正在运行时不允许切换位置。
+func (ov *ObjectsView) SetBucketAndPrefix(client ObjectStore, bucket, prefix string) {
+	if (client != ov.s3Client || bucket != ov.currentBucket || prefix != ov.currentPrefix) && ov.rejectIfBusy() {
+		return
+	}
 	ov.s3Client = client
 	ov.currentBucket = bucket
 	ov.currentPrefix = prefix
+	if client != nil {
+		client.SetFoldersFirst(ov.foldersFirst)
+	}
+	if bucket != "" {
+		ov.recordRecentLocation(bucket, prefix)
+	}
+	if client != nil {
+		saveLastLocation(ov.currentServiceAlias, bucket, prefix)
+	}
 
 	ov.resetPagingAndSelection()
 	ov.loadObjects()
 	ov.updateBreadcrumbs()
 }
 
+// ReplaceS3Client 在不改变当前位置的情况下替换 S3 客户端，用于重新认证后恢复会话
+func (ov *ObjectsView) ReplaceS3Client(client ObjectStore) {
+	ov.s3Client = client
+	client.SetFoldersFirst(ov.foldersFirst)
+}
+
+// handleCredentialsExpired 如果错误由凭证过期引起，则请求重新认证并在成功后执行 retry。
+// 返回 true 表示错误已被处理，调用方无需再显示错误对话框。
+func (ov *ObjectsView) handleCredentialsExpired(err error, retry func()) bool {
+	if ov.OnCredentialsExpired == nil || !s3client.IsExpiredTokenError(err) {
+		return false
+	}
+	ov.OnCredentialsExpired(retry)
+	return true
+}
+
 func (ov *ObjectsView) resetPagingAndSelection() {
 	ov.currentPage = 1
 	ov.pageMarkers = []string{""} // 重置为初始状态
@@ -424,10 +666,32 @@ func (ov *ObjectsView) resetPagingAndSelection() {
 	ov.updatePaginationControls()
 }
 
+// stepBackFromEmptyPage 在分页模式下加载到的非首页为空时回退到上一页并返回 true。
+// 末页的对象被删除或被其它客户端移走后，当前页码可能超出末尾，此时不显示空白页。
+func (ov *ObjectsView) stepBackFromEmptyPage(objects []s3client.S3Object) bool {
+	if len(objects) > 0 || ov.currentPage <= 1 {
+		return false
+	}
+	ov.currentPage--
+	if len(ov.pageMarkers) > ov.currentPage {
+		ov.pageMarkers = ov.pageMarkers[:ov.currentPage]
+	}
+	ov.nextPageMarker = nil
+	return true
+}
+
 // loadObjects 加载指定存储桶和前缀下的对象列表
+// 快速切换目录时多次加载可能同时进行，较早发出但较晚返回的结果会被丢弃，
+// 加载指示器也只在最新一次加载完成时隐藏。
 func (ov *ObjectsView) loadObjects() {
+	ov.loadGeneration++
+	generation := ov.loadGeneration
+	ov.cancelThumbnails()
+
 	if ov.s3Client == nil || ov.currentBucket == "" {
+		ov.loadingIndicator.Hide()
 		ov.objects = []s3client.S3Object{}
+		ov.loadErr = nil
 		ov.refreshObjectView()
 		ov.updateButtonsState()
 		ov.updatePaginationControls()
@@ -436,45 +700,61 @@ func (ov *ObjectsView) loadObjects() {
 
 	ov.loadingIndicator.Show()
 	ov.updatePaginationControls()
+	ov.resetFolderCounts()
+
+	// 在 UI 线程中记录本次加载的参数，后台加载期间这些状态可能被新的导航修改
+	client, bucket, prefix := ov.s3Client, ov.currentBucket, ov.currentPrefix
+	flatView, pageSize := ov.flatView, ov.pageSize
+	// 对于第一页，marker应该是空字符串
+	var marker string
+	if ov.currentPage > 1 && ov.currentPage <= len(ov.pageMarkers) {
+		marker = ov.pageMarkers[ov.currentPage-1]
+	}
 
 	go func() {
 		var objects []s3client.S3Object
 		var nextMarker *string
 		var err error
 
-		if ov.pageSize == 0 {
+		if flatView {
+			// 平铺视图，获取前缀下的所有文件，不分页
+			objects, err = client.ListAllObjectsFlat(bucket, prefix)
+			if err != nil {
+				log.Printf("平铺列出对象失败: %v", err)
+			}
+		} else if pageSize == 0 {
 			// 不限制分页，获取所有对象
-			objects, err = ov.s3Client.ListAllObjectsUnderPrefix(ov.currentBucket, ov.currentPrefix)
+			objects, err = client.ListAllObjectsUnderPrefix(bucket, prefix)
 			if err != nil {
 				log.Printf("列出所有对象失败: %v", err)
 			}
 			// 不分页时不需要 nextMarker
 		} else {
 			// 使用分页
-			// 对于第一页，marker应该是空字符串
-			var marker string
-			if ov.currentPage == 1 {
-				marker = ""
-			} else if ov.currentPage <= len(ov.pageMarkers) {
-				marker = ov.pageMarkers[ov.currentPage-1]
-			} else {
-				// 这种情况不应该发生，但为了安全起见
-				marker = ""
-			}
-			objects, nextMarker, err = ov.s3Client.ListObjects(ov.currentBucket, ov.currentPrefix, marker, int32(ov.pageSize))
+			objects, nextMarker, err = client.ListObjects(bucket, prefix, marker, int32(pageSize))
 		}
 
 		fyne.Do(func() {
+			if generation != ov.loadGeneration {
+				log.Printf("丢弃过期的列表结果: %s/%s", bucket, prefix)
+				return
+			}
 			ov.loadingIndicator.Hide()
+			ov.loadErr = err
 			if err != nil {
+				// 错误显示在内容区域的占位中，并提供重试按钮
 				log.Printf("列出对象失败: %v", err)
-				dialog.ShowError(fmt.Errorf("列出对象失败: %v", err), ov.window)
+				ov.handleCredentialsExpired(err, ov.loadObjects)
 				ov.objects = []s3client.S3Object{}
 			} else {
+				if ov.pageSize != 0 && !ov.flatView && ov.stepBackFromEmptyPage(objects) {
+					ov.loadObjects()
+					return
+				}
 				ov.objects = objects
 				ov.nextPageMarker = nextMarker
 				// 只有在分页模式下才更新pageMarkers
-				if ov.pageSize != 0 && nextMarker != nil {
+				if ov.pageSize != 0 && !ov.flatView && nextMarker != nil {
 					// 确保pageMarkers数组足够长
 					if len(ov.pageMarkers) < ov.currentPage+1 {
 						ov.pageMarkers = append(ov.pageMarkers, make([]string, ov.currentPage+1-len(ov.pageMarkers))...)
@@ -483,51 +763,128 @@ func (ov *ObjectsView) loadObjects() {
 					ov.pageMarkers[ov.currentPage] = *nextMarker
 				}
 			}
+			s3client.SortObjectsBy(ov.objects, ov.sortOrder())
+			// 搜索词和类型筛选在切换目录后仍然生效，对新列表重新筛选；切换存储桶后重新递归搜索
+			ov.refreshRecursiveSearch()
+			ov.filteredObjects = ov.displayFilter()
 			ov.refreshObjectView()
 			ov.updateButtonsState()
 			ov.updatePaginationControls()
-			go ov.loadThumbnails()
+			ov.loadThumbnails()
 		})
 	}()
 }
 
-// loadThumbnails 遍历当前对象列表并加载图片缩略图
+// loadThumbnails 为当前列表中尚未缓存的图片加载缩略图，同时进行的下载数量受缩略图并发数限制。
+// 之前未完成的缩略图加载会被取消。
 func (ov *ObjectsView) loadThumbnails() {
-	for i, obj := range ov.objects {
-		if isPreviewableImage(obj.Name) {
-			cacheLock.RLock()
-			_, exists := thumbnailCache[obj.Key]
-			cacheLock.RUnlock()
+	ov.cancelThumbnails()
+	if !ov.thumbnailsEnabled || ov.s3Client == nil {
+		return
+	}
 
-			if !exists {
-				go ov.generateThumbnail(i, obj)
+	var pending []s3client.S3Object
+	cacheLock.RLock()
+	for _, obj := range ov.objects {
+		if _, exists := thumbnailCache[obj.Key]; !exists && isPreviewableImage(obj.Name) {
+			pending = append(pending, obj)
+		}
+	}
+	cacheLock.RUnlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ov.thumbnailCancel = cancel
+	client, bucket := ov.s3Client, ov.currentBucket
+	go runThumbnailJobs(ctx, pending, thumbnailConcurrencySetting(), func(item s3client.S3Object) {
+		ov.generateThumbnail(ctx, client, bucket, item)
+	})
+}
+
+// cancelThumbnails 取消正在进行的缩略图加载，在切换目录或重新加载列表时调用
+func (ov *ObjectsView) cancelThumbnails() {
+	if ov.thumbnailCancel != nil {
+		ov.thumbnailCancel()
+		ov.thumbnailCancel = nil
+	}
+}
+
+// runThumbnailJobs 使用 workers 个 worker 依次处理 items，ctx 被取消后不再开始新的任务。所有任务结束后返回。
+func runThumbnailJobs(ctx context.Context, items []s3client.S3Object, workers int, generate func(item s3client.S3Object)) {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan s3client.S3Object)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(items); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				generate(item)
 			}
+		}()
+	}
+	for _, item := range items {
+		select {
+		case jobs <- item:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
 		}
 	}
+	close(jobs)
+	wg.Wait()
 }
 
-// generateThumbnail 为单个图片对象生成缩略图并更新UI
-func (ov *ObjectsView) generateThumbnail(index int, item s3client.S3Object) {
-	body, err := ov.s3Client.DownloadObject(ov.currentBucket, item.Key)
+// generateThumbnail 为单个图片对象生成缩略图并更新UI。ctx 被取消时放弃下载，不报告错误。
+func (ov *ObjectsView) generateThumbnail(ctx context.Context, client ObjectStore, bucket string, item s3client.S3Object) {
+	if ctx.Err() != nil {
+		return
+	}
+	// 磁盘缓存中有同一对象（键、大小和 ETag 都相同）的缩略图时不再下载
+	if thumb, ok := loadDiskThumbnail(bucket, item); ok {
+		ov.showThumbnail(ctx, item, thumb)
+		return
+	}
+	body, err := client.DownloadObject(ctx, bucket, item.Key)
+	if ctx.Err() != nil {
+		return
+	}
 	if err != nil {
 		log.Printf("生成缩略图失败 (下载 %s): %v", item.Key, err)
+		Notify(T("部分缩略图加载失败"), fmt.Sprintf("%s: %v", item.Key, err))
 		return
 	}
 	defer body.Close()
 
-	data, err := ioutil.ReadAll(body)
+	data, err := ioutil.ReadAll(&contextReader{ctx: ctx, reader: body})
+	if ctx.Err() != nil {
+		return
+	}
 	if err != nil {
 		log.Printf("生成缩略图失败 (读取 %s): %v", item.Key, err)
+		Notify(T("部分缩略图加载失败"), fmt.Sprintf("%s: %v", item.Key, err))
 		return
 	}
 
 	img, _, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
 		log.Printf("生成缩略图失败 (解码 %s): %v", item.Key, err)
+		Notify(T("部分缩略图加载失败"), fmt.Sprintf("%s: %v", item.Key, err))
 		return
 	}
 
 	thumb := resize.Thumbnail(80, 80, img, resize.Lanczos3)
+	saveDiskThumbnail(bucket, item, thumb)
+	ov.showThumbnail(ctx, item, thumb)
+}
+
+// showThumbnail 将缩略图加入内存缓存，并更新当前列表中对应的条目
+func (ov *ObjectsView) showThumbnail(ctx context.Context, item s3client.S3Object, thumb image.Image) {
 	thumbRes := &thumbnailResource{name: item.Key, img: thumb}
 
 	cacheLock.Lock()
@@ -535,6 +892,20 @@ func (ov *ObjectsView) generateThumbnail(index int, item s3client.S3Object) {
 	cacheLock.Unlock()
 
 	fyne.Do(func() {
+		if ctx.Err() != nil || !ov.thumbnailsEnabled {
+			return // 已切换目录或关闭了缩略图
+		}
+		// 列表可能经过筛选，按 key 查找条目当前显示的位置
+		index := -1
+		for i, obj := range ov.getDisplayedObjects() {
+			if obj.Key == item.Key {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return
+		}
 		if ov.viewMode == listViewMode {
 			if ov.objectList != nil {
 				ov.objectList.RefreshItem(index)
@@ -555,6 +926,7 @@ func (ov *ObjectsView) generateThumbnail(index int, item s3client.S3Object) {
 
 // updateBreadcrumbs 更新面包屑导航
 func (ov *ObjectsView) updateBreadcrumbs() {
+	ov.updateUpButton()
 	if ov.breadcrumbContainer == nil {
 		return
 	}
@@ -636,19 +1008,23 @@ func (ov *ObjectsView) handleItemClick(id widget.ListItemID, m *desktop.MouseEve
 			}
 		}
 	}
+	ov.keyboardCursor = id
 	ov.refreshSelection()
 	ov.updateButtonsState()
+	ov.updateSelectionTitle()
+}
 
-	// 根据选择更新窗口标题
+// updateSelectionTitle 根据选择更新窗口标题，只选中一个项目时显示其名称
+func (ov *ObjectsView) updateSelectionTitle() {
 	if len(ov.selectedObjectIDs) == 1 {
 		for selectedID := range ov.selectedObjectIDs { // 获取单个选定的ID
 			items := ov.getDisplayedObjects()
 			if selectedID < len(items) {
-				ov.window.SetTitle(fmt.Sprintf("S3 资源管理器 ---> %s", items[selectedID].Name))
+				ov.window.SetTitle(fmt.Sprintf(T("S3 资源管理器 ---> %s"), items[selectedID].Name))
 			}
 		}
 	} else {
-		ov.window.SetTitle("S3 资源管理器") // 默认标题
+		ov.window.SetTitle(T("S3 资源管理器")) // 默认标题
 	}
 }
 
@@ -689,49 +1065,145 @@ func (ov *ObjectsView) showContextMenu(id widget.ListItemID, m *desktop.MouseEve
 		obj := selectedObjects[0]
 		if obj.IsFolder {
 			// 文件夹菜单项
-			openItem := fyne.NewMenuItem("打开", func() {
+			openItem := fyne.NewMenuItem(T("打开"), func() {
 				ov.SetBucketAndPrefix(ov.s3Client, ov.currentBucket, obj.Key)
 			})
 			openItem.Icon = theme.FolderOpenIcon()
 			menuItems = append(menuItems, openItem)
+
+			zipItem := fyne.NewMenuItem(T("下载为 ZIP"), func() {
+				ov.startZipDownload(obj)
+			})
+			zipItem.Icon = theme.DownloadIcon()
+			menuItems = append(menuItems, zipItem)
+
+			findDuplicatesItem := fyne.NewMenuItem(T("查找重复文件"), func() {
+				ov.startDuplicateScan(obj.Key)
+			})
+			findDuplicatesItem.Icon = theme.SearchIcon()
+			menuItems = append(menuItems, findDuplicatesItem)
+
+			exportItem := fyne.NewMenuItem(T("导出列表"), func() {
+				ov.startListingExport(obj.Key)
+			})
+			exportItem.Icon = theme.DocumentSaveIcon()
+			menuItems = append(menuItems, exportItem)
+
+			folderConsoleItem := fyne.NewMenuItem(T("在控制台中打开"), func() {
+				ov.openInConsole(obj.Key)
+			})
+			folderConsoleItem.Icon = theme.ComputerIcon()
+			menuItems = append(menuItems, folderConsoleItem)
+
+			folderPropertiesItem := fyne.NewMenuItem(T("属性"), func() {
+				ov.showPropertiesDialog(obj)
+			})
+			folderPropertiesItem.Icon = theme.InfoIcon()
+			menuItems = append(menuItems, folderPropertiesItem)
 		} else {
 			// 文件菜单项
-			openItem := fyne.NewMenuItem("打开", func() {
+			openItem := fyne.NewMenuItem(T("打开"), func() {
 				ov.showPreviewWindow(obj)
 			})
 			openItem.Icon = theme.FileImageIcon() // 使用更通用的图标
 			menuItems = append(menuItems, openItem)
 			
-			downloadItem := fyne.NewMenuItem("下载", func() {
+			downloadItem := fyne.NewMenuItem(T("下载"), func() {
 				// 使用系统文件管理器选择下载目录
 				go ov.openSystemFolderSelector()
 			})
 			downloadItem.Icon = theme.DownloadIcon()
 			menuItems = append(menuItems, downloadItem)
+
+			// 公网访问地址（仅对公开可读的对象有效）
+			publicURLItem := fyne.NewMenuItem(T("复制公网地址"), func() {
+				ov.copyPublicURL(obj)
+			})
+			publicURLItem.Icon = theme.ContentCopyIcon()
+			menuItems = append(menuItems, publicURLItem)
+
+			// 预签名下载链接在有效期内无需凭证即可访问，用于分享私有对象
+			presignItem := fyne.NewMenuItem(T("复制下载链接"), func() {
+				ov.showPresignDialog(obj)
+			})
+			presignItem.Icon = theme.MailForwardIcon()
+			menuItems = append(menuItems, presignItem)
+
+			openInBrowserItem := fyne.NewMenuItem(T("在浏览器中打开"), func() {
+				ov.openPublicURL(obj)
+			})
+			openInBrowserItem.Icon = theme.ComputerIcon()
+			menuItems = append(menuItems, openInBrowserItem)
+
+			copyETagItem := fyne.NewMenuItem(T("复制 ETag/MD5"), func() {
+				ov.copyETag(obj)
+			})
+			copyETagItem.Icon = theme.InfoIcon()
+			menuItems = append(menuItems, copyETagItem)
+
+			propertiesItem := fyne.NewMenuItem(T("属性"), func() {
+				ov.showPropertiesDialog(obj)
+			})
+			propertiesItem.Icon = theme.InfoIcon()
+			menuItems = append(menuItems, propertiesItem)
 			
 			// 添加分隔线
 			menuItems = append(menuItems, fyne.NewMenuItemSeparator())
 		}
 		
-		copyItem := fyne.NewMenuItem("复制", func() {
+		copyItem := fyne.NewMenuItem(T("复制"), func() {
 			ov.handleCopy()
 		})
 		copyItem.Icon = theme.ContentCopyIcon()
 		menuItems = append(menuItems, copyItem)
+
+		copyURIItem := fyne.NewMenuItem(T("复制 S3 路径"), func() {
+			ov.copyS3URIs(selectedObjects)
+		})
+		copyURIItem.Icon = theme.ContentCopyIcon()
+		menuItems = append(menuItems, copyURIItem)
+
+		tagItem := fyne.NewMenuItem(T("批量设置标签"), func() {
+			ov.showBulkTagDialog()
+		})
+		tagItem.Icon = theme.DocumentCreateIcon()
+		menuItems = append(menuItems, tagItem)
 	} else if len(selectedObjects) > 1 {
 		// 多个项目选中
-		downloadItem := fyne.NewMenuItem("下载", func() {
+		downloadItem := fyne.NewMenuItem(T("下载"), func() {
 			// 使用系统文件管理器选择下载目录
 			go ov.openSystemFolderSelector()
 		})
 		downloadItem.Icon = theme.DownloadIcon()
 		menuItems = append(menuItems, downloadItem)
 		
-		copyItem := fyne.NewMenuItem("复制", func() {
+		copyItem := fyne.NewMenuItem(T("复制"), func() {
 			ov.handleCopy()
 		})
 		copyItem.Icon = theme.ContentCopyIcon()
 		menuItems = append(menuItems, copyItem)
+
+		copyURIItem := fyne.NewMenuItem(T("复制 S3 路径"), func() {
+			ov.copyS3URIs(selectedObjects)
+		})
+		copyURIItem.Icon = theme.ContentCopyIcon()
+		menuItems = append(menuItems, copyURIItem)
+
+		tagItem := fyne.NewMenuItem(T("批量设置标签"), func() {
+			ov.showBulkTagDialog()
+		})
+		tagItem.Icon = theme.DocumentCreateIcon()
+		menuItems = append(menuItems, tagItem)
+
+		// 恰好选中两个文本文件时可以比较差异
+		if canCompareObjects(selectedObjects) {
+			left, right := selectedObjects[0], selectedObjects[1]
+			compareItem := fyne.NewMenuItem(T("比较两个对象"), func() {
+				ov.showCompareWindow(left, right)
+			})
+			compareItem.Icon = theme.ViewRestoreIcon()
+			menuItems = append(menuItems, compareItem)
+		}
 		
 		// 添加分隔线
 		menuItems = append(menuItems, fyne.NewMenuItemSeparator())
@@ -741,156 +1213,33 @@ func (ov *ObjectsView) showContextMenu(id widget.ListItemID, m *desktop.MouseEve
 	}
 
 	// 添加粘贴选项（总是显示）
-	pasteItem := fyne.NewMenuItem("粘贴", func() {
+	pasteItem := fyne.NewMenuItem(T("粘贴"), func() {
 		ov.handlePaste()
 	})
 	pasteItem.Icon = theme.ContentPasteIcon()
 	menuItems = append(menuItems, pasteItem)
 
+	// 在服务商的 Web 控制台中打开当前位置
+	consoleItem := fyne.NewMenuItem(T("在控制台中打开当前位置"), func() {
+		ov.openInConsole(ov.currentPrefix)
+	})
+	consoleItem.Icon = theme.ComputerIcon()
+	menuItems = append(menuItems, consoleItem)
+
+	// 导出当前位置（位于存储桶根目录时即整个存储桶）的对象清单
+	exportCurrentItem := fyne.NewMenuItem(T("导出当前位置列表"), func() {
+		ov.startListingExport(ov.currentPrefix)
+	})
+	exportCurrentItem.Icon = theme.DocumentSaveIcon()
+	menuItems = append(menuItems, exportCurrentItem)
+
 	// 添加分隔线
 	menuItems = append(menuItems, fyne.NewMenuItemSeparator())
 
 	// 添加删除选项
 	if len(selectedObjects) > 0 {
-		deleteItem := fyne.NewMenuItem("删除", func() {
-			if len(ov.selectedObjectIDs) == 0 {
-				ShowToast(ov.window, "请先选择要删除的文件或文件夹。")
-				return
-			}
-
-			dialog.ShowConfirm("确认删除", fmt.Sprintf("确定要删除选中的 %d 个项目吗？", len(ov.selectedObjectIDs)), func(confirmed bool) {
-				if confirmed {
-					go func() {
-						// --- 为删除操作进行初步扫描以获取项目总数 ---
-						scanProgressDialog := dialog.NewProgressInfinite("正在准备删除", "正在扫描待删除项目...", ov.window)
-						scanProgressDialog.Show()
-
-						var totalItemsToDelete int32 = 0
-						var scanErrors []error
-						var scanWg sync.WaitGroup
-						var scanMu sync.Mutex
-
-						itemsToProcess := make(chan s3client.S3Object, len(ov.selectedObjectIDs))
-
-						// 使用选中的项目填充通道
-						for id := range ov.selectedObjectIDs {
-							items := ov.getDisplayedObjects()
-							if id < len(items) {
-								itemsToProcess <- items[id]
-							}
-						}
-						close(itemsToProcess)
-
-						// 用于扫描的工作者 goroutines
-						numScanWorkers := 5 // 可根据需要调整
-						for i := 0; i < numScanWorkers; i++ {
-							scanWg.Add(1)
-							go func() {
-								defer scanWg.Done()
-								for item := range itemsToProcess {
-									if item.IsFolder {
-										keys, err := ov.s3Client.ListAllKeysUnderPrefix(ov.currentBucket, item.Key)
-										scanMu.Lock()
-										if err != nil {
-											scanErrors = append(scanErrors, fmt.Errorf("扫描文件夹 '%s' 失败: %w", item.Name, err))
-										} else {
-											totalItemsToDelete += int32(len(keys)) // 添加文件夹内的所有键
-										}
-										scanMu.Unlock()
-									} else {
-										scanMu.Lock()
-										totalItemsToDelete++ // 文件本身
-										scanMu.Unlock()
-									}
-								}
-							}()
-						}
-						scanWg.Wait()
-						fyne.Do(func() {
-							scanProgressDialog.Hide()
-						})
-
-						if len(scanErrors) > 0 {
-							fyne.Do(func() {
-								dialog.ShowError(fmt.Errorf("扫描部分项目失败: %v", scanErrors[0]), ov.window) // 显示第一个错误
-							})
-							return
-						}
-
-						if totalItemsToDelete == 0 {
-							fyne.Do(func() {
-								dialog.ShowInformation("提示", "没有可删除的项目。", ov.window)
-							})
-							return
-						}
-
-						// --- 执行实际删除操作并显示进度条 ---
-						deleteProgressDialog := dialog.NewProgress("正在删除", "正在删除项目...", ov.window)
-						deleteProgressDialog.Show()
-
-						var currentDeletedItems int32 = 0
-						var deletionWg sync.WaitGroup
-						var deletionMu sync.Mutex
-						var failedDeletions []string
-
-						// 用于删除项目的通道（可以是文件或文件夹）
-						itemsToDeleteChannel := make(chan s3client.S3Object, len(ov.selectedObjectIDs))
-						for id := range ov.selectedObjectIDs {
-							items := ov.getDisplayedObjects()
-							if id < len(items) {
-								itemsToDeleteChannel <- items[id]
-							}
-						}
-						close(itemsToDeleteChannel)
-
-						numDeleteWorkers := 10 // 根据需要进行调整
-						for i := 0; i < numDeleteWorkers; i++ {
-							deletionWg.Add(1)
-							go func() {
-								defer deletionWg.Done()
-								for selectedObject := range itemsToDeleteChannel {
-									var err error
-									if selectedObject.IsFolder {
-										s3Prefix := selectedObject.Key
-										if !strings.HasSuffix(s3Prefix, "/") {
-											s3Prefix += "/"
-										}
-										// 调用更新进度的新函数
-										err = ov.deleteFolderAndContentsWithProgress(ov.currentBucket, s3Prefix, &currentDeletedItems, &deletionMu, deleteProgressDialog, totalItemsToDelete)
-									} else {
-										err = ov.s3Client.DeleteObject(ov.currentBucket, selectedObject.Key)
-										deletionMu.Lock()
-										currentDeletedItems++
-										fyne.Do(func() { deleteProgressDialog.SetValue(float64(currentDeletedItems) / float64(totalItemsToDelete)) })
-										deletionMu.Unlock()
-									}
-
-									if err != nil {
-										deletionMu.Lock()
-										failedDeletions = append(failedDeletions, selectedObject.Name)
-										deletionMu.Unlock()
-										log.Printf("删除项目 '%s' 失败: %v", selectedObject.Name, err)
-									}
-								}
-							}()
-						}
-						deletionWg.Wait()
-						fyne.Do(func() {
-							deleteProgressDialog.Hide()
-						})
-
-						fyne.Do(func() {
-							if len(failedDeletions) > 0 {
-								dialog.ShowError(fmt.Errorf("部分项目删除失败: %s", strings.Join(failedDeletions, ", ")), ov.window)
-							} else {
-								ShowToast(ov.window, fmt.Sprintf("%d 个项目已成功删除。", len(ov.selectedObjectIDs)))
-							}
-							ov.resetPagingAndSelection()
-							ov.loadObjects()
-						})
-					}()
-				}
-			}, ov.window)
+		deleteItem := fyne.NewMenuItem(T("删除"), func() {
+			ov.confirmDeleteSelected()
 		})
 		deleteItem.Icon = theme.DeleteIcon()
 		menuItems = append(menuItems, deleteItem)
@@ -919,8 +1268,37 @@ func (ov *ObjectsView) unselectAllObjects() {
 		ov.lastSelectedID = -1
 		ov.refreshSelection()
 		ov.updateButtonsState()
-		ov.window.SetTitle("S3 资源管理器") // 未选择任何内容时重置标题
+		ov.window.SetTitle(T("S3 资源管理器")) // 未选择任何内容时重置标题
+	}
+}
+
+// selectAllObjects 选中当前显示的所有项目（搜索和类型筛选后的列表）。未选择存储桶或列表为空时不做任何操作。
+func (ov *ObjectsView) selectAllObjects() {
+	items := ov.getDisplayedObjects()
+	if ov.currentBucket == "" || len(items) == 0 {
+		return
+	}
+	ov.selectedObjectIDs = make(map[widget.ListItemID]struct{}, len(items))
+	for id := range items {
+		ov.selectedObjectIDs[id] = struct{}{}
+	}
+	ov.lastSelectedID = len(items) - 1
+	ov.refreshSelection()
+	ov.updateButtonsState()
+	if len(items) == 1 {
+		ov.window.SetTitle(fmt.Sprintf(T("S3 资源管理器 ---> %s"), items[0].Name))
+	} else {
+		ov.window.SetTitle(T("S3 资源管理器"))
+	}
+}
+
+// deleteSelectedFromKeyboard 响应 Delete 键，与删除按钮一样确认后删除选中的项目。
+// 未选择存储桶、列表为空或没有选中项目时不做任何操作。
+func (ov *ObjectsView) deleteSelectedFromKeyboard() {
+	if ov.currentBucket == "" || len(ov.getDisplayedObjects()) == 0 || len(ov.selectedObjectIDs) == 0 {
+		return
 	}
+	ov.confirmDeleteSelected()
 }
 
 // handleCopy 处理复制操作，将选中的对象信息保存到应用内部
@@ -944,6 +1322,8 @@ func (ov *ObjectsView) handleCopy() {
 		copiedObjectsLock.Lock()
 		copiedObjects = objectsToCopy
 		copiedObjectsLock.Unlock()
+		// 在后台统计文件夹中的对象数量和总大小，用于粘贴确认
+		ov.startCopySummary(objectsToCopy)
 
 		// 记录复制操作的时间和类型
 		copyTimeLock.Lock()
@@ -954,9 +1334,9 @@ func (ov *ObjectsView) handleCopy() {
 		// 显示提示信息
 		var message string
 		if len(objectsToCopy) == 1 {
-			message = fmt.Sprintf("已复制: %s", objectsToCopy[0].Name)
+			message = fmt.Sprintf(T("已复制: %s"), objectsToCopy[0].Name)
 		} else {
-			message = fmt.Sprintf("已复制 %d 个项目", len(objectsToCopy))
+			message = fmt.Sprintf(T("已复制 %d 个项目"), len(objectsToCopy))
 		}
 		ShowToast(ov.window, message)
 	}
@@ -965,17 +1345,21 @@ func (ov *ObjectsView) handleCopy() {
 // handlePaste 处理粘贴操作，从剪贴板获取内容并执行相应操作
 func (ov *ObjectsView) handlePaste() {
 	if ov.s3Client == nil || ov.currentBucket == "" {
-		ShowToast(ov.window, "请先选择一个 S3 服务和存储桶。")
+		ShowToast(ov.window, T("请先选择一个 S3 服务和存储桶。"))
+		return
+	}
+	if ov.rejectIfBusy() {
 		return
 	}
 
-	// 首先尝试从Windows HDROP格式读取文件路径
+	// 首先尝试从系统剪贴板的文件格式读取文件路径
+	// (Windows HDROP、macOS NSPasteboard 文件 URL、Linux text/uri-list)
 	filePaths, err := getFilePathsFromClipboard()
 	if err != nil {
-		log.Printf("从Windows剪贴板读取文件路径时出错: %v", err)
+		log.Printf("从系统剪贴板读取文件路径时出错: %v", err)
 	}
 
-	// 如果Windows HDROP读取失败或没有文件路径，尝试使用Fyne的剪贴板API
+	// 如果系统剪贴板读取失败或没有文件路径，尝试使用Fyne的剪贴板API
 	if len(filePaths) == 0 {
 		// 从剪贴板获取内容
 		content := ov.window.Clipboard().Content()
@@ -993,27 +1377,8 @@ func (ov *ObjectsView) handlePaste() {
 			// 方法1: 处理 file:// URL格式 (Windows/Linux/Mac)
 			if strings.Contains(content, "file://") {
 				log.Printf("检测到 file:// 格式的内容")
-				lines := strings.Split(content, "\n")
-				for _, line := range lines {
-					line = strings.TrimSpace(line)
-					if strings.HasPrefix(line, "file://") {
-						log.Printf("处理行: %s", line)
-						// 移除 file:// 前缀并解码URL
-						path := strings.TrimPrefix(line, "file://")
-						// 处理Windows路径 (file:///C:/path -> C:\path)
-						if len(path) > 2 && path[0] == '/' && path[2] == ':' {
-							path = path[1:] // 移除开头的斜杠
-						}
-						decodedPath, err := url.QueryUnescape(path)
-						if err != nil {
-							// 如果解码失败，直接使用原始路径
-							decodedPath = path
-							log.Printf("URL解码失败，使用原始路径: %s", path)
-						}
-						filePaths = append(filePaths, decodedPath)
-						log.Printf("解析到文件路径 (file://): %s", decodedPath)
-					}
-				}
+				filePaths = parseURIList(content)
+				log.Printf("解析到 %d 个文件路径 (file://)", len(filePaths))
 			}
 
 			// 方法2: 处理纯文本路径格式 (Windows)
@@ -1095,24 +1460,19 @@ func (ov *ObjectsView) handlePaste() {
 	if useSystemClipboard {
 		log.Printf("开始上传 %d 个文件: %v", len(filePaths), filePaths)
 		// 开始上传过程
-		go ov.startUploadProcess(filePaths)
+		ov.runOperation(T("上传"), func() { ov.startUploadProcess(filePaths) })
 		return
 	}
 
 	// 如果有从S3复制的对象，执行S3到S3的复制
 	if useS3Objects {
-		dialog.ShowConfirm("确认粘贴", fmt.Sprintf("是否要粘贴 %d 个已复制的对象到当前目录？", len(localCopiedObjects)),
-			func(confirmed bool) {
-				if confirmed {
-					go ov.pasteS3Objects(localCopiedObjects)
-				}
-			}, ov.window)
+		ov.confirmPasteS3Objects(localCopiedObjects)
 		return
 	}
 
 	// 无法识别剪贴板内容格式
 	log.Printf("无法识别剪贴板内容格式")
-	ShowToast(ov.window, "剪贴板中没有可识别的文件路径。")
+	ShowToast(ov.window, T("剪贴板中没有可识别的文件路径。"))
 }
 
 // updateButtonsState 根据当前选择状态更新按钮的可用性
@@ -1123,7 +1483,11 @@ func (ov *ObjectsView) updateButtonsState() {
 
 	numSelected := len(ov.selectedObjectIDs)
 
-	if numSelected > 0 {
+	if numSelected > 0 && ov.isBusy() {
+		// 耗时操作运行期间禁止删除和再次下载
+		ov.deleteButton.Disable()
+		ov.downloadButton.Disable()
+	} else if numSelected > 0 {
 		ov.deleteButton.Enable()
 		ov.downloadButton.Enable()
 	} else {
@@ -1137,13 +1501,17 @@ func (ov *ObjectsView) updatePaginationControls() {
 		return
 	}
 
-	// 如果 pageSize 为 0，表示不限制分页
-	if ov.pageSize == 0 {
-		ov.pageInfoLabel.SetText("无分页")
+	// 平铺视图不分页
+	if ov.flatView {
+		ov.pageInfoLabel.SetText(T("平铺视图"))
+		ov.prevButton.Disable()
+		ov.nextButton.Disable()
+	} else if ov.pageSize == 0 { // 如果 pageSize 为 0，表示不限制分页
+		ov.pageInfoLabel.SetText(T("无分页"))
 		ov.prevButton.Disable()
 		ov.nextButton.Disable()
 	} else {
-		ov.pageInfoLabel.SetText(fmt.Sprintf("第 %d 页", ov.currentPage))
+		ov.pageInfoLabel.SetText(fmt.Sprintf(T("第 %d 页"), ov.currentPage))
 
 		if ov.currentPage > 1 {
 			ov.prevButton.Enable()
@@ -1162,121 +1530,149 @@ func (ov *ObjectsView) updatePaginationControls() {
 		ov.prevButton.Disable()
 		ov.nextButton.Disable()
 	}
+	ov.updateRefreshButton()
 }
 
 // showPreviewWindow 弹出一个新窗口来预览文件，或使用系统默认应用打开
 func (ov *ObjectsView) showPreviewWindow(item s3client.S3Object) {
-	ext := strings.ToLower(filepath.Ext(item.Name))
-
-	// 定义可直接在 Fyne 中预览的类型
-	switch ext {
-	case ".png", ".jpg", ".jpeg", ".gif":
-		ov.showInAppPreview(item, "image")
-	case ".txt", ".md", ".log", ".json", ".xml", ".yaml", ".yml", ".ini", ".cfg", ".go", ".py", ".js", ".html", ".css":
-		ov.showInAppPreview(item, "text")
-	default:
-		// 对于其他类型，下载到临时文件并用系统默认应用打开
-		ov.openWithDefaultApp(item)
+	// 可直接在 Fyne 中预览的类型在应用内打开
+	if previewTypeFor(item.Name) != "" {
+		ov.showInAppPreview(item)
+		return
 	}
+	// 对于其他类型，下载到临时文件并用系统默认应用打开
+	ov.openWithDefaultApp(item)
 }
 
-// showInAppPreview 在应用内的新窗口中显示预览
-func (ov *ObjectsView) showInAppPreview(item s3client.S3Object, previewType string) {
-	previewWindow := fyne.CurrentApp().NewWindow(fmt.Sprintf("预览 - %s", item.Name))
-	previewWindow.SetContent(container.NewCenter(widget.NewProgressBarInfinite()))
-	previewWindow.Resize(fyne.NewSize(800, 600))
-	previewWindow.Show()
-
-	go func() {
-		body, err := ov.s3Client.DownloadObject(ov.currentBucket, item.Key)
-		if err != nil {
-			log.Printf("预览失败 (下载): %v", err)
-			fyne.Do(func() { previewWindow.SetContent(container.NewCenter(widget.NewLabel("加载预览失败"))) })
-			return
-		}
-		defer body.Close()
-
-		data, err := ioutil.ReadAll(body)
-		if err != nil {
-			log.Printf("预览失败 (读取): %v", err)
-			fyne.Do(func() { previewWindow.SetContent(container.NewCenter(widget.NewLabel("加载预览失败"))) })
+// createTextPreview 创建文本预览内容。默认只读，小于 maxEditableTextSize 的文件可切换到编辑模式并保存回原 key。
+func (ov *ObjectsView) createTextPreview(item s3client.S3Object, originalText string) fyne.CanvasObject {
+	ext := strings.ToLower(filepath.Ext(item.Name))
+	editing := false
+
+	textEntry := widget.NewMultiLineEntry()
+	textEntry.SetText(originalText)
+	textEntry.Wrapping = fyne.TextWrapBreak
+
+	var body fyne.CanvasObject
+	var renderedText *widget.RichText
+	// 代码文件在只读模式下显示语法高亮视图，编辑时切换回输入框
+	var codeView *widget.RichText
+	var textScroll, codeScroll *container.Scroll
+	if ext == ".md" {
+		// 左侧：原始 Markdown 文本；右侧：渲染后的 Markdown
+		renderedText = widget.NewRichTextFromMarkdown(originalText)
+		renderedText.Wrapping = fyne.TextWrapBreak
+
+		split := container.NewHSplit(
+			container.NewScroll(textEntry),
+			container.NewScroll(renderedText),
+		)
+		split.Offset = 0.5
+		body = split
+	} else if codeView = newCodeView(ext, originalText); codeView != nil {
+		textScroll, codeScroll = container.NewScroll(textEntry), container.NewScroll(codeView)
+		textScroll.Hide()
+		body = container.NewStack(textScroll, codeScroll)
+	} else {
+		body = container.NewScroll(textEntry)
+	}
+	// showCode 在只读时显示语法高亮视图，编辑时显示输入框
+	showCode := func(show bool) {
+		if codeView == nil {
 			return
 		}
-
-		var previewContent fyne.CanvasObject
-		if previewType == "image" {
-			img, _, err := image.Decode(bytes.NewReader(data))
-			if err != nil {
-				log.Printf("预览图片失败 (解码): %v", err)
-				previewContent = container.NewCenter(widget.NewLabel("无法解码图片"))
-			} else {
-				canvasImg := canvas.NewImageFromImage(img)
-				canvasImg.FillMode = canvas.ImageFillContain
-				previewContent = container.NewScroll(canvasImg)
-			}
+		if show {
+			codeView.Segments = codeSegments(highlightCode(ext, originalText))
+			codeView.Refresh()
+			codeScroll.Show()
+			textScroll.Hide()
 		} else {
-			ext := strings.ToLower(filepath.Ext(item.Name))
-			originalText := string(data)
-
-			if ext == ".md" {
-				// 左侧：原始 Markdown 文本
-				rawText := widget.NewMultiLineEntry()
-				rawText.SetText(originalText)
-				rawText.Wrapping = fyne.TextWrapBreak
-				rawText.OnChanged = func(s string) { // 实现只读
-					if s != originalText {
-						rawText.SetText(originalText)
-					}
-				}
-
-				// 右侧：渲染后的 Markdown
-				renderedText := widget.NewRichTextFromMarkdown(originalText)
-				renderedText.Wrapping = fyne.TextWrapBreak
+			codeScroll.Hide()
+			textScroll.Show()
+		}
+	}
 
-				split := container.NewHSplit(
-					container.NewScroll(rawText),
-					container.NewScroll(renderedText),
-				)
-				split.Offset = 0.5
-				previewContent = split
-			} else {
-				// 其他文本文件：使用只读的 MultiLineEntry
-				textEntry := widget.NewMultiLineEntry()
+	textEntry.OnChanged = func(s string) {
+		if !editing { // 非编辑模式下实现只读
+			if s != originalText {
 				textEntry.SetText(originalText)
-				textEntry.Wrapping = fyne.TextWrapBreak
-				textEntry.OnChanged = func(s string) {
-					if s != originalText {
-						textEntry.SetText(originalText)
-					}
-				}
-				previewContent = container.NewScroll(textEntry)
 			}
+			return
 		}
-		fyne.Do(func() { previewWindow.SetContent(previewContent) })
-	}()
-}
+		if renderedText != nil {
+			renderedText.ParseMarkdown(s)
+		}
+	}
 
-// openWithDefaultApp 下载文件到临时目录并用系统默认应用打开
-func (ov *ObjectsView) openWithDefaultApp(item s3client.S3Object) {
-	loadingDialog := dialog.NewProgressInfinite("正在准备预览", "正在下载文件...", ov.window)
+	if int64(len(originalText)) > maxEditableTextSize {
+		return body
+	}
+
+	bucket := ov.currentBucket
+	var editButton *widget.Button
+	saveButton := widget.NewButtonWithIcon(T("保存"), theme.DocumentSaveIcon(), func() {
+		if ov.currentBucket != bucket {
+			dialog.ShowError(fmt.Errorf(T("当前存储桶已切换，无法保存到 '%s'"), bucket), ov.window)
+			return
+		}
+		newText := textEntry.Text
+		ov.confirmOverwrite(item.Key, func() {
+			ov.saveTextObject(item.Key, []byte(newText), func() {
+				originalText = newText
+				ShowToast(ov.window, fmt.Sprintf(T("文件 '%s' 已保存。"), item.Name))
+				ov.loadObjects()
+			})
+		})
+	})
+	saveButton.Importance = widget.HighImportance
+	saveButton.Hide()
+
+	editButton = widget.NewButtonWithIcon(T("编辑"), theme.DocumentCreateIcon(), func() {
+		editing = !editing
+		showCode(!editing)
+		if editing {
+			editButton.SetText(T("取消编辑"))
+			saveButton.Show()
+		} else {
+			// 放弃未保存的修改
+			editButton.SetText(T("编辑"))
+			saveButton.Hide()
+			textEntry.SetText(originalText)
+			if renderedText != nil {
+				renderedText.ParseMarkdown(originalText)
+			}
+		}
+	})
+
+	toolbar := container.NewHBox(editButton, saveButton)
+	return container.NewBorder(toolbar, nil, nil, nil, body)
+}
+
+// openWithDefaultApp 下载文件到临时目录并用系统默认应用打开
+func (ov *ObjectsView) openWithDefaultApp(item s3client.S3Object) {
+	loadingDialog := dialog.NewProgressInfinite(T("正在准备预览"), T("正在下载文件..."), ov.window)
 	loadingDialog.Show()
 
 	go func() {
 		defer loadingDialog.Hide()
 
-		body, err := ov.s3Client.DownloadObject(ov.currentBucket, item.Key)
+		body, err := ov.s3Client.DownloadObject(context.Background(), ov.currentBucket, item.Key)
 		if err != nil {
 			log.Printf("打开文件失败 (下载): %v", err)
-			fyne.Do(func() { dialog.ShowError(fmt.Errorf("下载文件失败: %v", err), ov.window) })
+			fyne.Do(func() {
+				if !ov.handleCredentialsExpired(err, func() { ov.openWithDefaultApp(item) }) {
+					dialog.ShowError(fmt.Errorf(T("下载文件失败: %v"), err), ov.window)
+				}
+			})
 			return
 		}
 		defer body.Close()
 
 		// 修正：创建带正确扩展名的临时文件
-		tempFile, err := ioutil.TempFile("", fmt.Sprintf("s3-explorer-*%s", filepath.Ext(item.Name)))
+		tempFile, err := ioutil.TempFile("", "s3-explorer-*"+common.SafeFileExt(item.Key))
 		if err != nil {
 			log.Printf("创建临时文件失败: %v", err)
-			fyne.Do(func() { dialog.ShowError(fmt.Errorf("创建临时文件失败: %v", err), ov.window) })
+			fyne.Do(func() { dialog.ShowError(fmt.Errorf(T("创建临时文件失败: %v"), err), ov.window) })
 			return
 		}
 		defer tempFile.Close()
@@ -1284,33 +1680,53 @@ func (ov *ObjectsView) openWithDefaultApp(item s3client.S3Object) {
 		_, err = io.Copy(tempFile, body)
 		if err != nil {
 			log.Printf("写入临时文件失败: %v", err)
-			fyne.Do(func() { dialog.ShowError(fmt.Errorf("写入临时文件失败: %v", err), ov.window) })
+			fyne.Do(func() { dialog.ShowError(fmt.Errorf(T("写入临时文件失败: %v"), err), ov.window) })
 			return
 		}
 
-		// 获取临时文件路径并用系统命令打开
-		tempFilePath := tempFile.Name()
-		var cmd *exec.Cmd
-		switch runtime.GOOS {
-		case "windows":
-			cmd = exec.Command("cmd", "/C", "start", tempFilePath)
-		case "darwin":
-			cmd = exec.Command("open", tempFilePath)
-		default: // linux, freebsd, openbsd, netbsd 等
-			cmd = exec.Command("xdg-open", tempFilePath)
-		}
-
-		if err := cmd.Start(); err != nil {
+		// 用系统命令打开临时文件
+		if err := openWithSystemApp(tempFile.Name()); err != nil {
 			log.Printf("打开外部应用失败: %v", err)
-			fyne.Do(func() { dialog.ShowError(fmt.Errorf("无法使用默认应用打开文件: %v", err), ov.window) })
+			fyne.Do(func() { dialog.ShowError(fmt.Errorf(T("无法使用默认应用打开文件: %v"), err), ov.window) })
 		}
 	}()
 }
 
-// handleDrop 处理拖放的文件和文件夹
-func (ov *ObjectsView) handleDrop(uris []fyne.URI) {
+// copyPublicURL 将对象的公网访问地址复制到系统剪贴板
+func (ov *ObjectsView) copyPublicURL(item s3client.S3Object) {
 	if ov.s3Client == nil || ov.currentBucket == "" {
-		dialog.ShowInformation("提示", "请先选择一个 S3 服务和存储桶才能上传。", ov.window)
+		return
+	}
+	publicURL := ov.s3Client.PublicObjectURL(ov.currentBucket, item.Key)
+	ov.window.Clipboard().SetContent(publicURL)
+	ShowToast(ov.window, T("已复制公网地址（仅当对象或存储桶公开可读时可访问）"))
+}
+
+// openPublicURL 在默认浏览器中打开对象的公网访问地址
+func (ov *ObjectsView) openPublicURL(item s3client.S3Object) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	publicURL, err := url.Parse(ov.s3Client.PublicObjectURL(ov.currentBucket, item.Key))
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(T("生成公网地址失败: %v"), err), ov.window)
+		return
+	}
+	if err := fyne.CurrentApp().OpenURL(publicURL); err != nil {
+		log.Printf("打开浏览器失败: %v", err)
+		dialog.ShowError(fmt.Errorf(T("无法在浏览器中打开: %v"), err), ov.window)
+		return
+	}
+	ShowToast(ov.window, T("已在浏览器中打开（仅当对象或存储桶公开可读时可访问）"))
+}
+
+// handleDrop 处理拖放的文件和文件夹。拖放到文件夹条目上时上传到该文件夹，否则上传到当前目录。
+func (ov *ObjectsView) handleDrop(pos fyne.Position, uris []fyne.URI) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		dialog.ShowInformation(T("提示"), T("请先选择一个 S3 服务和存储桶才能上传。"), ov.window)
+		return
+	}
+	if ov.rejectIfBusy() {
 		return
 	}
 	if len(uris) == 0 {
@@ -1329,33 +1745,45 @@ func (ov *ObjectsView) handleDrop(uris []fyne.URI) {
 		pathsToUpload = append(pathsToUpload, uri.Path())
 	}
 
-	if len(pathsToUpload) > 0 {
-		go ov.startUploadProcess(pathsToUpload)
+	if len(pathsToUpload) == 0 {
+		return
 	}
+	if folder, ok := ov.folderAtPosition(pos); ok {
+		log.Printf("拖放到文件夹 '%s'", folder.Key)
+		ov.runOperation(T("上传"), func() { ov.startUploadProcessTo(pathsToUpload, folder.Key) })
+		return
+	}
+	ov.runOperation(T("上传"), func() { ov.startUploadProcess(pathsToUpload) })
 }
 
 // uploadSingleFile 处理单个文件的实际上传逻辑。
-// 它将文件内容读入内存，然后上传到 S3。
-// 这种方法使用 bytes.NewReader (io.ReadSeeker) 来避免在使用 HTTP 和校验和时出现 "unseekable stream" 错误。
-func (ov *ObjectsView) uploadSingleFile(localPath, s3Key string, fileSize int64, totalOverallSize int64, bytesUploaded *int64, progressDialog *dialog.ProgressDialog) error {
-	// 1. 将整个文件内容读入内存
-	// 注意：对于大文件，这可能会消耗大量内存。
-	data, err := ioutil.ReadFile(localPath) // ioutil.ReadFile 返回 []byte
+// 较小的文件读入内存，较大的文件直接从磁盘流式读取，两者都是 io.ReadSeeker，
+// 以避免在使用 HTTP 和校验和时出现 "unseekable stream" 错误。
+func (t transferTarget) uploadSingleFile(ctx context.Context, localPath, s3Key string, fileSize int64, opts s3client.UploadOptions, attempt *transferAttempt) error {
+	// 1. 打开文件内容，大文件不会整个读入内存
+	reader, actualFileSize, closeSource, err := openUploadSource(localPath, fileSize)
 	if err != nil {
-		return fmt.Errorf("无法读取文件 '%s' 到内存: %w", filepath.Base(localPath), err)
+		return err
 	}
+	defer closeSource()
 
-	// 2. 从字节切片创建一个 io.ReadSeeker
-	reader := bytes.NewReader(data)
-	actualFileSize := int64(len(data)) // 从数据重新计算文件大小
+	// 未指定 Content-Type 时根据扩展名或文件开头的内容推断，使浏览器能正确显示图片、PDF 等文件
+	if opts.ContentType == "" {
+		if opts.ContentType, err = s3client.DetectContentType(s3Key, reader); err != nil {
+			return fmt.Errorf("读取文件 '%s' 失败: %w", filepath.Base(localPath), err)
+		}
+	}
 
-	// 3. 使用进度跟踪器包装 reader
-	// bytes.NewReader 是一个 io.ReadSeeker，而我们的 ProgressTracker 包装了一个 io.Reader。
-	// SDK 现在应该能够在需要时处理校验和。
-	readerWithProgress := NewProgressTracker(reader, totalOverallSize, bytesUploaded, progressDialog)
+	// 2. 使用进度跟踪器包装 reader
+	// 数据流是 io.ReadSeeker，ProgressTracker 会保留 Seek 能力，SDK 可以在需要时处理校验和。
+	readerWithProgress := attempt.track(reader)
 
-	// 4. 将 io.ReadSeeker (readerWithProgress) 传递给 S3 客户端。
-	err = ov.s3Client.UploadObject(ov.currentBucket, s3Key, readerWithProgress, actualFileSize)
+	// 3. 将 io.ReadSeeker (readerWithProgress) 传递给 S3 客户端。大文件使用分段上传，只在内存中缓存正在上传的分段。
+	if actualFileSize > s3client.MultipartUploadThreshold {
+		err = t.client.UploadLargeObject(ctx, t.bucket, s3Key, readerWithProgress, actualFileSize, opts)
+	} else {
+		err = t.client.UploadObject(ctx, t.bucket, s3Key, readerWithProgress, actualFileSize, opts)
+	}
 	if err != nil {
 		return fmt.Errorf("上传文件 '%s' 失败: %w", filepath.Base(localPath), err)
 	}
@@ -1369,7 +1797,9 @@ func (ov *ObjectsView) refreshObjectView() {
 		return
 	}
 	ov.unselectAllObjects()
-	if ov.viewMode == gridViewMode {
+	if placeholder := ov.createEmptyState(); placeholder != nil {
+		ov.mainContent.Objects = []fyne.CanvasObject{placeholder}
+	} else if ov.viewMode == gridViewMode {
 		ov.mainContent.Objects = []fyne.CanvasObject{ov.createGridView()}
 	} else {
 		ov.mainContent.Objects = []fyne.CanvasObject{ov.createListView()}
@@ -1395,6 +1825,39 @@ func (ov *ObjectsView) refreshObjectView() {
 	}
 }
 
+// createEmptyState 在没有可显示的对象时返回相应的占位内容，否则返回 nil
+func (ov *ObjectsView) createEmptyState() fyne.CanvasObject {
+	if !ov.hasServices {
+		addButton := widget.NewButtonWithIcon(T("添加服务"), theme.ContentAddIcon(), func() {
+			if ov.OnAddServiceRequested != nil {
+				ov.OnAddServiceRequested()
+			}
+		})
+		addButton.Importance = widget.HighImportance
+		return newEmptyState(theme.StorageIcon(), T("还没有配置服务，点击左上角 + 添加"), addButton)
+	}
+	if ov.connecting {
+		return newEmptyState(theme.StorageIcon(), T("正在连接服务…"), nil)
+	}
+	if ov.s3Client == nil {
+		return newEmptyState(theme.StorageIcon(), T("请在左侧选择一个服务"), nil)
+	}
+	if ov.currentBucket == "" {
+		return newEmptyState(theme.FolderIcon(), T("请选择一个存储桶"), nil)
+	}
+	if len(ov.getDisplayedObjects()) > 0 {
+		return nil
+	}
+	if ov.loadErr != nil {
+		retryButton := widget.NewButtonWithIcon(T("重试"), theme.ViewRefreshIcon(), ov.loadObjects)
+		return newEmptyState(theme.ErrorIcon(), fmt.Sprintf(T("列出对象失败: %v"), ov.loadErr), retryButton)
+	}
+	if ov.filteredObjects != nil {
+		return newEmptyState(theme.SearchIcon(), T("没有匹配的文件"), nil)
+	}
+	return newEmptyState(theme.FolderOpenIcon(), T("此文件夹为空"), nil)
+}
+
 // refreshSelection 在项目被选中/取消选中时调用。
 func (ov *ObjectsView) refreshSelection() {
 	if ov.viewMode == gridViewMode {
@@ -1418,12 +1881,15 @@ func (ov *ObjectsView) refreshSelection() {
 }
 
 func (ov *ObjectsView) createListView() fyne.CanvasObject {
+	ov.listEntries = nil
 	ov.objectList = widget.NewList(
 		func() int {
 			return len(ov.getDisplayedObjects())
 		},
 		func() fyne.CanvasObject {
-			return newListEntry(ov)
+			entry := newListEntry(ov)
+			ov.listEntries = append(ov.listEntries, entry)
+			return entry
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			items := ov.getDisplayedObjects()
@@ -1439,12 +1905,16 @@ func (ov *ObjectsView) createListView() fyne.CanvasObject {
 
 			if item.IsFolder {
 				entry.icon.SetResource(theme.FolderIcon())
-				entry.infoLabel.SetText("文件夹")
+				info := T("文件夹")
+				if countText := ov.folderCountText(item.Key); countText != "" {
+					info += " · " + countText
+				}
+				entry.setInfo(info, "")
 				entry.doubleTapped = func() {
 					ov.SetBucketAndPrefix(ov.s3Client, ov.currentBucket, item.Key)
 				}
 			} else {
-				if isPreviewableImage(item.Name) {
+				if ov.thumbnailsEnabled && isPreviewableImage(item.Name) {
 					cacheLock.RLock()
 					thumb, exists := thumbnailCache[item.Key]
 					cacheLock.RUnlock()
@@ -1457,7 +1927,14 @@ func (ov *ObjectsView) createListView() fyne.CanvasObject {
 					entry.icon.SetResource(getIconForFile(item.Name))
 				}
 
-				entry.infoLabel.SetText(fmt.Sprintf("%s | %s", formatBytes(item.Size), item.LastModified))
+				modified := ov.lastModifiedText(item)
+				info := fmt.Sprintf("%s | %s", formatBytes(item.Size), modified)
+				// 显示相对时间时，悬停显示绝对时间
+				hoverInfo := ""
+				if modified != item.LastModified {
+					hoverInfo = fmt.Sprintf("%s | %s", formatBytes(item.Size), item.LastModified)
+				}
+				entry.setInfo(info, hoverInfo)
 				entry.doubleTapped = func() {
 					ov.showPreviewWindow(item)
 				}
@@ -1465,7 +1942,7 @@ func (ov *ObjectsView) createListView() fyne.CanvasObject {
 			entry.Refresh()
 		},
 	)
-	return newTappableContainer(ov.objectList, ov.unselectAllObjects)
+	return container.NewBorder(ov.newSortHeader(), nil, nil, nil, newTappableContainer(ov.objectList, ov.unselectAllObjects))
 }
 
 func (ov *ObjectsView) createGridView() fyne.CanvasObject {
@@ -1481,11 +1958,12 @@ func (ov *ObjectsView) createGridView() fyne.CanvasObject {
 
 		if item.IsFolder {
 			entry.icon.SetResource(theme.FolderIcon())
+			entry.nameLabel.SetText(ov.gridFolderLabel(item.Name, item.Key))
 			entry.doubleTapped = func() {
 				ov.SetBucketAndPrefix(ov.s3Client, ov.currentBucket, item.Key)
 			}
 		} else {
-			if isPreviewableImage(item.Name) {
+			if ov.thumbnailsEnabled && isPreviewableImage(item.Name) {
 				cacheLock.RLock()
 				thumb, exists := thumbnailCache[item.Key]
 				cacheLock.RUnlock()
@@ -1505,7 +1983,7 @@ func (ov *ObjectsView) createGridView() fyne.CanvasObject {
 	}
 
 	grid := container.NewGridWrap(fyne.NewSize(120, 120), items...)
-	return container.NewScroll(grid)
+	return container.NewBorder(ov.newSortHeader(), nil, nil, nil, container.NewScroll(grid))
 }
 
 // GetContent 返回 ObjectsView 的 Fyne UI 内容
@@ -1515,7 +1993,7 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 
 	// 创建搜索框
 	ov.searchEntry = widget.NewEntry()
-	ov.searchEntry.SetPlaceHolder("搜索文件...")
+	ov.searchEntry.SetPlaceHolder(T("搜索文件..."))
 	ov.searchEntry.OnChanged = func(s string) {
 		ov.filterObjects(s)
 	}
@@ -1523,41 +2001,47 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 	createFolderButton := widget.NewButtonWithIcon("", theme.FolderNewIcon(), func() {
 		// 动画结束后执行的逻辑
 		if ov.s3Client == nil || ov.currentBucket == "" {
-			ShowToast(ov.window, "请先选择一个 S3 服务和存储桶。")
+			ShowToast(ov.window, T("请先选择一个 S3 服务和存储桶。"))
 			return
 		}
 
 		// 创建自定义弹窗以更好地控制尺寸
 		folderNameEntry := widget.NewEntry()
-		folderNameEntry.SetPlaceHolder("请输入文件夹名称")
+		folderNameEntry.SetPlaceHolder(T("请输入文件夹名称"))
 
 		formContent := container.NewVBox(
-			widget.NewLabel("文件夹名称:"),
+			widget.NewLabel(T("文件夹名称:")),
 			folderNameEntry,
 			layout.NewSpacer(),
 		)
 
 		// 创建自定义对话框
-		createFolderDialog := dialog.NewCustomConfirm("创建新文件夹", "创建", "取消", formContent, func(confirmed bool) {
+		createFolderDialog := dialog.NewCustomConfirm(T("创建新文件夹"), T("创建"), T("取消"), formContent, func(confirmed bool) {
 			if confirmed {
 				folderName := folderNameEntry.Text
 				if folderName == "" {
-					ShowToast(ov.window, "文件夹名称不能为空。")
+					ShowToast(ov.window, T("文件夹名称不能为空。"))
 					return
 				}
 				s3Key := ov.currentPrefix + folderName + "/"
 
-				go func() {
-					err := ov.s3Client.CreateFolder(ov.currentBucket, s3Key)
-					fyne.Do(func() {
-						if err != nil {
-							dialog.ShowError(fmt.Errorf("创建文件夹失败: %v", err), ov.window)
-						} else {
-							ShowToast(ov.window, fmt.Sprintf("文件夹 '%s' 创建成功！", folderName))
-							ov.loadObjects()
-						}
-					})
-				}()
+				var createFolder func()
+				createFolder = func() {
+					go func() {
+						err := ov.s3Client.CreateFolder(ov.currentBucket, s3Key)
+						fyne.Do(func() {
+							if err != nil {
+								if !ov.handleCredentialsExpired(err, createFolder) {
+									dialog.ShowError(fmt.Errorf(T("创建文件夹失败: %v"), err), ov.window)
+								}
+							} else {
+								ShowToast(ov.window, fmt.Sprintf(T("文件夹 '%s' 创建成功！"), folderName))
+								ov.loadObjects()
+							}
+						})
+					}()
+				}
+				createFolder()
 			}
 		}, ov.window)
 		createFolderDialog.Resize(fyne.NewSize(400, 200)) // 增大弹窗尺寸
@@ -1579,12 +2063,28 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 	uploadButton := widget.NewButtonWithIcon("", theme.UploadIcon(), func() {
 		// 动画结束后执行的逻辑
 		if ov.s3Client == nil || ov.currentBucket == "" {
-			ShowToast(ov.window, "请先选择一个 S3 服务和存储桶。")
+			ShowToast(ov.window, T("请先选择一个 S3 服务和存储桶。"))
 			return
 		}
 
+		// 高级选项中填写的请求头在选择文件前校验，格式错误时不打开文件选择框
+		headersEditor, uploadHeaders := newUploadHeadersEditor()
+		uploadOptions := func() (s3client.UploadOptions, bool) {
+			opts, err := parseUploadHeaders(uploadHeaders())
+			if err != nil {
+				dialog.ShowError(err, ov.window)
+				return opts, false
+			}
+			opts.StorageClass = uploadStorageClassOption(ov.uploadStorageClass)
+			return opts, true
+		}
+
 		// 创建更美观的上传选项弹窗
 		fileUploadFunc := func() {
+			opts, ok := uploadOptions()
+			if !ok {
+				return
+			}
 			fd := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
 				if err != nil {
 					dialog.ShowError(err, ov.window)
@@ -1594,13 +2094,18 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 					return
 				}
 				defer reader.Close()
-				go ov.startUploadProcess([]string{reader.URI().Path()})
+				path := reader.URI().Path()
+				ov.runOperation(T("上传"), func() { ov.startUploadProcessWithOptions([]string{path}, ov.currentPrefix, opts) })
 			}, ov.window)
 			fd.SetFilter(storage.NewExtensionFileFilter([]string{})) // 不限制文件类型
 			fd.Show()
 		}
 
 		folderUploadFunc := func() {
+			opts, ok := uploadOptions()
+			if !ok {
+				return
+			}
 			dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
 				if err != nil {
 					dialog.ShowError(err, ov.window)
@@ -1609,13 +2114,13 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 				if uri == nil {
 					return
 				}
-				go ov.startUploadProcess([]string{uri.Path()})
+				ov.runOperation(T("上传"), func() { ov.startUploadProcessWithOptions([]string{uri.Path()}, ov.currentPrefix, opts) })
 			}, ov.window)
 		}
 
 		// 创建带图标的按钮，使界面更美观
-		fileBtn := widget.NewButtonWithIcon("上传文件", theme.FileIcon(), fileUploadFunc)
-		folderBtn := widget.NewButtonWithIcon("上传文件夹", theme.FolderIcon(), folderUploadFunc)
+		fileBtn := widget.NewButtonWithIcon(T("上传文件"), theme.FileIcon(), fileUploadFunc)
+		folderBtn := widget.NewButtonWithIcon(T("上传文件夹"), theme.FolderIcon(), folderUploadFunc)
 
 		// 设置按钮大小和样式
 		fileBtn.Importance = widget.HighImportance
@@ -1623,18 +2128,39 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 
 		// 创建垂直布局的内容，增加间距
 		content := container.NewVBox(
-			container.NewCenter(widget.NewLabelWithStyle("请选择上传类型", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+			container.NewCenter(widget.NewLabelWithStyle(T("请选择上传类型"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
 			widget.NewSeparator(),
 			container.NewPadded(fileBtn),
 			container.NewPadded(folderBtn),
+			widget.NewSeparator(),
+			newUploadTimestampOptions(),
+			newUploadSkipUnchangedOption(),
+			ov.newUploadStorageClassOption(),
+			widget.NewAccordion(widget.NewAccordionItem(T("高级"), headersEditor)),
 		)
 
 		// 创建自定义对话框并设置合适的尺寸
-		uploadDialog := dialog.NewCustom("上传文件", "取消", content, ov.window)
-		uploadDialog.Resize(fyne.NewSize(300, 200)) // 调整高度
+		uploadDialog := dialog.NewCustom(T("上传文件"), T("取消"), content, ov.window)
+		uploadDialog.Resize(fyne.NewSize(420, 460)) // 调整高度
 		uploadDialog.Show()
 	})
 
+	createTextFileButton := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+		ov.showCreateTextFileDialog()
+	})
+
+	// 为按钮添加点击动画
+	if ov.animationManager != nil {
+		originalCreateTextFileButtonOnTapped := createTextFileButton.OnTapped
+		createTextFileButton.OnTapped = func() {
+			ov.animationManager.AnimateButtonClick(createTextFileButton, func() {
+				if originalCreateTextFileButtonOnTapped != nil {
+					originalCreateTextFileButtonOnTapped()
+				}
+			})
+		}
+	}
+
 	// 为按钮添加点击动画
 	if ov.animationManager != nil {
 		originalUploadButtonOnTapped := uploadButton.OnTapped
@@ -1650,7 +2176,7 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 	ov.downloadButton = widget.NewButtonWithIcon("", theme.DownloadIcon(), func() {
 		// 动画结束后执行的逻辑
 		if len(ov.selectedObjectIDs) == 0 {
-			ShowToast(ov.window, "请至少选择一个要下载的项目。")
+			ShowToast(ov.window, T("请至少选择一个要下载的项目。"))
 			return
 		}
 
@@ -1671,145 +2197,7 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 	}
 	ov.deleteButton = widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
 		// 动画结束后执行的逻辑
-		selectedCount := len(ov.selectedObjectIDs)
-		if selectedCount == 0 {
-			ShowToast(ov.window, "请先选择要删除的文件或文件夹。")
-			return
-		}
-
-		dialog.ShowConfirm("确认删除", fmt.Sprintf("确定要删除选中的 %d 个项目吗？", selectedCount), func(confirmed bool) {
-			if confirmed {
-				go func() {
-					// --- 为删除操作进行初步扫描以获取项目总数 ---
-					scanProgressDialog := dialog.NewProgressInfinite("正在准备删除", "正在扫描待删除项目...", ov.window)
-					scanProgressDialog.Show()
-
-					var totalItemsToDelete int32 = 0
-					var scanErrors []error
-					var scanWg sync.WaitGroup
-					var scanMu sync.Mutex
-
-					itemsToProcess := make(chan s3client.S3Object, selectedCount)
-
-					// 使用选中的项目填充通道
-					for id := range ov.selectedObjectIDs {
-						items := ov.getDisplayedObjects()
-						if id < len(items) {
-							itemsToProcess <- items[id]
-						}
-					}
-					close(itemsToProcess)
-
-					// 用于扫描的工作者 goroutines
-					numScanWorkers := 5 // 可根据需要调整
-					for i := 0; i < numScanWorkers; i++ {
-						scanWg.Add(1)
-						go func() {
-							defer scanWg.Done()
-							for item := range itemsToProcess {
-								if item.IsFolder {
-									keys, err := ov.s3Client.ListAllKeysUnderPrefix(ov.currentBucket, item.Key)
-									scanMu.Lock()
-									if err != nil {
-										scanErrors = append(scanErrors, fmt.Errorf("扫描文件夹 '%s' 失败: %w", item.Name, err))
-									} else {
-										totalItemsToDelete += int32(len(keys)) // 添加文件夹内的所有键
-									}
-									scanMu.Unlock()
-								} else {
-									scanMu.Lock()
-									totalItemsToDelete++ // 文件本身
-									scanMu.Unlock()
-								}
-							}
-						}()
-					}
-					scanWg.Wait()
-					fyne.Do(func() {
-						scanProgressDialog.Hide()
-					})
-
-					if len(scanErrors) > 0 {
-						fyne.Do(func() {
-							dialog.ShowError(fmt.Errorf("扫描部分项目失败: %v", scanErrors[0]), ov.window) // 显示第一个错误
-						})
-						return
-					}
-
-					if totalItemsToDelete == 0 {
-						fyne.Do(func() {
-							dialog.ShowInformation("提示", "没有可删除的项目。", ov.window)
-						})
-						return
-					}
-
-					// --- 执行实际删除操作并显示进度条 ---
-					deleteProgressDialog := dialog.NewProgress("正在删除", "正在删除项目...", ov.window)
-					deleteProgressDialog.Show()
-
-					var currentDeletedItems int32 = 0
-					var deletionWg sync.WaitGroup
-					var deletionMu sync.Mutex
-					var failedDeletions []string
-
-					// 用于删除项目的通道（可以是文件或文件夹）
-					itemsToDeleteChannel := make(chan s3client.S3Object, selectedCount)
-					for id := range ov.selectedObjectIDs {
-						items := ov.getDisplayedObjects()
-						if id < len(items) {
-							itemsToDeleteChannel <- items[id]
-						}
-					}
-					close(itemsToDeleteChannel)
-
-					numDeleteWorkers := 10 // 根据需要进行调整
-					for i := 0; i < numDeleteWorkers; i++ {
-						deletionWg.Add(1)
-						go func() {
-							defer deletionWg.Done()
-							for selectedObject := range itemsToDeleteChannel {
-								var err error
-								if selectedObject.IsFolder {
-									s3Prefix := selectedObject.Key
-									if !strings.HasSuffix(s3Prefix, "/") {
-										s3Prefix += "/"
-									}
-									// 调用更新进度的新函数
-									err = ov.deleteFolderAndContentsWithProgress(ov.currentBucket, s3Prefix, &currentDeletedItems, &deletionMu, deleteProgressDialog, totalItemsToDelete)
-								} else {
-									err = ov.s3Client.DeleteObject(ov.currentBucket, selectedObject.Key)
-									deletionMu.Lock()
-									currentDeletedItems++
-									fyne.Do(func() { deleteProgressDialog.SetValue(float64(currentDeletedItems) / float64(totalItemsToDelete)) })
-									deletionMu.Unlock()
-								}
-
-								if err != nil {
-									deletionMu.Lock()
-									failedDeletions = append(failedDeletions, selectedObject.Name)
-									deletionMu.Unlock()
-									log.Printf("删除项目 '%s' 失败: %v", selectedObject.Name, err)
-								}
-							}
-						}()
-					}
-					deletionWg.Wait()
-					fyne.Do(func() {
-						deleteProgressDialog.Hide()
-					})
-
-					fyne.Do(func() {
-						if len(failedDeletions) > 0 {
-							dialog.ShowError(fmt.Errorf("部分项目删除失败: %s", strings.Join(failedDeletions, ", ")), ov.window)
-						} else {
-							ShowToast(ov.window, fmt.Sprintf("%d 个项目已成功删除。", selectedCount))
-						}
-						ov.resetPagingAndSelection()
-						ov.loadObjects()
-					})
-				}()
-			}
-		}, ov.window)
+		ov.confirmDeleteSelected()
 	})
 
 	// 为按钮添加点击动画
@@ -1827,20 +2215,7 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 
 	ov.viewSwitchButton = widget.NewButtonWithIcon("", theme.GridIcon(), func() {
 		// 动画结束后执行的逻辑
-		if ov.viewMode == listViewMode {
-			ov.viewMode = gridViewMode
-			ov.viewSwitchButton.SetIcon(theme.ListIcon())
-		} else {
-			ov.viewMode = listViewMode
-			ov.viewSwitchButton.SetIcon(theme.GridIcon())
-		}
-
-		// 通过回调通知父级保存视图偏好
-		if ov.OnViewModeChanged != nil && ov.currentServiceAlias != "" {
-			go ov.OnViewModeChanged(ov.currentServiceAlias, ov.viewMode)
-		}
-
-		ov.refreshObjectView()
+		ov.toggleViewMode()
 	})
 
 	// 为按钮添加点击动画
@@ -1855,12 +2230,45 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 		}
 	}
 
-	fileOpsButtons := container.NewHBox(createFolderButton, uploadButton, ov.downloadButton, ov.deleteButton, ov.viewSwitchButton)
+	findDuplicatesButton := widget.NewButtonWithIcon("", theme.SearchReplaceIcon(), func() {
+		ov.startDuplicateScan(ov.currentPrefix)
+	})
+
+	ov.flatViewCheck = widget.NewCheck(T("平铺视图"), func(checked bool) {
+		ov.flatView = checked
+		ov.resetPagingAndSelection()
+		ov.loadObjects()
+	})
+
+	ov.foldersFirstCheck = widget.NewCheck(T("文件夹优先"), func(checked bool) {
+		ov.SetFoldersFirst(checked)
+		if ov.OnFoldersFirstChanged != nil && ov.currentServiceAlias != "" {
+			ov.OnFoldersFirstChanged(ov.currentServiceAlias, checked)
+		}
+		ov.resetPagingAndSelection()
+		ov.loadObjects()
+	})
+	ov.foldersFirstCheck.Checked = ov.foldersFirst
 
-	topBar := container.NewBorder(nil, nil, ov.breadcrumbContainer, fileOpsButtons, ov.searchEntry)
+	bookmarksButton := ov.newBookmarksButton()
+
+	ov.refreshButton = widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), ov.refreshCurrentFolder)
+
+	ov.toolbarButtons = []fyne.Disableable{createFolderButton, createTextFileButton, uploadButton, findDuplicatesButton, bookmarksButton, ov.flatViewCheck, ov.foldersFirstCheck, ov.refreshButton}
+
+	fileOpsButtons := container.NewHBox(ov.refreshButton, createFolderButton, createTextFileButton, uploadButton, ov.downloadButton, ov.deleteButton, findDuplicatesButton, bookmarksButton, ov.flatViewCheck, ov.foldersFirstCheck, ov.viewSwitchButton)
+
+	ov.searchBucketCheck = widget.NewCheck(T("搜索整个存储桶"), ov.SetRecursiveSearch)
+	ov.searchBucketCheck.Checked = ov.recursiveSearch
+	searchBar := container.NewBorder(nil, nil, nil, ov.searchBucketCheck, ov.searchEntry)
+
+	ov.upButton = widget.NewButtonWithIcon("", theme.MoveUpIcon(), ov.goToParentFolder)
+	ov.updateUpButton()
+
+	topBar := container.NewBorder(nil, nil, container.NewHBox(ov.upButton, ov.breadcrumbContainer), fileOpsButtons, searchBar)
 
 	// 将顶部栏、加载指示器和分隔符组合在一起
-	topContent := container.NewVBox(topBar, ov.loadingIndicator, widget.NewSeparator())
+	topContent := container.NewVBox(topBar, ov.newTypeFilterBar(), ov.loadingIndicator, widget.NewSeparator())
 
 	// --- 分页控件 ---
 	ov.prevButton = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
@@ -1908,18 +2316,21 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 	ov.pageSizeEntry.OnSubmitted = func(s string) {
 		ps, err := strconv.Atoi(s)
 		if err != nil || ps < 0 {
-			dialog.ShowError(fmt.Errorf("无效的页面大小"), ov.window)
+			dialog.ShowError(errors.New(T("无效的页面大小")), ov.window)
 			ov.pageSizeEntry.SetText(strconv.Itoa(ov.pageSize))
 			return
 		}
 		ov.pageSize = ps
+		if ov.OnPageSizeChanged != nil && ov.currentServiceAlias != "" {
+			ov.OnPageSizeChanged(ov.currentServiceAlias, ps)
+		}
 		ov.resetPagingAndSelection()
 		ov.loadObjects()
 	}
 
 	pagingControls := container.NewHBox(
 		layout.NewSpacer(),
-		widget.NewLabel("每页显示:"),
+		widget.NewLabel(T("每页显示:")),
 		ov.pageSizeEntry,
 		ov.prevButton,
 		ov.pageInfoLabel,
@@ -1935,8 +2346,119 @@ func (ov *ObjectsView) GetContent() fyne.CanvasObject {
 	ov.mainContent = container.NewMax()
 	ov.refreshObjectView() // 初始视图
 
-	// 主布局，顶部是组合控件，中间是主内容
-	return container.NewBorder(topContent, statusBar, nil, nil, ov.mainContent)
+	// 主布局，顶部是组合控件，中间是主内容，底部是传输面板和状态栏
+	return container.NewBorder(topContent, container.NewVBox(ov.transfers.GetContent(), statusBar), nil, nil, ov.mainContent)
+}
+
+// openInConsole 在默认浏览器中打开当前存储桶指定前缀对应的 Web 控制台页面
+func (ov *ObjectsView) openInConsole(prefix string) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		ShowToast(ov.window, T("请先选择一个存储桶。"))
+		return
+	}
+	consoleURL, err := ov.s3Client.ConsoleURL(ov.currentBucket, prefix)
+	if err != nil {
+		dialog.ShowError(err, ov.window)
+		return
+	}
+	u, err := url.Parse(consoleURL)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(T("生成控制台地址失败: %v"), err), ov.window)
+		return
+	}
+	if err := fyne.CurrentApp().OpenURL(u); err != nil {
+		dialog.ShowError(fmt.Errorf(T("无法在浏览器中打开: %v"), err), ov.window)
+	}
+}
+
+// confirmOverwrite 检查目标 key 是否已存在，存在时弹出确认框，确认后（或不存在时）执行 proceed。
+// 与上传时自动重命名的逻辑不同，该方法用于需要写回同一 key 的场景（新建/编辑文本文件）。
+// 关闭覆盖确认时不检查直接执行。proceed 在 UI 线程中调用。
+func (ov *ObjectsView) confirmOverwrite(key string, proceed func()) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	if !confirmOverwriteSetting() {
+		proceed()
+		return
+	}
+	bucket := ov.currentBucket
+	go func() {
+		exists, err := ov.s3Client.ObjectExists(bucket, key)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(T("检查对象是否存在失败: %v"), err), ov.window)
+				return
+			}
+			if !exists {
+				proceed()
+				return
+			}
+			dialog.ShowConfirm(T("确认覆盖"), fmt.Sprintf(T("对象 '%s' 已存在，是否覆盖？"), key), func(confirmed bool) {
+				if confirmed {
+					proceed()
+				}
+			}, ov.window)
+		})
+	}()
+}
+
+// showCreateTextFileDialog 显示新建文本文件对话框，保存时会经过覆盖确认
+func (ov *ObjectsView) showCreateTextFileDialog() {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		ShowToast(ov.window, T("请先选择一个 S3 服务和存储桶。"))
+		return
+	}
+
+	fileNameEntry := widget.NewEntry()
+	fileNameEntry.SetPlaceHolder(T("例如：notes.txt"))
+	contentEntry := widget.NewMultiLineEntry()
+	contentEntry.SetPlaceHolder(T("文件内容"))
+	contentEntry.SetMinRowsVisible(8)
+
+	formContent := container.NewBorder(
+		container.NewVBox(widget.NewLabel(T("文件名:")), fileNameEntry, widget.NewLabel(T("内容:"))),
+		nil, nil, nil,
+		contentEntry,
+	)
+
+	d := dialog.NewCustomConfirm(T("新建文本文件"), T("保存"), T("取消"), formContent, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		fileName := strings.TrimSpace(fileNameEntry.Text)
+		if fileName == "" || strings.Contains(fileName, "/") {
+			ShowToast(ov.window, T("文件名不能为空，且不能包含 '/'。"))
+			return
+		}
+		key := ov.currentPrefix + fileName
+		data := []byte(contentEntry.Text)
+		ov.confirmOverwrite(key, func() {
+			ov.saveTextObject(key, data, func() {
+				ShowToast(ov.window, fmt.Sprintf(T("文件 '%s' 已保存。"), fileName))
+				ov.loadObjects()
+			})
+		})
+	}, ov.window)
+	d.Resize(fyne.NewSize(500, 400))
+	d.Show()
+}
+
+// saveTextObject 将文本内容上传到指定 key，成功后在 UI 线程中调用 onSuccess
+func (ov *ObjectsView) saveTextObject(key string, data []byte, onSuccess func()) {
+	bucket := ov.currentBucket
+	go func() {
+		err := ov.s3Client.UploadObject(context.Background(), bucket, key, bytes.NewReader(data), int64(len(data)), s3client.UploadOptions{})
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(T("保存文件失败: %v"), err), ov.window)
+				return
+			}
+			if onSuccess != nil {
+				onSuccess()
+			}
+		})
+	}()
 }
 
 // findAvailableObjectKey 检查目标key是否存在，如果存在，则返回一个带递增数字的新key。
@@ -1966,211 +2488,101 @@ func (ov *ObjectsView) findAvailableObjectKey(s3Key string) (string, error) {
 	}
 }
 
-// startUploadProcess 启动上传流程 (文件或文件夹)
+// startUploadProcess 启动上传流程 (文件或文件夹)，上传到当前目录
 func (ov *ObjectsView) startUploadProcess(localPaths []string) {
-	scanProgressDialog := dialog.NewProgressInfinite("正在准备上传", "正在扫描文件...", ov.window)
+	ov.startUploadProcessTo(localPaths, ov.currentPrefix)
+}
+
+// startUploadProcessTo 启动上传流程，将文件或文件夹上传到指定前缀下
+func (ov *ObjectsView) startUploadProcessTo(localPaths []string, targetPrefix string) {
+	ov.startUploadProcessWithOptions(localPaths, targetPrefix, s3client.UploadOptions{})
+}
+
+// startUploadProcessWithOptions 启动上传流程，上传的每个文件都附加 opts 中的请求头和元数据。
+// 扫描并确认后将上传加入传输面板，在后台进行。
+func (ov *ObjectsView) startUploadProcessWithOptions(localPaths []string, targetPrefix string, opts s3client.UploadOptions) {
+	scanProgressDialog := dialog.NewProgressInfinite(T("正在准备上传"), T("正在扫描文件..."), ov.window)
 	fyne.Do(func() {
 		scanProgressDialog.Show()
 	})
 
-	var totalSize int64
-	var filesToUpload []struct {
-		LocalPath string
-		S3Key     string
-		Size      int64
-	}
-	var foldersToCreate []string // 用于创建文件夹的 S3 key
-	var scanErrors []error
-	var scanWg sync.WaitGroup
-	var scanMu sync.Mutex
+	// 时间戳命名只作用于直接选择的文件，文件夹内的文件保持原有结构
+	timestampEnabled, timestampTemplate := uploadTimestampSetting()
+	naming := uploadNaming{timestamp: timestampEnabled, template: timestampTemplate, time: time.Now(), skipUnchanged: uploadSkipUnchangedSetting()}
 
 	// 步骤 1: 扫描所有文件并计算总大小
-	for _, localPath := range localPaths {
-		scanWg.Add(1)
-		go func(path string) {
-			defer scanWg.Done()
-			info, err := os.Stat(path)
-			if err != nil {
-				scanMu.Lock()
-				scanErrors = append(scanErrors, fmt.Errorf("无法获取项目信息 '%s': %w", filepath.Base(path), err))
-				scanMu.Unlock()
-				return
-			}
-
-			if info.IsDir() {
-				baseFolderName := filepath.Base(path)
-
-				availableFolderName, err := ov.findAvailableFolderName(baseFolderName)
-				if err != nil {
-					scanMu.Lock()
-					scanErrors = append(scanErrors, fmt.Errorf("查找可用文件夹名称失败 '%s': %w", baseFolderName, err))
-					scanMu.Unlock()
-					return
-				}
-
-				err = filepath.Walk(path, func(p string, i os.FileInfo, err error) error {
-					if err != nil {
-						return err
-					}
-					relPath, err := filepath.Rel(path, p)
-					if err != nil {
-						return err
-					}
-					s3Key := filepath.Join(ov.currentPrefix, availableFolderName, relPath)
-					s3Key = strings.ReplaceAll(s3Key, string(os.PathSeparator), "/")
-
-					scanMu.Lock()
-					if i.IsDir() {
-						foldersToCreate = append(foldersToCreate, s3Key+"/")
-					} else {
-						filesToUpload = append(filesToUpload, struct {
-							LocalPath string
-							S3Key     string
-							Size      int64
-						}{LocalPath: p, S3Key: s3Key, Size: i.Size()})
-						totalSize += i.Size()
-					}
-					scanMu.Unlock()
-					return nil
-				})
-				if err != nil {
-					scanMu.Lock()
-					scanErrors = append(scanErrors, fmt.Errorf("遍历文件夹 '%s' 失败: %w", filepath.Base(path), err))
-					scanMu.Unlock()
-				}
-			} else {
-				fileName := filepath.Base(path)
-				s3Key := ov.currentPrefix + fileName
-
-				availableKey, err := ov.findAvailableObjectKey(s3Key)
-				if err != nil {
-					scanMu.Lock()
-					scanErrors = append(scanErrors, fmt.Errorf("查找可用对象key失败 '%s': %w", s3Key, err))
-					scanMu.Unlock()
-					return
-				}
-
-				scanMu.Lock()
-				filesToUpload = append(filesToUpload, struct {
-					LocalPath string
-					S3Key     string
-					Size      int64
-				}{LocalPath: path, S3Key: availableKey, Size: info.Size()})
-				totalSize += info.Size()
-				scanMu.Unlock()
-			}
-		}(localPath)
-	}
-	scanWg.Wait()
+	plan, scanErrors := ov.buildUploadPlan(localPaths, targetPrefix, naming)
 	fyne.Do(func() {
 		scanProgressDialog.Hide()
 	})
 
 	if len(scanErrors) > 0 {
 		fyne.Do(func() {
-			dialog.ShowError(fmt.Errorf("扫描部分项目失败: %s", scanErrors[0].Error()), ov.window)
+			dialog.ShowError(fmt.Errorf(T("扫描部分项目失败: %s"), scanErrors[0].Error()), ov.window)
 		})
 		return
 	}
 
-	if len(filesToUpload) == 0 && len(foldersToCreate) == 0 {
+	if len(plan.files) == 0 && len(plan.folders) == 0 {
 		fyne.Do(func() {
-			ShowToast(ov.window, "没有可上传的项目。")
+			if plan.skipped > 0 {
+				ShowToast(ov.window, fmt.Sprintf(T("所有文件均未更改，已跳过 %d 个文件。"), plan.skipped))
+			} else {
+				ShowToast(ov.window, T("没有可上传的项目。"))
+			}
 		})
 		return
 	}
 
-	// 步骤 2: 执行上传并显示进度条
-	uploadProgressDialog := dialog.NewProgress("正在上传", "正在上传项目...", ov.window)
-	fyne.Do(func() {
-		uploadProgressDialog.Show()
-	})
+	plan.options = opts
 
-	var bytesUploaded int64
-	var uploadWg sync.WaitGroup
-	var uploadMu sync.Mutex
-	var failedUploads []string
-	numWorkers := 10
-
-	// 1. 并行创建所有文件夹
-	if len(foldersToCreate) > 0 {
-		folderChannel := make(chan string, len(foldersToCreate))
-		for i := 0; i < numWorkers; i++ {
-			uploadWg.Add(1)
-			go func() {
-				defer uploadWg.Done()
-				for s3Key := range folderChannel {
-					err := ov.s3Client.CreateFolder(ov.currentBucket, s3Key)
-					if err != nil {
-						log.Printf("创建文件夹 %s 失败: %v", s3Key, err)
-						uploadMu.Lock()
-						failedUploads = append(failedUploads, s3Key)
-						uploadMu.Unlock()
-					}
-				}
-			}()
-		}
-		for _, key := range foldersToCreate {
-			folderChannel <- key
-		}
-		close(folderChannel)
-		uploadWg.Wait() // 等待文件夹创建完成后再上传文件
+	if !ov.confirmUploadOverwrites(plan.overwrites) {
+		return
 	}
 
-	// 2. 并行上传所有文件
-	if len(filesToUpload) > 0 {
-		fileChannel := make(chan struct {
-			LocalPath string
-			S3Key     string
-			Size      int64
-		}, len(filesToUpload))
-
-		for i := 0; i < numWorkers; i++ {
-			uploadWg.Add(1)
-			go func() {
-				defer uploadWg.Done()
-				for fileInfo := range fileChannel {
-					err := ov.uploadSingleFile(fileInfo.LocalPath, fileInfo.S3Key, fileInfo.Size, totalSize, &bytesUploaded, uploadProgressDialog)
-					if err != nil {
-						uploadMu.Lock()
-						failedUploads = append(failedUploads, filepath.Base(fileInfo.LocalPath))
-						uploadMu.Unlock()
-						log.Printf("上传文件 %s 失败: %v", fileInfo.LocalPath, err)
-					}
-				}
-			}()
-		}
-		for _, f := range filesToUpload {
-			fileChannel <- f
-		}
-		close(fileChannel)
-		uploadWg.Wait()
+	// 上传很大的文件前请求确认，并显示文件大小
+	if message := largeUploadsMessage(plan.files); message != "" && !ov.confirmLargeUploads(message) {
+		return
 	}
 
+	// 步骤 2: 在传输面板中后台上传。上传不再占用耗时操作，用户可以继续浏览或开始其它传输
+	names := make([]string, len(localPaths))
+	for i, localPath := range localPaths {
+		names[i] = filepath.Base(localPath)
+	}
+	target := ov.currentTransferTarget()
 	fyne.Do(func() {
-		uploadProgressDialog.Hide()
+		task := ov.transfers.add(transferTitle(T("上传"), names), theme.UploadIcon())
+		go ov.runUpload(task, target, plan)
 	})
+}
+
+// runUpload 执行上传计划，在 task 中显示进度和结果。在后台 goroutine 中调用。
+func (ov *ObjectsView) runUpload(task *transferTask, target transferTarget, plan uploadPlan) {
+	var bytesUploaded int64
+	progress := &transferProgress{total: plan.totalSize, done: &bytesUploaded, dialog: task}
+	progress.rate = newTransferRate(plan.totalSize, task.SetStatus)
+	completed, failedUploads := target.executeUploadPlan(task.ctx, plan, progress, transferRetriesSetting())
 
 	fyne.Do(func() {
-		if len(failedUploads) > 0 {
-			const maxDisplayedFailures = 5
-			displayMessage := "部分项目上传失败: "
-			if len(failedUploads) > maxDisplayedFailures {
-				displayMessage += strings.Join(failedUploads[:maxDisplayedFailures], ", ") + fmt.Sprintf(" 等 %d 个文件", len(failedUploads))
-			} else {
-				displayMessage += strings.Join(failedUploads, ", ")
-			}
-			dialog.ShowError(fmt.Errorf(displayMessage), ov.window)
-		} else {
-			dialog.ShowInformation("成功", "所有项目上传完成。", ov.window)
-		}
-		ov.loadObjects()
+		// 取消前已经失败的项目同样可以在详情中查看
+		switch {
+		case task.cancelled():
+			task.finish(transferCancelled, fmt.Sprintf(T("已取消上传，已完成 %d / %d 个文件。"), completed, len(plan.files)), failedUploads)
+		case len(failedUploads) > 0:
+			task.finish(transferFailed, T("部分项目上传失败"), failedUploads)
+		case plan.skipped > 0:
+			task.finish(transferSucceeded, fmt.Sprintf(T("所有项目上传完成，跳过了 %d 个未更改的文件。"), plan.skipped), nil)
+		default:
+			task.finish(transferSucceeded, T("所有项目上传完成。"), nil)
+		}
+		ov.reloadIfShowing(target)
 	})
 }
 
 // startDownloadProcess 启动下载流程
 func (ov *ObjectsView) startDownloadProcess(localBasePath string) {
-	scanProgressDialog := dialog.NewProgressInfinite("正在准备下载", "正在扫描待下载项目...", ov.window)
+	scanProgressDialog := dialog.NewProgressInfinite(T("正在准备下载"), T("正在扫描待下载项目..."), ov.window)
 	scanProgressDialog.Show()
 
 	var totalDownloadSize int64
@@ -2184,15 +2596,17 @@ func (ov *ObjectsView) startDownloadProcess(localBasePath string) {
 
 	// 步骤 1: 扫描所有选中的项目以确定总大小和要下载的文件
 	objectsToScan := make(chan s3client.S3Object, len(ov.selectedObjectIDs))
+	var names []string // 显示在传输面板中的名称
 	for id := range ov.selectedObjectIDs {
 		items := ov.getDisplayedObjects()
 		if id < len(items) {
 			objectsToScan <- items[id]
+			names = append(names, items[id].Name)
 		}
 	}
 	close(objectsToScan)
 
-	numScanWorkers := 5 // 根据需要进行调整
+	numScanWorkers := min(maxScanWorkers, transferWorkers)
 	for i := 0; i < numScanWorkers; i++ {
 		scanWg.Add(1)
 		go func() {
@@ -2238,67 +2652,104 @@ func (ov *ObjectsView) startDownloadProcess(localBasePath string) {
 
 	if len(scanErrors) > 0 {
 		fyne.Do(func() {
-			dialog.ShowError(fmt.Errorf("扫描部分项目失败: %s", scanErrors[0].Error()), ov.window)
+			dialog.ShowError(fmt.Errorf(T("扫描部分项目失败: %s"), scanErrors[0].Error()), ov.window)
 		})
 		return
 	}
 
 	if len(filesToDownload) == 0 {
 		fyne.Do(func() {
-			ShowToast(ov.window, "没有可下载的项目。")
+			ShowToast(ov.window, T("没有可下载的项目。"))
 		})
 		return
 	}
 
-	// 步骤 2: 执行下载并显示进度条
-	downloadProgressDialog := dialog.NewProgress("正在下载", "正在下载项目...", ov.window)
-	downloadProgressDialog.Show()
+	// 步骤 2: 在传输面板中后台下载
+	ov.startDownloads(transferTitle(T("下载"), names), filesToDownload, totalDownloadSize, localBasePath)
+}
 
+// startDownloads 将已扫描的文件加入传输面板并在后台下载到 localBasePath 下。
+// 下载不占用耗时操作，用户可以继续浏览或开始其它传输。
+func (ov *ObjectsView) startDownloads(name string, files []struct {
+	S3Object  s3client.S3Object
+	LocalPath string
+}, totalSize int64, localBasePath string) {
+	target := ov.currentTransferTarget()
+	fyne.Do(func() {
+		task := ov.transfers.add(name, theme.DownloadIcon())
+		go ov.runDownloads(task, target, files, totalSize, localBasePath)
+	})
+}
+
+// runDownloads 下载 files 中的文件，在 task 中显示进度和结果。在后台 goroutine 中调用。
+func (ov *ObjectsView) runDownloads(task *transferTask, target transferTarget, files []struct {
+	S3Object  s3client.S3Object
+	LocalPath string
+}, totalSize int64, localBasePath string) {
 	var bytesDownloaded int64
+	progress := &transferProgress{total: totalSize, done: &bytesDownloaded, dialog: task}
+	progress.rate = newTransferRate(totalSize, task.SetStatus)
+	completed, failedDownloads := target.executeDownloads(task.ctx, files, progress, transferRetriesSetting())
+
+	fyne.Do(func() {
+		switch {
+		case task.cancelled():
+			task.finish(transferCancelled, fmt.Sprintf(T("已取消下载，已完成 %d / %d 个文件。"), completed, len(files)), failedDownloads)
+		case len(failedDownloads) > 0:
+			task.finish(transferFailed, T("部分项目下载失败"), failedDownloads)
+		default:
+			task.finish(transferSucceeded, T("所有项目下载完成。"), nil)
+			ov.openDownloaded(localBasePath, downloadedPaths(files))
+		}
+	})
+}
+
+// executeDownloads 并行下载 files 中的文件，返回下载完成的文件数和重试后仍然失败的项目。
+// ctx 被取消后不再开始新的文件，因取消而中止的文件既不计为完成也不计为失败。
+func (t transferTarget) executeDownloads(ctx context.Context, files []struct {
+	S3Object  s3client.S3Object
+	LocalPath string
+}, progress *transferProgress, retries int) (int, []transferFailure) {
 	var downloadWg sync.WaitGroup
 	var downloadMu sync.Mutex
-	var failedDownloads []string
-	numDownloadWorkers := 10
+	var failedDownloads []transferFailure
+	completed := 0
+	numDownloadWorkers := transferWorkers
 
 	downloadChannel := make(chan struct {
 		S3Object  s3client.S3Object
 		LocalPath string
-	}, len(filesToDownload))
+	}, len(files))
 
 	for i := 0; i < numDownloadWorkers; i++ {
 		downloadWg.Add(1)
 		go func() {
 			defer downloadWg.Done()
 			for fileInfo := range downloadChannel {
-				err := ov.downloadFile(fileInfo.S3Object, fileInfo.LocalPath, totalDownloadSize, &bytesDownloaded, downloadProgressDialog)
-				if err != nil {
-					downloadMu.Lock()
-					failedDownloads = append(failedDownloads, fileInfo.S3Object.Name)
-					downloadMu.Unlock()
+				if ctx.Err() != nil {
+					continue // 已取消，丢弃剩余文件
+				}
+				attemptErrors, err := transferWithRetry(progress, retries, fileInfo.S3Object.Key, func(attempt *transferAttempt) error {
+					return t.downloadFile(ctx, fileInfo.S3Object, fileInfo.LocalPath, attempt)
+				})
+				downloadMu.Lock()
+				if err != nil && ctx.Err() == nil {
+					failedDownloads = append(failedDownloads, transferFailure{name: fileInfo.S3Object.Name, errors: attemptErrors})
 					log.Printf("下载文件 '%s' 失败: %v", fileInfo.S3Object.Name, err)
+				} else if err == nil {
+					completed++
 				}
+				downloadMu.Unlock()
 			}
 		}()
 	}
 
-	for _, f := range filesToDownload {
+	for _, f := range files {
 		downloadChannel <- f
 	}
 	close(downloadChannel)
-
 	downloadWg.Wait()
-	fyne.Do(func() {
-		downloadProgressDialog.Hide()
-	})
-
-	fyne.Do(func() {
-		if len(failedDownloads) > 0 {
-			dialog.ShowError(fmt.Errorf("部分项目下载失败: %s", strings.Join(failedDownloads, ", ")), ov.window)
-		} else {
-			ShowToast(ov.window, "所有项目下载完成。")
-		}
-		ov.loadObjects()
-	})
+	return completed, failedDownloads
 }
 
 // openSystemFolderSelector 打开系统文件管理器让用户选择下载目录
@@ -2313,41 +2764,205 @@ func (ov *ObjectsView) openSystemFolderSelector() {
 			return
 		}
 		// 开始下载过程
-		go ov.startDownloadProcess(uri.Path())
+		ov.runOperation(T("下载"), func() { ov.startDownloadProcess(uri.Path()) })
 	}, ov.window)
 }
 
 // downloadFile 下载单个文件
-func (ov *ObjectsView) downloadFile(obj s3client.S3Object, localPath string, totalSize int64, bytesDownloaded *int64, progressDialog *dialog.ProgressDialog) error {
+// 大文件会优先使用多线程范围下载，服务端不支持时回退为单流下载。数据先写入未完成下载的临时文件，
+// 完成后再重命名为 localPath；下载失败时保留已下载的部分，重试或再次下载同一版本的对象时从中断处继续。
+// ctx 被取消时删除未下载完成的数据。
+func (t transferTarget) downloadFile(ctx context.Context, obj s3client.S3Object, localPath string, attempt *transferAttempt) (err error) {
 	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
 		return fmt.Errorf("创建本地目录失败: %w", err)
 	}
+	partial := newPartialDownload(localPath)
+	defer func() {
+		if err != nil && ctx.Err() != nil {
+			partial.discard()
+		}
+	}()
+
+	if obj.Size >= rangeDownloadThreshold {
+		err := t.downloadFileInRanges(ctx, obj, partial, attempt)
+		if err == nil {
+			return partial.finish()
+		}
+		if !errors.Is(err, errRangeFallback) {
+			return err
+		}
+		log.Printf("文件 '%s' 无法使用范围下载，回退为单流下载: %v", obj.Key, err)
+	}
+
+	if err := t.downloadFileStream(ctx, obj, partial, attempt); err != nil {
+		return err
+	}
+	return partial.finish()
+}
+
+// downloadFileStream 单流下载对象到 partial 的数据文件。上次下载中断时，先确认对象的大小和 ETag 没有变化，
+// 再从已下载的位置继续；对象已修改或服务端不支持范围请求时从头下载。
+func (t transferTarget) downloadFileStream(ctx context.Context, obj s3client.S3Object, partial *partialDownload, attempt *transferAttempt) error {
+	var offset int64
+	if partial.resume(obj.Size, obj.ETag, false) {
+		if fi, err := os.Stat(partial.dataPath()); err == nil && fi.Size() < obj.Size {
+			if info, err := t.client.StatObject(t.bucket, obj.Key); err == nil && info.Size == obj.Size && info.ETag == obj.ETag {
+				offset = fi.Size()
+			}
+		}
+	}
 
-	localFile, err := os.Create(localPath)
+	var body io.ReadCloser
+	if offset > 0 {
+		var err error
+		body, err = t.client.DownloadObjectRange(ctx, t.bucket, obj.Key, offset, -1)
+		switch {
+		case errors.Is(err, s3client.ErrRangeNotSupported):
+			offset = 0
+		case err != nil:
+			return fmt.Errorf("从 S3 下载失败: %w", err)
+		default:
+			log.Printf("从第 %d 字节继续下载 '%s'", offset, obj.Key)
+		}
+	}
+	if offset == 0 {
+		if err := partial.start(obj.Size, obj.ETag, false); err != nil {
+			return err
+		}
+		var err error
+		body, err = t.client.DownloadObject(ctx, t.bucket, obj.Key)
+		if err != nil {
+			return fmt.Errorf("从 S3 下载失败: %w", err)
+		}
+	}
+	defer body.Close()
+
+	localFile, err := os.OpenFile(partial.dataPath(), os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("创建本地文件失败: %w", err)
 	}
 	defer localFile.Close()
+	if err := localFile.Truncate(offset); err != nil {
+		return fmt.Errorf("写入本地文件失败: %w", err)
+	}
+	if _, err := localFile.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("写入本地文件失败: %w", err)
+	}
+	// 已下载的部分直接计入进度，使用进度跟踪器包装 S3 下载的数据流
+	attempt.skip(offset)
+	readerWithProgress := attempt.track(body)
 
-	body, err := ov.s3Client.DownloadObject(ov.currentBucket, obj.Key)
+	_, err = io.Copy(localFile, readerWithProgress)
 	if err != nil {
-		return fmt.Errorf("从 S3 下载失败: %w", err)
+		return fmt.Errorf("写入本地文件失败: %w", err)
 	}
-	defer body.Close()
+	return localFile.Close()
+}
 
-	// 使用进度跟踪器包装 S3 下载的数据流
-	readerWithProgress := NewProgressTracker(body, totalSize, bytesDownloaded, progressDialog)
+// errRangeFallback 表示范围下载不可用，需要回退为单流下载
+var errRangeFallback = errors.New("范围下载不可用")
 
-	_, err = io.Copy(localFile, readerWithProgress)
+// downloadFileInRanges 将大文件拆分为多个字节范围并发下载，并写入预先分配好大小的本地文件的对应偏移处。
+// 每完成一个分段都会记录下来，对象版本未变时再次下载只下载未完成的分段。
+// 当 HeadObject 或范围请求不受支持时返回包装了 errRangeFallback 的错误，已计入的进度会被回退。
+func (t transferTarget) downloadFileInRanges(ctx context.Context, obj s3client.S3Object, partial *partialDownload, attempt *transferAttempt) error {
+	info, err := t.client.StatObject(t.bucket, obj.Key)
 	if err != nil {
-		return fmt.Errorf("写入本地文件失败: %w", err)
+		return fmt.Errorf("%w: %v", errRangeFallback, err)
+	}
+	size := info.Size
+
+	var localFile *os.File
+	resumed := partial.resume(size, info.ETag, true)
+	if resumed {
+		localFile, err = os.OpenFile(partial.dataPath(), os.O_WRONLY, 0644)
+	} else {
+		if err := partial.start(size, info.ETag, true); err != nil {
+			return err
+		}
+		localFile, err = os.Create(partial.dataPath())
+	}
+	if err != nil {
+		return fmt.Errorf("创建本地文件失败: %w", err)
+	}
+	defer localFile.Close()
+	if err := localFile.Truncate(size); err != nil {
+		return fmt.Errorf("预分配本地文件失败: %w", err)
+	}
+
+	type byteRange struct{ start, end int64 }
+	completedParts := partial.completedParts()
+	rangeChannel := make(chan byteRange, size/rangeDownloadPartSize+1)
+	for start := int64(0); start < size; start += rangeDownloadPartSize {
+		end := start + rangeDownloadPartSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		if completedParts[start] {
+			attempt.skip(end - start + 1) // 上次已下载完成的分段
+			continue
+		}
+		rangeChannel <- byteRange{start: start, end: end}
+	}
+	close(rangeChannel)
+	if resumed {
+		log.Printf("继续下载 '%s'，跳过 %d 个已完成的分段", obj.Key, len(completedParts))
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for i := 0; i < rangeDownloadWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range rangeChannel {
+				mu.Lock()
+				failed := firstErr != nil
+				mu.Unlock()
+				if failed {
+					continue // 已有分段失败，丢弃剩余分段
+				}
+
+				body, err := t.client.DownloadObjectRange(ctx, t.bucket, obj.Key, r.start, r.end)
+				if err == nil {
+					var n int64
+					n, err = io.Copy(io.NewOffsetWriter(localFile, r.start), attempt.track(body))
+					body.Close()
+					if err == nil && n != r.end-r.start+1 {
+						err = fmt.Errorf("分段 %d-%d 数据不完整", r.start, r.end)
+					}
+					if err == nil {
+						err = partial.completePart(r.start)
+					}
+				}
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		if errors.Is(firstErr, s3client.ErrRangeNotSupported) {
+			// 回退前撤销已计入的进度，避免单流下载时重复计算
+			attempt.rollback()
+			return fmt.Errorf("%w: %v", errRangeFallback, firstErr)
+		}
+		return fmt.Errorf("范围下载失败: %w", firstErr)
 	}
 	return nil
 }
 
 // downloadCopiedObjects 下载复制的S3对象到本地目录
 func (ov *ObjectsView) downloadCopiedObjects(localBasePath string, objectsToDownload []s3client.S3Object) {
-	scanProgressDialog := dialog.NewProgressInfinite("正在准备下载", "正在计算下载大小...", ov.window)
+	scanProgressDialog := dialog.NewProgressInfinite(T("正在准备下载"), T("正在计算下载大小..."), ov.window)
 	scanProgressDialog.Show()
 
 	var totalDownloadSize int64
@@ -2360,7 +2975,7 @@ func (ov *ObjectsView) downloadCopiedObjects(localBasePath string, objectsToDown
 	var scanMu sync.Mutex
 
 	// 步骤 1: 扫描所有选中的项目以确定总大小和要下载的文件
-	numScanWorkers := 5 // 根据需要进行调整
+	numScanWorkers := min(maxScanWorkers, transferWorkers)
 	objectChannel := make(chan s3client.S3Object, len(objectsToDownload))
 
 	for i := 0; i < numScanWorkers; i++ {
@@ -2413,77 +3028,35 @@ func (ov *ObjectsView) downloadCopiedObjects(localBasePath string, objectsToDown
 
 	if len(scanErrors) > 0 {
 		fyne.Do(func() {
-			dialog.ShowError(fmt.Errorf("扫描部分项目失败: %s", scanErrors[0].Error()), ov.window)
+			dialog.ShowError(fmt.Errorf(T("扫描部分项目失败: %s"), scanErrors[0].Error()), ov.window)
 		})
 		return
 	}
 
 	if len(filesToDownload) == 0 {
 		fyne.Do(func() {
-			ShowToast(ov.window, "没有可下载的项目。")
+			ShowToast(ov.window, T("没有可下载的项目。"))
 		})
 		return
 	}
 
-	// 步骤 2: 执行下载并显示进度条
-	downloadProgressDialog := dialog.NewProgress("正在下载", "正在下载项目...", ov.window)
-	downloadProgressDialog.Show()
-
-	var bytesDownloaded int64
-	var downloadWg sync.WaitGroup
-	var downloadMu sync.Mutex
-	var failedDownloads []string
-	numDownloadWorkers := 10
-
-	downloadChannel := make(chan struct {
-		S3Object  s3client.S3Object
-		LocalPath string
-	}, len(filesToDownload))
-
-	for i := 0; i < numDownloadWorkers; i++ {
-		downloadWg.Add(1)
-		go func() {
-			defer downloadWg.Done()
-			for fileInfo := range downloadChannel {
-				err := ov.downloadFile(fileInfo.S3Object, fileInfo.LocalPath, totalDownloadSize, &bytesDownloaded, downloadProgressDialog)
-				if err != nil {
-					downloadMu.Lock()
-					failedDownloads = append(failedDownloads, fileInfo.S3Object.Name)
-					downloadMu.Unlock()
-					log.Printf("下载文件 '%s' 失败: %v", fileInfo.S3Object.Name, err)
-				}
-			}
-		}()
-	}
-
-	for _, f := range filesToDownload {
-		downloadChannel <- f
+	// 步骤 2: 在传输面板中后台下载
+	names := make([]string, len(objectsToDownload))
+	for i, obj := range objectsToDownload {
+		names[i] = obj.Name
 	}
-	close(downloadChannel)
-
-	downloadWg.Wait()
-	fyne.Do(func() {
-		downloadProgressDialog.Hide()
-	})
-
-	fyne.Do(func() {
-		if len(failedDownloads) > 0 {
-			dialog.ShowError(fmt.Errorf("部分项目下载失败: %s", strings.Join(failedDownloads, ", ")), ov.window)
-		} else {
-			ShowToast(ov.window, "所有项目已下载完成。")
-		}
-	})
+	ov.startDownloads(transferTitle(T("下载"), names), filesToDownload, totalDownloadSize, localBasePath)
 }
 
 // pasteS3Objects 在S3存储桶内复制对象
 func (ov *ObjectsView) pasteS3Objects(objectsToCopy []s3client.S3Object) {
 	if ov.s3Client == nil || ov.currentBucket == "" {
-		dialog.ShowError(fmt.Errorf("未选择S3服务或存储桶"), ov.window)
+		dialog.ShowError(errors.New(T("未选择S3服务或存储桶")), ov.window)
 		return
 	}
 
 	// 显示进度对话框
-	progressDialog := dialog.NewProgressInfinite("正在复制", "正在复制对象...", ov.window)
+	progressDialog := dialog.NewProgressInfinite(T("正在复制"), T("正在复制对象..."), ov.window)
 	progressDialog.Show()
 
 	var wg sync.WaitGroup
@@ -2543,9 +3116,9 @@ func (ov *ObjectsView) pasteS3Objects(objectsToCopy []s3client.S3Object) {
 			for i, err := range errors {
 				errorMessages[i] = err.Error()
 			}
-			dialog.ShowError(fmt.Errorf("部分对象复制失败 (%d/%d):\n%s", errorCount, len(objectsToCopy), strings.Join(errorMessages, "\n")), ov.window)
+			dialog.ShowError(fmt.Errorf(T("部分对象复制失败 (%d/%d):\n%s"), errorCount, len(objectsToCopy), strings.Join(errorMessages, "\n")), ov.window)
 		} else {
-			ShowToast(ov.window, fmt.Sprintf("成功复制 %d 个对象。", successCount))
+			ShowToast(ov.window, fmt.Sprintf(T("成功复制 %d 个对象。"), successCount))
 		}
 
 		// 刷新对象列表
@@ -2594,10 +3167,15 @@ func (ov *ObjectsView) copySingleObject(object s3client.S3Object) error {
 	return nil
 }
 
-// findAvailableFolderName 检查目标前缀中是否存在同名文件夹，如果存在，则返回一个带递增数字的新名称。
+// findAvailableFolderName 检查当前目录中是否存在同名文件夹，如果存在，则返回一个带递增数字的新名称。
 func (ov *ObjectsView) findAvailableFolderName(baseName string) (string, error) {
+	return ov.findAvailableFolderNameIn(ov.currentPrefix, baseName)
+}
+
+// findAvailableFolderNameIn 检查指定前缀中是否存在同名文件夹，如果存在，则返回一个带递增数字的新名称。
+func (ov *ObjectsView) findAvailableFolderNameIn(prefix, baseName string) (string, error) {
 	// 1. 检查原始名称是否可用
-	destKeyPrefix := ov.currentPrefix + baseName + "/"
+	destKeyPrefix := prefix + baseName + "/"
 
 	// 使用 ListAllObjectsUnderPrefix 检查文件夹下是否有内容
 	objects, err := ov.s3Client.ListAllObjectsUnderPrefix(ov.currentBucket, destKeyPrefix)
@@ -2620,7 +3198,7 @@ func (ov *ObjectsView) findAvailableFolderName(baseName string) (string, error)
 	// 2. 如果原始名称不可用，尝试 "baseName(n)"
 	for i := 1; ; i++ {
 		newName := fmt.Sprintf("%s(%d)", baseName, i)
-		destKeyPrefix = ov.currentPrefix + newName + "/"
+		destKeyPrefix = prefix + newName + "/"
 
 		objects, err := ov.s3Client.ListAllObjectsUnderPrefix(ov.currentBucket, destKeyPrefix)
 		if err != nil {
@@ -2651,26 +3229,26 @@ func (ov *ObjectsView) copyFolderRecursive(folder s3client.S3Object) error {
 	newFolderKey := ov.currentPrefix + availableName + "/"
 	log.Printf("准备复制文件夹: %s -> %s", folder.Key, newFolderKey)
 
-	// 列出源文件夹中的所有对象
-	objects, err := ov.s3Client.ListAllObjectsUnderPrefix(ov.currentBucket, folder.Key)
+	// 列出源文件夹中的所有对象键（包括子目录中的对象和文件夹占位对象）
+	keys, err := ov.s3Client.ListAllKeysUnderPrefix(ov.currentBucket, folder.Key)
 	if err != nil {
 		return fmt.Errorf("列出源文件夹 '%s' 内容时出错: %v", folder.Key, err)
 	}
 
 	// 复制每个对象到目标文件夹
-	for _, obj := range objects {
+	for _, key := range keys {
 		// 计算目标对象键
-		relativePath := strings.TrimPrefix(obj.Key, folder.Key)
+		relativePath := strings.TrimPrefix(key, folder.Key)
 		targetKey := newFolderKey + relativePath
 
 		// 因为目标文件夹是全新的，所以我们直接复制，不检查是否存在。
 		// 这会保留源文件夹的结构。
-		err := ov.s3Client.CopyObject(ov.currentBucket, obj.Key, targetKey)
+		err := ov.s3Client.CopyObject(ov.currentBucket, key, targetKey)
 		if err != nil {
 			// 如果单个对象复制失败，记录并继续尝试复制其他对象
-			log.Printf("复制对象 '%s' 到 '%s' 时出错: %v", obj.Key, targetKey, err)
+			log.Printf("复制对象 '%s' 到 '%s' 时出错: %v", key, targetKey, err)
 		} else {
-			log.Printf("成功复制对象: %s -> %s", obj.Key, targetKey)
+			log.Printf("成功复制对象: %s -> %s", key, targetKey)
 		}
 	}
 
@@ -2694,94 +3272,8 @@ func (ov *ObjectsView) deleteFolderAndContents(bucket, prefix string) error {
 	// 3. 将文件夹对象本身添加到列表
 	keysToDelete = append(keysToDelete, prefix)
 
-	// 4. 并行删除对象
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var deletionErrors []error
-
-	deleteChannel := make(chan string, len(keysToDelete))
-	numWorkers := 10 // 合理的并行工作者数量
-
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for key := range deleteChannel {
-				err := ov.s3Client.DeleteObject(bucket, key)
-				if err != nil {
-					mu.Lock()
-					// 存储根错误以供报告
-					if len(deletionErrors) == 0 {
-						deletionErrors = append(deletionErrors, err)
-					}
-					mu.Unlock()
-					log.Printf("删除对象 %s 失败: %v", key, err)
-				}
-			}
-		}()
-	}
-
-	for _, key := range keysToDelete {
-		deleteChannel <- key
-	}
-	close(deleteChannel)
-
-	wg.Wait()
-
-	if len(deletionErrors) > 0 {
-		return fmt.Errorf("删除文件夹 '%s' 时发生错误，部分对象删除失败", prefix)
-	}
-
-	return nil
-}
-
-// deleteFolderAndContentsWithProgress 递归删除文件夹及其所有内容，并更新进度
-func (ov *ObjectsView) deleteFolderAndContentsWithProgress(bucket, prefix string, currentDeletedItems *int32, mu *sync.Mutex, progressDialog *dialog.ProgressDialog, totalItemsToDelete int32) error {
-	keys, err := ov.s3Client.ListAllKeysUnderPrefix(bucket, prefix)
-	if err != nil {
-		return fmt.Errorf("列出文件夹 '%s' 内容失败: %w", prefix, err)
-	}
-
-	// 将文件夹对象本身添加到待删除键的列表中
-	keysToDelete := append(keys, prefix)
-
-	var wg sync.WaitGroup
-	var deletionErrors []error
-
-	deleteChannel := make(chan string, len(keysToDelete))
-	numWorkers := 10
-
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for key := range deleteChannel {
-				err := ov.s3Client.DeleteObject(bucket, key)
-				if err != nil {
-					mu.Lock()
-					if len(deletionErrors) == 0 { // 仅存储第一个错误
-						deletionErrors = append(deletionErrors, err)
-					}
-					mu.Unlock()
-					log.Printf("删除对象 %s 失败: %v", key, err)
-				} else {
-					mu.Lock()
-					*currentDeletedItems++
-					fyne.Do(func() { progressDialog.SetValue(float64(*currentDeletedItems) / float64(totalItemsToDelete)) })
-					mu.Unlock()
-				}
-			}
-		}()
-	}
-
-	for _, key := range keysToDelete {
-		deleteChannel <- key
-	}
-	close(deleteChannel)
-
-	wg.Wait()
-
-	if len(deletionErrors) > 0 {
+	// 4. 分批删除对象
+	if failed := ov.deleteKeysInBatches(context.Background(), bucket, keysToDelete, nil); len(failed) > 0 {
 		return fmt.Errorf("删除文件夹 '%s' 时发生错误，部分对象删除失败", prefix)
 	}
 
@@ -2818,36 +3310,15 @@ func formatBytes(b int64) string {
 	return common.FormatBytes(b)
 }
 
-// filterObjects 根据搜索词过滤对象列表
+// filterObjects 根据搜索词和选中的类型过滤对象列表。开启递归搜索时显示整个存储桶中的搜索结果，
+// 搜索结果尚未就绪时先在后台开始搜索。
 func (ov *ObjectsView) filterObjects(searchTerm string) {
-	if searchTerm == "" {
-		// 如果搜索词为空，显示所有对象
-		ov.filteredObjects = nil
-	} else {
-		// 过滤对象列表
-		ov.filteredObjects = make([]s3client.S3Object, 0)
-		searchTerm = strings.ToLower(searchTerm)
-
-		for _, obj := range ov.objects {
-			// 将对象名称转换为小写进行不区分大小写的搜索
-			if strings.Contains(strings.ToLower(obj.Name), searchTerm) {
-				ov.filteredObjects = append(ov.filteredObjects, obj)
-			}
-		}
-
-		// 对过滤后的对象进行排序，确保文件夹在前
-		sort.Slice(ov.filteredObjects, func(i, j int) bool {
-			// 如果一个是文件夹，另一个是文件，则文件夹排在前面
-			if ov.filteredObjects[i].IsFolder && !ov.filteredObjects[j].IsFolder {
-				return true
-			}
-			if !ov.filteredObjects[i].IsFolder && ov.filteredObjects[j].IsFolder {
-				return false
-			}
-			// 如果两个都是文件夹或都是文件，则按名称排序
-			return ov.filteredObjects[i].Name < ov.filteredObjects[j].Name
-		})
+	if ov.recursiveSearchActive(searchTerm) && !ov.hasSearchResults(searchTerm) {
+		ov.scheduleRecursiveSearch(searchTerm)
+		return
 	}
+	ov.refreshRecursiveSearch()
+	ov.filteredObjects = ov.displayFilter()
 
 	// 重置选择状态
 	ov.selectedObjectIDs = make(map[widget.ListItemID]struct{})
@@ -2858,6 +3329,14 @@ func (ov *ObjectsView) filterObjects(searchTerm string) {
 	ov.refreshObjectView()
 }
 
+// searchText 返回搜索框中的文字，界面未创建时返回空字符串
+func (ov *ObjectsView) searchText() string {
+	if ov.searchEntry == nil {
+		return ""
+	}
+	return ov.searchEntry.Text
+}
+
 // getDisplayedObjects 返回当前应该显示的对象列表（过滤后或全部）
 func (ov *ObjectsView) getDisplayedObjects() []s3client.S3Object {
 	if ov.filteredObjects != nil {
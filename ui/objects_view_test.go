@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+const testBucket = "bucket"
+
+// newTestObjectsView 创建一个使用内存存储、位于 prefix 目录下的对象视图，不创建任何界面
+func newTestObjectsView(store *memStore, prefix string) *ObjectsView {
+	return &ObjectsView{s3Client: store, currentBucket: testBucket, currentPrefix: prefix}
+}
+
+func TestFindAvailableObjectKey(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/a.txt", "1")
+	store.put(testBucket, "docs/a(1).txt", "2")
+	ov := newTestObjectsView(store, "docs/")
+
+	tests := map[string]string{
+		"docs/a.txt":    "docs/a(2).txt",
+		"docs/b.txt":    "docs/b.txt",
+		"docs/a(1).txt": "docs/a(1)(1).txt",
+	}
+	for key, want := range tests {
+		got, err := ov.findAvailableObjectKey(key)
+		if err != nil {
+			t.Fatalf("findAvailableObjectKey(%q) 返回错误: %v", key, err)
+		}
+		if got != want {
+			t.Errorf("findAvailableObjectKey(%q) = %q, 期望 %q", key, got, want)
+		}
+	}
+}
+
+func TestCopySingleObjectRenamesOnCollision(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "src/report.pdf", "new")
+	store.put(testBucket, "dst/report.pdf", "old")
+	ov := newTestObjectsView(store, "dst/")
+
+	if err := ov.copySingleObject(s3client.S3Object{Name: "report.pdf", Key: "src/report.pdf"}); err != nil {
+		t.Fatalf("copySingleObject 返回错误: %v", err)
+	}
+	want := []string{"dst/report(1).pdf", "dst/report.pdf", "src/report.pdf"}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, want) {
+		t.Errorf("复制后的对象 = %v, 期望 %v", got, want)
+	}
+	if string(store.buckets[testBucket]["dst/report.pdf"]) != "old" {
+		t.Error("已存在的对象不应被覆盖")
+	}
+}
+
+func TestFindAvailableFolderNameIn(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "dst/photos/a.jpg", "x") // 没有占位对象，但有内容
+	store.put(testBucket, "dst/photos(1)/", "")    // 只有占位对象的空文件夹
+	ov := newTestObjectsView(store, "dst/")
+
+	got, err := ov.findAvailableFolderNameIn("dst/", "photos")
+	if err != nil {
+		t.Fatalf("findAvailableFolderNameIn 返回错误: %v", err)
+	}
+	if got != "photos(2)" {
+		t.Errorf("findAvailableFolderNameIn = %q, 期望 photos(2)", got)
+	}
+
+	got, err = ov.findAvailableFolderNameIn("dst/", "videos")
+	if err != nil || got != "videos" {
+		t.Errorf("findAvailableFolderNameIn(videos) = %q, %v", got, err)
+	}
+}
+
+func TestCopyFolderRecursive(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "src/photos/", "")
+	store.put(testBucket, "src/photos/a.jpg", "a")
+	store.put(testBucket, "src/photos/2024/b.jpg", "b")
+	store.put(testBucket, "src/photos/empty/", "")
+	store.put(testBucket, "src/photos-other.txt", "不在文件夹中")
+	store.put(testBucket, "dst/photos/old.jpg", "old")
+	ov := newTestObjectsView(store, "dst/")
+
+	folder := s3client.S3Object{Name: "photos", Key: "src/photos/", IsFolder: true}
+	if err := ov.copyFolderRecursive(folder); err != nil {
+		t.Fatalf("copyFolderRecursive 返回错误: %v", err)
+	}
+
+	want := []string{
+		"dst/photos(1)/",
+		"dst/photos(1)/2024/b.jpg",
+		"dst/photos(1)/a.jpg",
+		"dst/photos(1)/empty/",
+		"dst/photos/old.jpg",
+		"src/photos-other.txt",
+		"src/photos/",
+		"src/photos/2024/b.jpg",
+		"src/photos/a.jpg",
+		"src/photos/empty/",
+	}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, want) {
+		t.Errorf("复制后的对象 = %v, 期望 %v", got, want)
+	}
+}
+
+func TestDeleteFolderAndContents(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/", "")
+	store.put(testBucket, "docs/readme.md", "r")
+	store.put(testBucket, "docs/img/logo.png", "l")
+	store.put(testBucket, "docs2/keep.txt", "k")
+	store.put(testBucket, "docs.txt", "k")
+	ov := newTestObjectsView(store, "")
+
+	if err := ov.deleteFolderAndContents(testBucket, "docs/"); err != nil {
+		t.Fatalf("deleteFolderAndContents 返回错误: %v", err)
+	}
+	want := []string{"docs.txt", "docs2/keep.txt"}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, want) {
+		t.Errorf("删除后的对象 = %v, 期望 %v", got, want)
+	}
+}
+
+func TestDeleteFolderAndContentsReportsFailures(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/a.txt", "a")
+	store.put(testBucket, "docs/b.txt", "b")
+	store.deleteErrors["docs/b.txt"] = errors.New("拒绝访问")
+	ov := newTestObjectsView(store, "")
+
+	if err := ov.deleteFolderAndContents(testBucket, "docs/"); err == nil {
+		t.Fatal("部分对象删除失败时应返回错误")
+	}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, []string{"docs/b.txt"}) {
+		t.Errorf("删除后的对象 = %v, 期望只剩删除失败的 docs/b.txt", got)
+	}
+}
+
+func TestStepBackFromEmptyPage(t *testing.T) {
+	next := "token-4"
+	ov := &ObjectsView{currentPage: 3, pageMarkers: []string{"", "token-2", "token-3", "token-4"}, nextPageMarker: &next}
+	if !ov.stepBackFromEmptyPage(nil) {
+		t.Fatal("超出末尾的空页应回退到上一页")
+	}
+	if ov.currentPage != 2 || !reflect.DeepEqual(ov.pageMarkers, []string{"", "token-2"}) || ov.nextPageMarker != nil {
+		t.Errorf("回退后 currentPage=%d pageMarkers=%v nextPageMarker=%v", ov.currentPage, ov.pageMarkers, ov.nextPageMarker)
+	}
+
+	// 第一页为空表示目录本身为空，不回退
+	ov = &ObjectsView{currentPage: 1, pageMarkers: []string{""}}
+	if ov.stepBackFromEmptyPage(nil) || ov.currentPage != 1 {
+		t.Error("第一页为空时不应回退")
+	}
+	ov = &ObjectsView{currentPage: 2, pageMarkers: []string{"", "token-2"}}
+	if ov.stepBackFromEmptyPage([]s3client.S3Object{{Name: "a"}}) || ov.currentPage != 2 {
+		t.Error("非空页不应回退")
+	}
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/config"
+)
+
+// localMinIOTemplate 是引导中提供的本地 MinIO 服务模板，使用 MinIO 的默认地址和默认账号
+var localMinIOTemplate = config.S3ServiceConfig{
+	Alias:     "本地 MinIO",
+	Endpoint:  "http://localhost:9000",
+	AccessKey: "minioadmin",
+	SecretKey: "minioadmin",
+	PathStyle: true,
+	Provider:  "minio",
+}
+
+// maybeShowOnboarding 在首次运行（还没有任何服务）时显示引导对话框，只显示一次
+func (sv *ServicesView) maybeShowOnboarding() {
+	prefs := fyne.CurrentApp().Preferences()
+	if prefs.Bool(prefOnboardingShown) {
+		return
+	}
+	prefs.SetBool(prefOnboardingShown, true)
+
+	intro := widget.NewLabel(T("欢迎使用 S3 资源管理器！窗口分为三栏：\n\n" +
+		"• 左侧：S3 服务列表，添加服务后点击即可连接\n" +
+		"• 中间：所选服务中的存储桶\n" +
+		"• 右侧：存储桶中的文件和文件夹，支持上传、下载、预览和拖拽\n\n" +
+		"首先添加一个 S3 服务。如果在本机运行了 MinIO，可以使用本地 MinIO 模板快速开始。"))
+	intro.Wrapping = fyne.TextWrapWord
+
+	var d *dialog.CustomDialog
+	addButton := widget.NewButtonWithIcon(T("添加服务"), theme.ContentAddIcon(), func() {
+		d.Hide()
+		sv.ShowAddServiceDialog()
+	})
+	addButton.Importance = widget.HighImportance
+	minioButton := widget.NewButtonWithIcon(T("使用本地 MinIO 模板"), theme.StorageIcon(), func() {
+		d.Hide()
+		template := localMinIOTemplate
+		template.Alias = T(template.Alias)
+		sv.showAddServiceDialog(&template)
+	})
+	laterButton := widget.NewButton(T("稍后"), func() {
+		d.Hide()
+	})
+
+	buttons := container.NewHBox(layout.NewSpacer(), laterButton, minioButton, addButton)
+	d = dialog.NewCustomWithoutButtons(T("欢迎"), container.NewBorder(nil, buttons, nil, nil, intro), sv.window)
+	d.Resize(fyne.NewSize(520, 320))
+	d.Show()
+}
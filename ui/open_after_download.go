@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"s3-explorer/s3client"
+)
+
+// openWithSystemApp 用系统默认应用打开本地文件或文件夹
+func openWithSystemApp(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("cmd", "/C", "start", path)
+	case "darwin":
+		cmd = exec.Command("open", path)
+	default: // linux, freebsd, openbsd, netbsd 等
+		cmd = exec.Command("xdg-open", path)
+	}
+	return cmd.Start()
+}
+
+// downloadOpenTarget 返回下载完成后要打开的路径：只下载了一个直接选中的文件时打开该文件，
+// 否则（多个文件或文件夹）打开下载目录
+func downloadOpenTarget(localBasePath string, localPaths []string) string {
+	if len(localPaths) == 1 && filepath.Dir(localPaths[0]) == filepath.Clean(localBasePath) {
+		return localPaths[0]
+	}
+	return localBasePath
+}
+
+// downloadedPaths 返回下载列表中所有文件的本地路径
+func downloadedPaths(files []struct {
+	S3Object  s3client.S3Object
+	LocalPath string
+}) []string {
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.LocalPath
+	}
+	return paths
+}
+
+// SetOpenAfterDownload 设置下载完成后是否自动打开文件或所在文件夹，设置会被保存
+func (ov *ObjectsView) SetOpenAfterDownload(enabled bool) {
+	fyne.CurrentApp().Preferences().SetBool(prefOpenAfterDownload, enabled)
+}
+
+// OpenAfterDownload 返回下载完成后是否自动打开文件或所在文件夹
+func (ov *ObjectsView) OpenAfterDownload() bool {
+	return openAfterDownloadSetting()
+}
+
+// openDownloaded 在开启自动打开时打开下载结果，必须在 UI 线程中调用
+func (ov *ObjectsView) openDownloaded(localBasePath string, localPaths []string) {
+	if !openAfterDownloadSetting() {
+		return
+	}
+	target := downloadOpenTarget(localBasePath, localPaths)
+	if err := openWithSystemApp(target); err != nil {
+		log.Printf("打开 '%s' 失败: %v", target, err)
+		dialog.ShowError(fmt.Errorf(T("无法使用默认应用打开文件: %v"), err), ov.window)
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadOpenTarget(t *testing.T) {
+	base := filepath.Join("home", "downloads")
+	tests := []struct {
+		paths []string
+		want  string
+	}{
+		{[]string{filepath.Join(base, "a.txt")}, filepath.Join(base, "a.txt")},
+		{[]string{filepath.Join(base, "a.txt"), filepath.Join(base, "b.txt")}, base},
+		// 只包含一个文件的文件夹仍然打开下载目录
+		{[]string{filepath.Join(base, "docs", "a.txt")}, base},
+		{nil, base},
+	}
+	for _, tt := range tests {
+		if got := downloadOpenTarget(base, tt.paths); got != tt.want {
+			t.Errorf("downloadOpenTarget(%v) = %q, 期望 %q", tt.paths, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package ui
+
+// goToParentFolder 与点击上一级面包屑一样返回上一级目录，已在存储桶根目录时不做任何操作。
+// 由工具栏的上一级按钮和退格键调用。
+func (ov *ObjectsView) goToParentFolder() {
+	if ov.currentBucket == "" || ov.currentPrefix == "" {
+		return
+	}
+	ov.SetBucketAndPrefix(ov.s3Client, ov.currentBucket, parentKeyPrefix(ov.currentPrefix))
+}
+
+// updateUpButton 在存储桶根目录或未选择存储桶时禁用上一级按钮，随面包屑一起更新
+func (ov *ObjectsView) updateUpButton() {
+	if ov.upButton == nil {
+		return
+	}
+	if ov.currentBucket == "" || ov.currentPrefix == "" {
+		ov.upButton.Disable()
+	} else {
+		ov.upButton.Enable()
+	}
+}
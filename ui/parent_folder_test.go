@@ -0,0 +1,18 @@
+package ui
+
+import "testing"
+
+func TestParentKeyPrefix(t *testing.T) {
+	tests := map[string]string{
+		"a/":        "",
+		"a/b/":      "a/",
+		"a/b/c/":    "a/b/",
+		"a/b/c.txt": "a/b/",
+		"file.txt":  "",
+	}
+	for key, want := range tests {
+		if got := parentKeyPrefix(key); got != want {
+			t.Errorf("parentKeyPrefix(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
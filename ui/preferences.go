@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// settingsPage 是设置对话框中的一页。validate 在保存前校验输入，save 将输入写入首选项；
+// 两者都可以为 nil。
+type settingsPage struct {
+	title    string
+	content  fyne.CanvasObject
+	validate func() error
+	save     func()
+}
+
+// showSettingsPages 显示包含若干设置页的对话框。只有一页时直接显示页面内容，否则每页一个标签。
+// 点击保存时先校验所有页面，任意一页无效时提示错误且不保存任何设置。
+func showSettingsPages(w fyne.Window, title string, size fyne.Size, pages ...settingsPage) {
+	var content fyne.CanvasObject
+	if len(pages) == 1 {
+		content = pages[0].content
+	} else {
+		tabs := container.NewAppTabs()
+		for _, page := range pages {
+			tabs.Append(container.NewTabItem(page.title, container.NewVScroll(page.content)))
+		}
+		content = tabs
+	}
+
+	d := dialog.NewCustomConfirm(title, T("保存"), T("取消"), content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		for _, page := range pages {
+			if page.validate == nil {
+				continue
+			}
+			if err := page.validate(); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+		}
+		for _, page := range pages {
+			if page.save != nil {
+				page.save()
+			}
+		}
+	}, w)
+	d.Resize(size)
+	d.Show()
+}
+
+// parseIntSetting 解析设置中的整数，text 不是整数或不在 [min, max] 内时返回 false
+func parseIntSetting(text string, min, max int) (int, bool) {
+	value, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || value < min || value > max {
+		return 0, false
+	}
+	return value, true
+}
+
+// themeSettingsPage 创建界面主题设置页。主题由 main 包创建，保存后通过 onThemeChanged 通知调用方重新设置主题。
+func themeSettingsPage(onThemeChanged func(variant string)) settingsPage {
+	options := []string{T("跟随系统主题"), T("浅色主题"), T("深色主题")}
+	values := []string{ThemeVariantSystem, ThemeVariantLight, ThemeVariantDark}
+	themeSelect := widget.NewSelect(options, nil)
+	for i, value := range values {
+		if value == ThemeVariantSetting() {
+			themeSelect.SetSelected(options[i])
+		}
+	}
+
+	return settingsPage{
+		title: T("主题"),
+		content: container.NewVBox(
+			container.New(layout.NewFormLayout(),
+				widget.NewLabel(T("界面主题:")), themeSelect,
+			),
+			widget.NewLabel(T("也可以通过 \"视图\" 菜单切换主题。")),
+		),
+		save: func() {
+			for i, option := range options {
+				if option != themeSelect.Selected || values[i] == ThemeVariantSetting() {
+					continue
+				}
+				SetThemeVariantSetting(values[i])
+				if onThemeChanged != nil {
+					onThemeChanged(values[i])
+				}
+			}
+		},
+	}
+}
+
+// ShowPreferencesDialog 显示汇总所有应用设置的首选项对话框，每类设置一个标签页，一次保存全部修改。
+// onThemeChanged 在界面主题改变时调用，用于重新设置应用主题。
+func ShowPreferencesDialog(w fyne.Window, onThemeChanged func(variant string)) {
+	showSettingsPages(w, T("首选项"), fyne.NewSize(560, 520),
+		newServiceDefaultsPage(),
+		transferSettingsPage(),
+		confirmationSettingsPage(w),
+		themeSettingsPage(onThemeChanged),
+		languageSettingsPage(),
+	)
+}
@@ -0,0 +1,40 @@
+package ui
+
+import "testing"
+
+func TestParseIntSetting(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max int
+		want     int
+		wantOK   bool
+	}{
+		{"10", 1, 32, 10, true},
+		{" 32 ", 1, 32, 32, true},
+		{"0", 0, 100, 0, true},
+		{"0", 1, 32, 0, false},
+		{"33", 1, 32, 0, false},
+		{"-1", 0, 100, 0, false},
+		{"abc", 0, 100, 0, false},
+		{"", 0, 100, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseIntSetting(tt.text, tt.min, tt.max)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseIntSetting(%q, %d, %d) = %d, %v, 期望 %d, %v", tt.text, tt.min, tt.max, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRangeWorkersFor(t *testing.T) {
+	tests := []struct{ concurrency, want int }{
+		{1, 2},
+		{10, 5},
+		{32, 8},
+	}
+	for _, tt := range tests {
+		if got := rangeWorkersFor(tt.concurrency); got != tt.want {
+			t.Errorf("rangeWorkersFor(%d) = %d, 期望 %d", tt.concurrency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// presignExpiryOptions 是生成下载链接时可选的有效期
+var presignExpiryOptions = []struct {
+	label  string
+	expiry time.Duration
+}{
+	{"15 分钟", 15 * time.Minute},
+	{"1 小时", time.Hour},
+	{"24 小时", 24 * time.Hour},
+	{"7 天", s3client.MaxPresignExpiry},
+}
+
+// defaultPresignExpiry 是下载链接有效期选择框的默认选项
+const defaultPresignExpiry = 1
+
+// showPresignDialog 让用户选择有效期后生成对象的预签名下载链接并复制到剪贴板
+func (ov *ObjectsView) showPresignDialog(item s3client.S3Object) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	labels := make([]string, len(presignExpiryOptions))
+	for i, o := range presignExpiryOptions {
+		labels[i] = T(o.label)
+	}
+	expirySelect := widget.NewSelect(labels, nil)
+	expirySelect.SetSelectedIndex(defaultPresignExpiry)
+	hint := widget.NewLabel(T("持有链接的人在有效期内无需凭证即可下载该文件。"))
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabel(T("有效期:")), nil, expirySelect),
+		hint,
+	)
+	d := dialog.NewCustomConfirm(T("复制下载链接"), T("复制"), T("取消"), content, func(confirmed bool) {
+		if confirmed {
+			ov.copyPresignedURL(item, expirySelect.SelectedIndex())
+		}
+	}, ov.window)
+	d.Resize(fyne.NewSize(380, 200))
+	d.Show()
+}
+
+// copyPresignedURL 生成对象的预签名下载链接并复制到剪贴板，option 是 presignExpiryOptions 中的下标
+func (ov *ObjectsView) copyPresignedURL(item s3client.S3Object, option int) {
+	if option < 0 || option >= len(presignExpiryOptions) {
+		option = defaultPresignExpiry
+	}
+	o := presignExpiryOptions[option]
+	presignedURL, err := ov.s3Client.PresignGetObject(ov.currentBucket, item.Key, o.expiry)
+	if err != nil {
+		log.Printf("生成 '%s' 的下载链接失败: %v", item.Key, err)
+		dialog.ShowError(fmt.Errorf(T("生成下载链接失败: %v"), err), ov.window)
+		return
+	}
+	ov.window.Clipboard().SetContent(presignedURL)
+	ShowToast(ov.window, fmt.Sprintf(T("已复制下载链接，有效期 %s"), T(o.label)))
+}
@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// maxPrefetchSize 超过该大小的文件不做预取，避免在后台占用过多内存和带宽
+const maxPrefetchSize = 20 << 20
+
+// previewTypeFor 根据文件名返回应用内预览类型："image"、"text"、"archive"，不支持应用内预览时返回空字符串
+func previewTypeFor(name string) string {
+	if archiveFormat(name) != "" {
+		return "archive"
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return "image"
+	case ".txt", ".md", ".log", ".json", ".xml", ".yaml", ".yml", ".ini", ".cfg", ".go", ".py", ".js", ".html", ".css":
+		return "text"
+	default:
+		return ""
+	}
+}
+
+// prefetchEntry 是一次文件下载的结果，done 关闭后 data/err 可读
+type prefetchEntry struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// previewGallery 是预览窗口的状态，支持在当前列表的文件间前后切换，并在后台预取相邻文件
+type previewGallery struct {
+	ov     *ObjectsView
+	client ObjectStore
+	bucket string
+	files  []s3client.S3Object
+	index  int
+
+	window        fyne.Window
+	contentArea   *fyne.Container
+	positionLabel *widget.Label
+	prevButton    *widget.Button
+	nextButton    *widget.Button
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	cache  map[string]*prefetchEntry
+}
+
+// showInAppPreview 在应用内的新窗口中预览文件，并可在当前列表的文件之间切换
+func (ov *ObjectsView) showInAppPreview(item s3client.S3Object) {
+	// 按 getDisplayedObjects 的顺序收集文件，跳过文件夹
+	var files []s3client.S3Object
+	index := 0
+	for _, obj := range ov.getDisplayedObjects() {
+		if obj.IsFolder {
+			continue
+		}
+		if obj.Key == item.Key {
+			index = len(files)
+		}
+		files = append(files, obj)
+	}
+	if len(files) == 0 || files[index].Key != item.Key {
+		files = []s3client.S3Object{item}
+		index = 0
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &previewGallery{
+		ov:            ov,
+		client:        ov.s3Client,
+		bucket:        ov.currentBucket,
+		files:         files,
+		index:         index,
+		window:        fyne.CurrentApp().NewWindow(T("预览")),
+		contentArea:   container.NewStack(),
+		positionLabel: widget.NewLabel(""),
+		ctx:           ctx,
+		cancel:        cancel,
+		cache:         make(map[string]*prefetchEntry),
+	}
+	g.prevButton = widget.NewButtonWithIcon(T("上一个"), theme.NavigateBackIcon(), func() { g.show(g.index - 1) })
+	g.nextButton = widget.NewButtonWithIcon(T("下一个"), theme.NavigateNextIcon(), func() { g.show(g.index + 1) })
+
+	// 窗口关闭时取消所有未完成的下载和预取
+	g.window.SetOnClosed(cancel)
+	g.window.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		switch ev.Name {
+		case fyne.KeyLeft, fyne.KeyPageUp:
+			g.show(g.index - 1)
+		case fyne.KeyRight, fyne.KeyPageDown:
+			g.show(g.index + 1)
+		}
+	})
+
+	navBar := container.NewHBox(g.prevButton, layout.NewSpacer(), g.positionLabel, layout.NewSpacer(), g.nextButton)
+	g.window.SetContent(container.NewBorder(nil, navBar, nil, nil, g.contentArea))
+	g.window.Resize(fyne.NewSize(800, 600))
+	g.window.Show()
+	g.show(index)
+}
+
+// show 切换到第 index 个文件并显示其预览
+func (g *previewGallery) show(index int) {
+	if index < 0 || index >= len(g.files) {
+		return
+	}
+	g.index = index
+	item := g.files[index]
+
+	g.window.SetTitle(fmt.Sprintf(T("预览 - %s"), item.Name))
+	g.positionLabel.SetText(fmt.Sprintf("%d / %d", index+1, len(g.files)))
+	if index > 0 {
+		g.prevButton.Enable()
+	} else {
+		g.prevButton.Disable()
+	}
+	if index < len(g.files)-1 {
+		g.nextButton.Enable()
+	} else {
+		g.nextButton.Disable()
+	}
+
+	previewType := previewTypeFor(item.Name)
+	if previewType == "" {
+		g.setContent(g.unsupportedContent(item))
+		g.prefetchAround(index)
+		return
+	}
+
+	g.setContent(container.NewCenter(widget.NewProgressBarInfinite()))
+	if previewType == "archive" {
+		g.showArchive(index, item)
+		g.prefetchAround(index)
+		return
+	}
+	entry := g.fetch(item)
+	go func() {
+		select {
+		case <-entry.done:
+		case <-g.ctx.Done():
+			return
+		}
+
+		var previewContent fyne.CanvasObject
+		if entry.err != nil {
+			log.Printf("预览失败 (下载): %v", entry.err)
+			previewContent = container.NewCenter(widget.NewLabel(T("加载预览失败")))
+		} else if previewType == "image" {
+			img, _, err := image.Decode(bytes.NewReader(entry.data))
+			if err != nil {
+				log.Printf("预览图片失败 (解码): %v", err)
+				previewContent = container.NewCenter(widget.NewLabel(T("无法解码图片")))
+			} else {
+				canvasImg := canvas.NewImageFromImage(img)
+				canvasImg.FillMode = canvas.ImageFillContain
+				previewContent = container.NewScroll(canvasImg)
+			}
+		}
+
+		fyne.Do(func() {
+			if g.index != index {
+				return // 用户已切换到其他文件
+			}
+			if previewContent == nil {
+				previewContent = g.ov.createTextPreview(item, string(entry.data))
+			}
+			g.setContent(previewContent)
+		})
+	}()
+	g.prefetchAround(index)
+}
+
+// showArchive 在后台读取压缩包的目录并显示内容列表。压缩包不整体下载，因此不使用预取缓存。
+func (g *previewGallery) showArchive(index int, item s3client.S3Object) {
+	go func() {
+		entries, err := listArchive(g.ctx, g.client, g.bucket, item)
+		if g.ctx.Err() != nil {
+			return
+		}
+		fyne.Do(func() {
+			if g.index != index {
+				return // 用户已切换到其他文件
+			}
+			if err != nil {
+				log.Printf("预览压缩包失败: %v", err)
+				g.setContent(container.NewCenter(widget.NewLabel(T("无法读取压缩包内容"))))
+				return
+			}
+			g.setContent(g.createArchivePreview(item, entries))
+		})
+	}()
+}
+
+// setContent 替换预览区域的内容
+func (g *previewGallery) setContent(content fyne.CanvasObject) {
+	g.contentArea.Objects = []fyne.CanvasObject{content}
+	g.contentArea.Refresh()
+}
+
+// unsupportedContent 返回不支持应用内预览的文件的占位内容
+func (g *previewGallery) unsupportedContent(item s3client.S3Object) fyne.CanvasObject {
+	openButton := widget.NewButtonWithIcon(T("使用默认应用打开"), theme.FileApplicationIcon(), func() {
+		g.ov.openWithDefaultApp(item)
+	})
+	return newEmptyState(theme.FileIcon(), T("此文件类型不支持应用内预览"), openButton)
+}
+
+// fetch 返回文件的下载结果，已在缓存中时直接复用，否则在后台开始下载
+func (g *previewGallery) fetch(item s3client.S3Object) *prefetchEntry {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if entry, ok := g.cache[item.Key]; ok {
+		return entry
+	}
+
+	entry := &prefetchEntry{done: make(chan struct{})}
+	g.cache[item.Key] = entry
+	go func() {
+		defer close(entry.done)
+		body, err := g.client.DownloadObject(context.Background(), g.bucket, item.Key)
+		if err != nil {
+			entry.err = err
+			return
+		}
+		defer body.Close()
+		entry.data, entry.err = io.ReadAll(&contextReader{ctx: g.ctx, reader: body})
+	}()
+	return entry
+}
+
+// prefetchAround 预取相邻的文件，并丢弃不再相邻的缓存
+func (g *previewGallery) prefetchAround(index int) {
+	keep := make(map[string]bool)
+	for _, i := range []int{index - 1, index, index + 1} {
+		if i < 0 || i >= len(g.files) {
+			continue
+		}
+		keep[g.files[i].Key] = true
+		previewType := previewTypeFor(g.files[i].Name)
+		if i != index && previewType != "" && previewType != "archive" && g.files[i].Size <= maxPrefetchSize {
+			g.fetch(g.files[i])
+		}
+	}
+
+	g.mu.Lock()
+	for key := range g.cache {
+		if !keep[key] {
+			delete(g.cache, key)
+		}
+	}
+	g.mu.Unlock()
+}
+
+// contextReader 在 context 被取消后让读取立即返回错误，用于中止后台下载
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
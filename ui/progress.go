@@ -6,18 +6,25 @@ import (
 	"sync/atomic"
 
 	"fyne.io/fyne/v2" // Added fyne import
-	"fyne.io/fyne/v2/dialog"
 )
 
+// progressIndicator 是显示总进度的控件，*dialog.ProgressDialog 和 *cancellableProgress 都实现了它
+type progressIndicator interface {
+	SetValue(float64)
+}
+
 // ProgressTracker 包装一个 io.Reader 以跟踪读取进度并更新进度条。
 // 如果底层 reader 也是 io.ReadSeeker，则 ProgressTracker 也将实现 io.ReadSeeker。
 type ProgressTracker struct {
 	reader              io.Reader
 	seeker              io.ReadSeeker // 如果 reader 可寻址则保存 seeker
 	totalSize           int64
-	bytesTransferred    *int64                 // 使用指针指向原子计数器以共享进度
-	totalProgressDialog *dialog.ProgressDialog // 更改为 *dialog.ProgressDialog
-	totalProgressValue  *float64               // 使用指针以共享进度值
+	bytesTransferred    *int64            // 使用指针指向原子计数器以共享进度
+	totalProgressDialog progressIndicator // 显示总进度的控件，可以为 nil
+	totalProgressValue  *float64          // 使用指针以共享进度值
+	attemptBytes        *int64            // 本次传输尝试计入的字节数，用于失败重试时撤销进度，可以为 nil
+	rate                *transferRate     // 计算传输速度和剩余时间，可以为 nil
+	read                int64             // 本跟踪器已计入总进度的字节数，回到开头重新读取时撤销
 }
 
 // NewProgressTracker 为单个读取操作创建一个新的进度跟踪器
@@ -26,7 +33,7 @@ func NewProgressTracker(
 	reader io.Reader,
 	totalSize int64,
 	bytesTransferred *int64,
-	totalProgressDialog *dialog.ProgressDialog,
+	totalProgressDialog progressIndicator,
 ) *ProgressTracker {
 	// 尝试类型断言，看 reader 是否也是 io.ReadSeeker
 	seeker, _ := reader.(io.ReadSeeker) // 如果失败我们不关心，seeker 将为 nil
@@ -46,6 +53,13 @@ func (p *ProgressTracker) Read(b []byte) (int, error) {
 	if n > 0 {
 		// 原子性地将读取的字节数加到总数中。
 		newVal := atomic.AddInt64(p.bytesTransferred, int64(n))
+		atomic.AddInt64(&p.read, int64(n))
+		if p.attemptBytes != nil {
+			atomic.AddInt64(p.attemptBytes, int64(n))
+		}
+		if p.rate != nil {
+			p.rate.update(newVal)
+		}
 
 		// 更新进度条。
 		if p.totalSize > 0 {
@@ -61,9 +75,18 @@ func (p *ProgressTracker) Read(b []byte) (int, error) {
 }
 
 // Seek 如果底层 reader 是可寻址的，则实现 io.Seeker 接口。
+// 回到开头表示上传被重试，数据会被重新读取，因此撤销已计入总进度的字节数，避免进度超过 100%。
 func (p *ProgressTracker) Seek(offset int64, whence int) (int64, error) {
 	if p.seeker != nil {
-		return p.seeker.Seek(offset, whence)
+		pos, err := p.seeker.Seek(offset, whence)
+		if err == nil && pos == 0 {
+			n := atomic.SwapInt64(&p.read, 0)
+			atomic.AddInt64(p.bytesTransferred, -n)
+			if p.attemptBytes != nil {
+				atomic.AddInt64(p.attemptBytes, -n)
+			}
+		}
+		return pos, err
 	}
 	// 如果底层 reader 不可寻址，则返回错误
 	return 0, fmt.Errorf("底层 reader 不支持 seek 操作")
@@ -74,7 +97,8 @@ type ProgressWriter struct {
 	writer              io.Writer
 	totalSize           int64
 	bytesTransferred    *int64 // 指向共享原子计数器的指针
-	totalProgressDialog *dialog.ProgressDialog
+	totalProgressDialog progressIndicator
+	rate                *transferRate // 计算传输速度和剩余时间，可以为 nil
 }
 
 // NewProgressWriter 为写入操作创建一个新的进度跟踪器。
@@ -82,7 +106,7 @@ func NewProgressWriter(
 	writer io.Writer,
 	totalSize int64,
 	bytesTransferred *int64,
-	progressDialog *dialog.ProgressDialog,
+	progressDialog progressIndicator,
 ) *ProgressWriter {
 	return &ProgressWriter{
 		writer:              writer,
@@ -97,6 +121,9 @@ func (p *ProgressWriter) Write(b []byte) (int, error) {
 	n, err := p.writer.Write(b)
 	if n > 0 {
 		newVal := atomic.AddInt64(p.bytesTransferred, int64(n))
+		if p.rate != nil {
+			p.rate.update(newVal)
+		}
 		if p.totalSize > 0 {
 			progress := float64(newVal) / float64(p.totalSize)
 			if p.totalProgressDialog != nil {
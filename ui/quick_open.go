@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// maxRecentLocations 是快速打开中保留的最近访问位置数量
+const maxRecentLocations = 20
+
+// maxQuickOpenResults 是快速打开面板中最多显示的结果数量
+const maxQuickOpenResults = 50
+
+// quickOpenLocation 是一个存储桶中的位置，prefix 为空表示存储桶根目录
+type quickOpenLocation struct {
+	bucket string
+	prefix string
+}
+
+// quickOpenItem 是快速打开面板中的一个候选项，object 不为 nil 时表示当前列表中的对象
+type quickOpenItem struct {
+	kind     string // 显示在名称前的类别
+	name     string // 参与模糊匹配的名称
+	location quickOpenLocation
+	object   *s3client.S3Object
+}
+
+// recordRecentLocation 记录最近访问的位置，重复访问的位置移到最前
+func (ov *ObjectsView) recordRecentLocation(bucket, prefix string) {
+	loc := quickOpenLocation{bucket: bucket, prefix: prefix}
+	recent := []quickOpenLocation{loc}
+	for _, l := range ov.recentLocations {
+		if l != loc && len(recent) < maxRecentLocations {
+			recent = append(recent, l)
+		}
+	}
+	ov.recentLocations = recent
+}
+
+// fuzzyScore 判断 query 的字符是否按顺序出现在 candidate 中（不区分大小写），并返回匹配得分。
+// 连续匹配和在单词开头（路径分隔符、连字符等之后）的匹配得分更高。
+func fuzzyScore(query, candidate string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+	c := []rune(strings.ToLower(candidate))
+	score, qi := 0, 0
+	prevMatch := -2
+	for ci, r := range c {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+		score++
+		if ci == prevMatch+1 {
+			score += 5
+		}
+		if ci == 0 || strings.ContainsRune("/-_. ", c[ci-1]) {
+			score += 3
+		}
+		prevMatch = ci
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// rankQuickOpenItems 返回与 query 模糊匹配的候选项，按得分从高到低排序，得分相同时名称较短的在前。
+// query 为空时按原有顺序返回所有候选项。结果最多 maxQuickOpenResults 个。
+func rankQuickOpenItems(items []quickOpenItem, query string) []quickOpenItem {
+	type scored struct {
+		item  quickOpenItem
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		if score, ok := fuzzyScore(query, item.name); ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+	if query != "" {
+		sort.SliceStable(matches, func(i, j int) bool {
+			if matches[i].score != matches[j].score {
+				return matches[i].score > matches[j].score
+			}
+			return len(matches[i].item.name) < len(matches[j].item.name)
+		})
+	}
+	if len(matches) > maxQuickOpenResults {
+		matches = matches[:maxQuickOpenResults]
+	}
+	result := make([]quickOpenItem, len(matches))
+	for i, m := range matches {
+		result[i] = m.item
+	}
+	return result
+}
+
+// quickOpenItems 收集快速打开的候选项：最近访问的位置、存储桶和当前列表中的对象，均来自已加载的数据
+func (ov *ObjectsView) quickOpenItems() []quickOpenItem {
+	var items []quickOpenItem
+	for _, loc := range ov.recentLocations {
+		items = append(items, quickOpenItem{kind: T("最近"), name: bookmarkLocation(loc.bucket, loc.prefix), location: loc})
+	}
+	if ov.QuickOpenBuckets != nil {
+		for _, bucket := range ov.QuickOpenBuckets() {
+			items = append(items, quickOpenItem{kind: T("存储桶"), name: bucket, location: quickOpenLocation{bucket: bucket}})
+		}
+	}
+	for i := range ov.objects {
+		obj := ov.objects[i]
+		items = append(items, quickOpenItem{kind: T("当前目录"), name: obj.Name, object: &obj})
+	}
+	return items
+}
+
+// openQuickOpenItem 打开选中的候选项：位置和文件夹直接跳转，文件打开预览
+func (ov *ObjectsView) openQuickOpenItem(item quickOpenItem) {
+	if item.object == nil {
+		if ov.rejectIfBusy() {
+			return
+		}
+		if ov.OnLocationOpened != nil {
+			ov.OnLocationOpened(item.location.bucket, item.location.prefix)
+		}
+		return
+	}
+	if item.object.IsFolder {
+		ov.SetBucketAndPrefix(ov.s3Client, ov.currentBucket, item.object.Key)
+	} else {
+		ov.showPreviewWindow(*item.object)
+	}
+}
+
+// quickOpenEntry 是快速打开面板的输入框，上下方向键用于在结果之间移动
+type quickOpenEntry struct {
+	widget.Entry
+	onMove func(delta int)
+}
+
+func newQuickOpenEntry(onMove func(delta int)) *quickOpenEntry {
+	e := &quickOpenEntry{onMove: onMove}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+func (e *quickOpenEntry) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyUp:
+		e.onMove(-1)
+	case fyne.KeyDown:
+		e.onMove(1)
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+// showQuickOpen 显示快速打开面板，输入时模糊搜索候选项，回车打开高亮的结果
+func (ov *ObjectsView) showQuickOpen() {
+	if ov.connecting || ov.currentServiceAlias == "" {
+		return
+	}
+	items := ov.quickOpenItems()
+	results := rankQuickOpenItems(items, "")
+	highlighted := 0
+
+	var d *dialog.CustomDialog
+	var resultList *widget.List
+	resultList = widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			label.SetText("[" + results[id].kind + "] " + results[id].name)
+			label.TextStyle.Bold = id == highlighted
+			label.Refresh()
+		},
+	)
+	open := func(id int) {
+		if id < 0 || id >= len(results) {
+			return
+		}
+		d.Hide()
+		ov.openQuickOpenItem(results[id])
+	}
+	resultList.OnSelected = func(id widget.ListItemID) {
+		resultList.Unselect(id)
+		open(id)
+	}
+
+	entry := newQuickOpenEntry(func(delta int) {
+		if len(results) == 0 {
+			return
+		}
+		highlighted = (highlighted + delta + len(results)) % len(results)
+		resultList.Refresh()
+		resultList.ScrollTo(highlighted)
+	})
+	entry.SetPlaceHolder(T("搜索存储桶、最近访问的目录和当前列表中的对象"))
+	entry.OnChanged = func(query string) {
+		results = rankQuickOpenItems(items, query)
+		highlighted = 0
+		resultList.Refresh()
+		resultList.ScrollToTop()
+	}
+	entry.OnSubmitted = func(string) { open(highlighted) }
+
+	d = dialog.NewCustom(T("快速打开"), T("关闭"), container.NewBorder(entry, nil, nil, nil, resultList), ov.window)
+	d.Resize(fyne.NewSize(550, 420))
+	d.Show()
+	ov.window.Canvas().Focus(entry)
+}
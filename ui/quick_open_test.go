@@ -0,0 +1,43 @@
+package ui
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("lgs", "logs-2024"); !ok {
+		t.Error("按顺序出现的字符应当匹配")
+	}
+	if _, ok := fuzzyScore("sgl", "logs-2024"); ok {
+		t.Error("顺序不同的字符不应匹配")
+	}
+	contiguous, _ := fuzzyScore("log", "backup/logs")
+	scattered, _ := fuzzyScore("log", "lazy-object-gallery")
+	if contiguous <= scattered {
+		t.Errorf("连续匹配的得分 %d 应高于分散匹配的得分 %d", contiguous, scattered)
+	}
+}
+
+func TestRankQuickOpenItems(t *testing.T) {
+	items := []quickOpenItem{
+		{name: "media-archive"},
+		{name: "photos"},
+		{name: "photos-backup"},
+	}
+	got := rankQuickOpenItems(items, "pho")
+	if len(got) != 2 || got[0].name != "photos" || got[1].name != "photos-backup" {
+		t.Errorf("rankQuickOpenItems = %v", got)
+	}
+	if all := rankQuickOpenItems(items, ""); len(all) != len(items) || all[0].name != "media-archive" {
+		t.Errorf("空查询应按原有顺序返回所有候选项，实际为 %v", all)
+	}
+}
+
+func TestRecordRecentLocation(t *testing.T) {
+	ov := newTestObjectsView(newMemStore(), "")
+	ov.recordRecentLocation("b", "a/")
+	ov.recordRecentLocation("b", "c/")
+	ov.recordRecentLocation("b", "a/")
+	want := []quickOpenLocation{{"b", "a/"}, {"b", "c/"}}
+	if len(ov.recentLocations) != 2 || ov.recentLocations[0] != want[0] || ov.recentLocations[1] != want[1] {
+		t.Errorf("recentLocations = %v, 期望 %v", ov.recentLocations, want)
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"s3-explorer/s3client"
+)
+
+// recursiveSearchDelay 是停止输入后开始递归搜索前的等待时间，避免每输入一个字符就列举整个存储桶
+const recursiveSearchDelay = 400 * time.Millisecond
+
+// SetRecursiveSearch 开启或关闭在整个存储桶中搜索。关闭后搜索框只筛选当前已加载的列表。
+func (ov *ObjectsView) SetRecursiveSearch(enabled bool) {
+	ov.recursiveSearch = enabled
+	ov.filterObjects(ov.searchText())
+}
+
+// recursiveSearchActive 判断当前是否应该显示整个存储桶的搜索结果
+func (ov *ObjectsView) recursiveSearchActive(term string) bool {
+	return ov.recursiveSearch && term != "" && ov.currentBucket != ""
+}
+
+// hasSearchResults 判断已有的搜索结果是否对应当前的搜索词和存储桶
+func (ov *ObjectsView) hasSearchResults(term string) bool {
+	return ov.searchResults != nil && ov.searchedTerm == term && ov.searchedBucket == ov.currentBucket
+}
+
+// searchResultsView 返回按类型筛选和排序后的搜索结果，没有匹配时返回空列表而不是 nil
+func (ov *ObjectsView) searchResultsView() []s3client.S3Object {
+	filtered := filterObjectList(ov.searchResults, "", ov.typeFilters, ov.sortOrder())
+	if filtered == nil {
+		filtered = append([]s3client.S3Object{}, ov.searchResults...)
+		s3client.SortObjectsBy(filtered, ov.sortOrder())
+	}
+	return filtered
+}
+
+// displayFilter 返回当前应显示的筛选结果：递归搜索的结果，或按搜索词和类型筛选的当前列表
+func (ov *ObjectsView) displayFilter() []s3client.S3Object {
+	term := ov.searchText()
+	if ov.recursiveSearchActive(term) && ov.hasSearchResults(term) {
+		return ov.searchResultsView()
+	}
+	return filterObjectList(ov.objects, term, ov.typeFilters, ov.sortOrder())
+}
+
+// refreshRecursiveSearch 在搜索词或存储桶变化后重新开始递归搜索，搜索结果仍然有效时不做任何操作
+func (ov *ObjectsView) refreshRecursiveSearch() {
+	term := ov.searchText()
+	if !ov.recursiveSearchActive(term) {
+		ov.stopRecursiveSearch()
+		ov.searchResults = nil
+		return
+	}
+	if !ov.hasSearchResults(term) {
+		ov.scheduleRecursiveSearch(term)
+	}
+}
+
+// scheduleRecursiveSearch 在停止输入 recursiveSearchDelay 后搜索 term，期间的输入会重新计时
+func (ov *ObjectsView) scheduleRecursiveSearch(term string) {
+	ov.stopRecursiveSearch()
+	ov.searchTimer = time.AfterFunc(recursiveSearchDelay, func() {
+		fyne.Do(func() {
+			if ov.searchText() == term && ov.recursiveSearchActive(term) {
+				ov.runRecursiveSearch(term)
+			}
+		})
+	})
+}
+
+// stopRecursiveSearch 取消等待中和进行中的递归搜索，进行中的搜索结果会被丢弃
+func (ov *ObjectsView) stopRecursiveSearch() {
+	ov.searchGeneration++
+	if ov.searchTimer != nil {
+		ov.searchTimer.Stop()
+		ov.searchTimer = nil
+	}
+	if ov.searchCancel != nil {
+		ov.searchCancel()
+		ov.searchCancel = nil
+		ov.loadingIndicator.Hide()
+	}
+}
+
+// runRecursiveSearch 在后台列举整个存储桶并查找文件名包含 term 的对象，完成后显示搜索结果。
+// 结果超过 s3client.MaxSearchResults 时只显示前面的部分并提示。
+func (ov *ObjectsView) runRecursiveSearch(term string) {
+	ov.stopRecursiveSearch()
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	generation := ov.searchGeneration
+	ctx, cancel := context.WithCancel(context.Background())
+	ov.searchCancel = cancel
+	client, bucket := ov.s3Client, ov.currentBucket
+	ov.loadingIndicator.Show()
+
+	go func() {
+		results, truncated, err := client.SearchObjects(ctx, bucket, "", term)
+		fyne.Do(func() {
+			if generation != ov.searchGeneration {
+				return // 搜索词已变化或搜索已取消
+			}
+			ov.searchCancel = nil
+			cancel()
+			ov.loadingIndicator.Hide()
+			if err != nil {
+				log.Printf("搜索存储桶 '%s' 失败: %v", bucket, err)
+				dialog.ShowError(fmt.Errorf(T("搜索失败: %v"), err), ov.window)
+				return
+			}
+			if results == nil {
+				results = []s3client.S3Object{}
+			}
+			ov.searchResults, ov.searchedTerm, ov.searchedBucket = results, term, bucket
+			if truncated {
+				ShowToast(ov.window, fmt.Sprintf(T("匹配的对象过多，只显示前 %d 个结果。"), s3client.MaxSearchResults))
+			}
+			ov.filterObjects(term)
+		})
+	}()
+}
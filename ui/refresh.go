@@ -0,0 +1,36 @@
+package ui
+
+import "strings"
+
+// refreshCurrentFolder 重新加载当前目录，并清除该目录下对象的缩略图缓存，使在别处修改过的图片重新生成缩略图。
+// 未选择存储桶或正在加载时不做任何操作，避免重叠的加载。
+func (ov *ObjectsView) refreshCurrentFolder() {
+	if ov.s3Client == nil || ov.currentBucket == "" || ov.connecting || ov.loadingIndicator.Visible() {
+		return
+	}
+	clearThumbnailCache(ov.currentPrefix)
+	ov.loadObjects()
+}
+
+// updateRefreshButton 在加载期间禁用刷新按钮，加载完成后恢复
+func (ov *ObjectsView) updateRefreshButton() {
+	if ov.refreshButton == nil {
+		return
+	}
+	if ov.loadingIndicator.Visible() || ov.connecting || ov.currentBucket == "" {
+		ov.refreshButton.Disable()
+	} else {
+		ov.refreshButton.Enable()
+	}
+}
+
+// clearThumbnailCache 清除前缀 prefix 下所有对象的缩略图缓存
+func clearThumbnailCache(prefix string) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	for key := range thumbnailCache {
+		if strings.HasPrefix(key, prefix) {
+			delete(thumbnailCache, key)
+		}
+	}
+}
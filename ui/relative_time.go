@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"s3-explorer/s3client"
+)
+
+// SetShowRelativeTime 设置是否以相对时间显示对象的修改时间，设置会被保存
+func (ov *ObjectsView) SetShowRelativeTime(show bool) {
+	fyne.CurrentApp().Preferences().SetBool(prefRelativeTime, show)
+	ov.showRelativeTime = show
+	if ov.objectList != nil {
+		ov.objectList.Refresh()
+	}
+}
+
+// ShowRelativeTime 返回是否以相对时间显示修改时间
+func (ov *ObjectsView) ShowRelativeTime() bool {
+	return ov.showRelativeTime
+}
+
+// lastModifiedText 返回列表中显示的修改时间。开启相对时间时显示相对时间，网关未返回修改时间时回退到原始文字。
+func (ov *ObjectsView) lastModifiedText(item s3client.S3Object) string {
+	if !ov.showRelativeTime || item.ModifiedTime.IsZero() {
+		return item.LastModified
+	}
+	return formatRelativeTime(item.ModifiedTime, time.Now())
+}
+
+// formatRelativeTime 将 t 格式化为相对于 now 的时间，例如 "3 小时前"、"昨天"。
+// 超过 30 天的时间显示日期；晚于 now 的时间（本地时钟偏差）视为刚刚。
+func formatRelativeTime(t, now time.Time) string {
+	t = t.In(now.Location())
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return T("刚刚")
+	case d < time.Hour:
+		return fmt.Sprintf(T("%d 分钟前"), int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf(T("%d 小时前"), int(d/time.Hour))
+	}
+
+	// 按日历日计算天数，避免 "昨天 23 点" 在今天凌晨显示为 "0 天前"
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+	days := int(today.Sub(day).Hours()+12) / 24 // 加半天以抵消夏令时切换造成的误差
+	switch {
+	case days <= 1:
+		return T("昨天")
+	case days <= 30:
+		return fmt.Sprintf(T("%d 天前"), days)
+	}
+	return t.Format("2006-01-02")
+}
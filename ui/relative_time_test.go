@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "刚刚"},
+		{now.Add(time.Minute), "刚刚"},
+		{now.Add(-5 * time.Minute), "5 分钟前"},
+		{now.Add(-3 * time.Hour), "3 小时前"},
+		{time.Date(2024, 3, 9, 7, 0, 0, 0, time.UTC), "昨天"},
+		{time.Date(2024, 3, 8, 23, 0, 0, 0, time.UTC), "2 天前"},
+		{time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC), "2024-02-01"},
+	}
+	for _, tt := range tests {
+		if got := formatRelativeTime(tt.t, now); got != tt.want {
+			t.Errorf("formatRelativeTime(%v) = %q, 期望 %q", tt.t, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// partialSuffix 是未完成下载的数据文件的后缀，下载完成后重命名为目标文件
+const partialSuffix = ".s3part"
+
+// partialMeta 记录未完成下载对应的对象版本和已完成的分段，保存在数据文件旁的 .meta 文件中
+type partialMeta struct {
+	Size   int64   `json:"size"`
+	ETag   string  `json:"etag"`
+	Ranged bool    `json:"ranged"`          // 是否为多线程范围下载，此时数据文件已预分配为完整大小
+	Parts  []int64 `json:"parts,omitempty"` // 范围下载中已完成分段的起始位置
+}
+
+// partialDownload 管理一个文件未完成的下载。数据先写入 localPath+".s3part"，完成后再重命名为目标文件，
+// 下载中断或失败时保留已下载的数据，下次下载同一版本的对象时从中断处继续。
+type partialDownload struct {
+	localPath string
+
+	mu   sync.Mutex
+	meta partialMeta
+}
+
+func newPartialDownload(localPath string) *partialDownload {
+	return &partialDownload{localPath: localPath}
+}
+
+// dataPath 返回未完成下载的数据文件路径
+func (p *partialDownload) dataPath() string {
+	return p.localPath + partialSuffix
+}
+
+// metaPath 返回记录对象版本和已完成分段的文件路径
+func (p *partialDownload) metaPath() string {
+	return p.localPath + partialSuffix + ".meta"
+}
+
+// resume 读取上次未完成下载的记录。对象的大小和 ETag 与记录一致、且使用相同的下载方式时返回 true；
+// 没有 ETag 时无法确认对象未被修改，总是重新下载。
+func (p *partialDownload) resume(size int64, etag string, ranged bool) bool {
+	data, err := os.ReadFile(p.metaPath())
+	if err != nil {
+		return false
+	}
+	var meta partialMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return false
+	}
+	if meta.ETag == "" || meta.ETag != etag || meta.Size != size || meta.Ranged != ranged {
+		return false
+	}
+	if _, err := os.Stat(p.dataPath()); err != nil {
+		return false
+	}
+	p.meta = meta
+	return true
+}
+
+// start 开始一次新的下载，记录对象版本并清空已完成的分段。调用方负责清空数据文件。
+func (p *partialDownload) start(size int64, etag string, ranged bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.meta = partialMeta{Size: size, ETag: etag, Ranged: ranged}
+	return p.saveMeta()
+}
+
+// completedParts 返回范围下载中已完成分段的起始位置
+func (p *partialDownload) completedParts() map[int64]bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	parts := make(map[int64]bool, len(p.meta.Parts))
+	for _, start := range p.meta.Parts {
+		parts[start] = true
+	}
+	return parts
+}
+
+// completePart 记录范围下载中已完成的分段，可以被多个 worker 并发调用
+func (p *partialDownload) completePart(start int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.meta.Parts = append(p.meta.Parts, start)
+	return p.saveMeta()
+}
+
+// saveMeta 写入记录文件，调用方必须持有锁
+func (p *partialDownload) saveMeta() error {
+	data, err := json.Marshal(p.meta)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(p.metaPath(), data, 0644); err != nil {
+		return fmt.Errorf("写入下载记录失败: %w", err)
+	}
+	return nil
+}
+
+// finish 将下载完成的数据文件重命名为目标文件并删除记录
+func (p *partialDownload) finish() error {
+	if err := os.Rename(p.dataPath(), p.localPath); err != nil {
+		return fmt.Errorf("保存下载的文件失败: %w", err)
+	}
+	os.Remove(p.metaPath())
+	return nil
+}
+
+// discard 删除未完成的数据文件和记录
+func (p *partialDownload) discard() {
+	os.Remove(p.dataPath())
+	os.Remove(p.metaPath())
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+// preparePartial 模拟上次中断的下载：数据文件中已有 data，记录的对象版本为 etag
+func preparePartial(t *testing.T, localPath string, size int64, etag, data string) {
+	t.Helper()
+	partial := newPartialDownload(localPath)
+	if err := partial.start(size, etag, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial.dataPath(), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDownloadFileResumesPartialDownload(t *testing.T) {
+	const content = "hello world"
+	sum := md5.Sum([]byte(content))
+	etag := hex.EncodeToString(sum[:])
+
+	store := newMemStore()
+	store.put(testBucket, "docs/a.txt", content)
+	ov := newTestObjectsView(store, "docs/")
+	obj := s3client.S3Object{Key: "docs/a.txt", Name: "a.txt", Size: int64(len(content)), ETag: etag}
+
+	tests := []struct {
+		name, etag, want string
+	}{
+		// 已下载的部分用大写字母标记，继续下载时保留，证明只下载了剩余部分
+		{"同一版本从中断处继续", etag, "HELLO world"},
+		{"对象已修改时从头下载", "other", content},
+	}
+	for _, tt := range tests {
+		localPath := filepath.Join(t.TempDir(), "a.txt")
+		preparePartial(t, localPath, obj.Size, tt.etag, "HELLO")
+
+		var done int64
+		attempt := &transferAttempt{progress: &transferProgress{total: obj.Size, done: &done}}
+		if err := ov.currentTransferTarget().downloadFile(context.Background(), obj, localPath, attempt); err != nil {
+			t.Fatalf("%s: downloadFile() 返回错误: %v", tt.name, err)
+		}
+		data, err := os.ReadFile(localPath)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("%s: 文件内容 = %q, %v, 期望 %q", tt.name, data, err, tt.want)
+		}
+		if done != obj.Size {
+			t.Errorf("%s: 进度 = %d, 期望 %d", tt.name, done, obj.Size)
+		}
+		for _, p := range []string{localPath + partialSuffix, localPath + partialSuffix + ".meta"} {
+			if _, err := os.Stat(p); !os.IsNotExist(err) {
+				t.Errorf("%s: 下载完成后 %s 仍然存在", tt.name, filepath.Base(p))
+			}
+		}
+	}
+}
+
+func TestPartialDownloadRecordsCompletedParts(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "big.bin")
+	partial := newPartialDownload(localPath)
+	if partial.resume(100, "etag", true) {
+		t.Fatal("没有记录时不应继续下载")
+	}
+	if err := partial.start(100, "etag", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial.dataPath(), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	partial.completePart(0)
+	partial.completePart(50)
+
+	resumed := newPartialDownload(localPath)
+	if !resumed.resume(100, "etag", true) {
+		t.Fatal("同一版本的对象应继续下载")
+	}
+	if parts := resumed.completedParts(); len(parts) != 2 || !parts[0] || !parts[50] {
+		t.Errorf("completedParts() = %v, 期望 0 和 50", parts)
+	}
+	for _, tt := range []struct {
+		size   int64
+		etag   string
+		ranged bool
+	}{{100, "changed", true}, {200, "etag", true}, {100, "etag", false}, {100, "", true}} {
+		if newPartialDownload(localPath).resume(tt.size, tt.etag, tt.ranged) {
+			t.Errorf("resume(%d, %q, %v) = true, 期望 false", tt.size, tt.etag, tt.ranged)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"s3-explorer/s3client"
+)
+
+// s3URI 返回对象的 S3 路径，如 s3://bucket/docs/a.txt，供 AWS CLI 等工具使用。文件夹的键本身以 "/" 结尾。
+func s3URI(bucket, key string) string {
+	return "s3://" + bucket + "/" + key
+}
+
+// s3URIs 返回多个对象的 S3 路径，按键排序，每行一个
+func s3URIs(bucket string, objects []s3client.S3Object) string {
+	uris := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		uris = append(uris, s3URI(bucket, obj.Key))
+	}
+	slices.Sort(uris)
+	return strings.Join(uris, "\n")
+}
+
+// copyS3URIs 将选中对象的 S3 路径复制到剪贴板
+func (ov *ObjectsView) copyS3URIs(objects []s3client.S3Object) {
+	if len(objects) == 0 || ov.currentBucket == "" {
+		return
+	}
+	text := s3URIs(ov.currentBucket, objects)
+	ov.window.Clipboard().SetContent(text)
+	if len(objects) == 1 {
+		ShowToast(ov.window, fmt.Sprintf(T("已复制 S3 路径: %s"), text))
+	} else {
+		ShowToast(ov.window, fmt.Sprintf(T("已复制 %d 个 S3 路径。"), len(objects)))
+	}
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestS3URIs(t *testing.T) {
+	if got := s3URI("bucket", "docs/a.txt"); got != "s3://bucket/docs/a.txt" {
+		t.Errorf("s3URI() = %q", got)
+	}
+	objects := []s3client.S3Object{
+		{Key: "docs/b.txt"},
+		{Key: "docs/sub/", IsFolder: true},
+		{Key: "docs/a.txt"},
+	}
+	want := "s3://bucket/docs/a.txt\ns3://bucket/docs/b.txt\ns3://bucket/docs/sub/"
+	if got := s3URIs("bucket", objects); got != want {
+		t.Errorf("s3URIs() = %q, 期望 %q", got, want)
+	}
+}
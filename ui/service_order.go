@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2/dialog"
+)
+
+// moveSelectedService 将选中的服务在列表中上移（delta < 0）或下移（delta > 0）一位并保存顺序，
+// 移动后保持选中该服务，不重新连接。
+func (sv *ServicesView) moveSelectedService(delta int) {
+	if sv.configStore == nil || sv.selectedServiceID < 0 || sv.selectedServiceID >= len(sv.configStore.Services) {
+		return
+	}
+	alias := sv.configStore.Services[sv.selectedServiceID].Alias
+	if err := sv.configStore.ReorderService(alias, delta); err != nil {
+		dialog.ShowError(fmt.Errorf(T("调整服务顺序失败: %v"), err), sv.window)
+		return
+	}
+	sv.loadConfig(func() {
+		for i, svc := range sv.configStore.Services {
+			if svc.Alias == alias {
+				sv.selectedServiceID = i
+				break
+			}
+		}
+		sv.serviceList.Refresh()
+		sv.updateButtonsState()
+	})
+}
+
+// updateMoveButtons 根据选中服务的位置启用上移/下移按钮
+func (sv *ServicesView) updateMoveButtons() {
+	if sv.moveUpButton == nil || sv.moveDownButton == nil {
+		return
+	}
+	count := 0
+	if sv.configStore != nil {
+		count = len(sv.configStore.Services)
+	}
+	if sv.selectedServiceID > 0 && sv.selectedServiceID < count {
+		sv.moveUpButton.Enable()
+	} else {
+		sv.moveUpButton.Disable()
+	}
+	if sv.selectedServiceID >= 0 && sv.selectedServiceID < count-1 {
+		sv.moveDownButton.Enable()
+	} else {
+		sv.moveDownButton.Disable()
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/config"
+)
+
+// servicesExportFileName 是导出服务配置时默认的文件名
+const servicesExportFileName = "s3-explorer-services.json"
+
+// ShowExportServicesDialog 询问是否包含密钥，然后将所有服务配置导出到用户选择的 JSON 文件
+func (sv *ServicesView) ShowExportServicesDialog() {
+	if sv.configStore == nil || len(sv.configStore.Services) == 0 {
+		dialog.ShowInformation(T("提示"), T("还没有配置服务"), sv.window)
+		return
+	}
+	services := sv.configStore.Services
+
+	secretsCheck := widget.NewCheck(T("包含 Secret Key 和会话令牌"), nil)
+	hint := widget.NewLabel(T("密钥将以明文写入文件，请妥善保管导出的文件。"))
+	hint.Wrapping = fyne.TextWrapWord
+	items := []*widget.FormItem{
+		widget.NewFormItem("", secretsCheck),
+		widget.NewFormItem("", hint),
+	}
+	dialog.ShowForm(fmt.Sprintf(T("导出 %d 个服务"), len(services)), T("选择保存位置"), T("取消"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		data, err := config.ExportServices(services, secretsCheck.Checked)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(T("导出配置失败: %v"), err), sv.window)
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, sv.window)
+				return
+			}
+			if writer == nil {
+				return // 用户取消
+			}
+			defer writer.Close()
+			if _, err := writer.Write(data); err != nil {
+				dialog.ShowError(fmt.Errorf(T("导出配置失败: %v"), err), sv.window)
+				return
+			}
+			ShowToast(sv.window, fmt.Sprintf(T("已导出 %d 个服务。"), len(services)))
+		}, sv.window)
+		saveDialog.SetFileName(servicesExportFileName)
+		saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+		saveDialog.Show()
+	}, sv.window)
+}
+
+// ShowImportServicesDialog 从用户选择的 JSON 文件导入服务配置，按别名合并；
+// 存在同名服务时询问跳过还是覆盖。
+func (sv *ServicesView) ShowImportServicesDialog() {
+	if sv.configStore == nil {
+		return
+	}
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, sv.window)
+			return
+		}
+		if reader == nil {
+			return // 用户取消
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(T("导入配置失败: %v"), err), sv.window)
+			return
+		}
+		services, err := config.ParseServices(data)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(T("导入配置失败: %v"), err), sv.window)
+			return
+		}
+		if len(services) == 0 {
+			dialog.ShowInformation(T("提示"), T("文件中没有服务配置。"), sv.window)
+			return
+		}
+
+		conflicts := sv.configStore.ConflictingAliases(services)
+		if len(conflicts) == 0 {
+			sv.importServices(services, false)
+			return
+		}
+		skipOption, overwriteOption := T("跳过同名服务"), T("覆盖同名服务")
+		conflictRadio := widget.NewRadioGroup([]string{skipOption, overwriteOption}, nil)
+		conflictRadio.SetSelected(skipOption)
+		conflictLabel := widget.NewLabel(fmt.Sprintf(T("以下服务已存在: %s"), strings.Join(conflicts, ", ")))
+		conflictLabel.Wrapping = fyne.TextWrapWord
+		items := []*widget.FormItem{
+			widget.NewFormItem("", conflictLabel),
+			widget.NewFormItem(T("同名服务"), conflictRadio),
+		}
+		d := dialog.NewForm(T("导入配置"), T("导入"), T("取消"), items, func(ok bool) {
+			if ok {
+				sv.importServices(services, conflictRadio.Selected == overwriteOption)
+			}
+		}, sv.window)
+		d.Resize(fyne.NewSize(420, 240))
+		d.Show()
+	}, sv.window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	openDialog.Show()
+}
+
+// importServices 保存导入的服务并重新加载服务列表。导入的服务没有密钥时需要用户编辑后补充。
+func (sv *ServicesView) importServices(services []config.S3ServiceConfig, overwrite bool) {
+	result, err := sv.configStore.ImportServices(services, overwrite)
+	sv.loadConfig(nil)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(T("导入配置失败: %v"), err), sv.window)
+		return
+	}
+	ShowToast(sv.window, fmt.Sprintf(T("已添加 %d 个服务，覆盖 %d 个，跳过 %d 个。"), result.Added, result.Updated, result.Skipped))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -14,6 +15,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"s3-explorer/config" // 导入我们之前创建的 config 包
+	"s3-explorer/s3client"
 )
 
 // serviceListEntry 是服务列表的自定义列表项
@@ -72,10 +74,17 @@ type ServicesView struct {
 	selectedServiceID widget.ListItemID
 	loadingIndicator  *ThinProgressBar
 	editButton        *widget.Button
+	duplicateButton   *widget.Button
+	moveUpButton      *widget.Button
+	moveDownButton    *widget.Button
 	deleteButton      *widget.Button
 	animationManager  *AnimationManager // 添加动画管理器
+	emptyState        fyne.CanvasObject // 没有服务时显示的引导内容
+	pendingReauth     *reauthRequest    // 正在进行的重新认证，为 nil 表示没有等待中的重新认证
 
 	OnServiceSelected func(svc config.S3ServiceConfig)
+	// OnServicesLoaded 在服务列表加载完成后触发，参数为服务数量
+	OnServicesLoaded func(count int)
 }
 
 // NewServicesView 创建并返回一个新的 ServicesView 实例
@@ -93,6 +102,42 @@ func NewServicesView(w fyne.Window, am *AnimationManager) *ServicesView { // 修
 
 // UpdateServiceViewMode 更新内存中服务的视图模式并保存到文件
 func (sv *ServicesView) UpdateServiceViewMode(alias string, viewMode string) {
+	sv.updateService(alias, "视图模式", func(svc *config.S3ServiceConfig) {
+		svc.ViewMode = viewMode
+	})
+}
+
+// UpdateServiceFoldersFirst 更新服务的"文件夹优先"排序设置并保存
+func (sv *ServicesView) UpdateServiceFoldersFirst(alias string, foldersFirst bool) {
+	sv.updateService(alias, "排序方式", func(svc *config.S3ServiceConfig) {
+		svc.FoldersFirst = foldersFirst
+	})
+}
+
+// UpdateServiceUploadStorageClass 更新服务上传时使用的存储类别并保存
+func (sv *ServicesView) UpdateServiceUploadStorageClass(alias, storageClass string) {
+	sv.updateService(alias, "存储类别", func(svc *config.S3ServiceConfig) {
+		svc.UploadStorageClass = storageClass
+	})
+}
+
+// UpdateServiceSort 更新服务对象列表的排序字段和方向并保存
+func (sv *ServicesView) UpdateServiceSort(alias, field string, descending bool) {
+	sv.updateService(alias, "排序字段", func(svc *config.S3ServiceConfig) {
+		svc.SortField = field
+		svc.SortDescending = descending
+	})
+}
+
+// UpdateServicePageSize 更新服务的每页显示数量并保存
+func (sv *ServicesView) UpdateServicePageSize(alias string, pageSize int) {
+	sv.updateService(alias, "每页显示数量", func(svc *config.S3ServiceConfig) {
+		svc.PageSize = pageSize
+	})
+}
+
+// updateService 查找指定别名的服务，使用 modify 修改后保存并重新加载配置
+func (sv *ServicesView) updateService(alias, what string, modify func(svc *config.S3ServiceConfig)) {
 	if sv.configStore == nil {
 		return
 	}
@@ -108,15 +153,15 @@ func (sv *ServicesView) UpdateServiceViewMode(alias string, viewMode string) {
 	}
 
 	if found {
-		serviceToUpdate.ViewMode = viewMode
+		modify(&serviceToUpdate)
 		err := sv.configStore.UpdateService(alias, serviceToUpdate)
 		if err != nil {
-			log.Printf("更新服务 '%s' 的视图模式失败: %v", alias, err)
+			log.Printf("更新服务 '%s' 的%s失败: %v", alias, what, err)
 		} else {
 			sv.loadConfig(nil)
 		}
 	} else {
-		log.Printf("无法找到服务 '%s' 来更新视图模式。", alias)
+		log.Printf("无法找到服务 '%s' 来更新%s。", alias, what)
 	}
 }
 
@@ -138,18 +183,37 @@ func (sv *ServicesView) handleServiceTapped(id widget.ListItemID) {
 	sv.updateButtonsState()
 }
 
+// SelectService 选中指定别名的服务并触发 OnServiceSelected，服务不存在时返回 false
+func (sv *ServicesView) SelectService(alias string) bool {
+	if sv.configStore == nil {
+		return false
+	}
+	for i, svc := range sv.configStore.Services {
+		if svc.Alias == alias {
+			if sv.selectedServiceID != i {
+				sv.handleServiceTapped(i)
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // updateButtonsState 根据选择状态更新按钮可用性
 func (sv *ServicesView) updateButtonsState() {
-	if sv.editButton == nil || sv.deleteButton == nil {
+	if sv.editButton == nil || sv.duplicateButton == nil || sv.deleteButton == nil {
 		return
 	}
 	if sv.selectedServiceID == -1 {
 		sv.editButton.Disable()
+		sv.duplicateButton.Disable()
 		sv.deleteButton.Disable()
 	} else {
 		sv.editButton.Enable()
+		sv.duplicateButton.Enable()
 		sv.deleteButton.Enable()
 	}
+	sv.updateMoveButtons()
 }
 
 // loadConfig 加载 S3 服务配置，并在完成后执行回调
@@ -162,11 +226,17 @@ func (sv *ServicesView) loadConfig(onComplete func()) {
 			if err != nil {
 				log.Printf("加载配置失败: %v", err)
 				sv.configStore = &config.ConfigStore{Services: []config.S3ServiceConfig{}}
-				dialog.ShowError(fmt.Errorf("加载配置失败: %v", err), sv.window)
+				dialog.ShowError(fmt.Errorf(T("加载配置失败: %v"), err), sv.window)
 			} else {
 				sv.configStore = store
 			}
 			sv.refreshServiceList()
+			if sv.OnServicesLoaded != nil {
+				sv.OnServicesLoaded(len(sv.configStore.Services))
+			}
+			if err == nil && len(sv.configStore.Services) == 0 {
+				sv.maybeShowOnboarding()
+			}
 			if onComplete != nil {
 				onComplete()
 			}
@@ -180,35 +250,285 @@ func (sv *ServicesView) refreshServiceList() {
 		return
 	}
 	sv.serviceList.Refresh()
+
+	if sv.emptyState != nil {
+		if sv.configStore == nil || len(sv.configStore.Services) == 0 {
+			sv.emptyState.Show()
+		} else {
+			sv.emptyState.Hide()
+		}
+	}
+}
+
+// serviceForm 保存添加/编辑服务对话框中的输入控件
+type serviceForm struct {
+	content         fyne.CanvasObject
+	aliasEntry      *widget.Entry
+	endpointEntry   *widget.Entry
+	accessKeyEntry  *widget.Entry
+	secretKeyEntry  *widget.Entry
+	tokenEntry      *widget.Entry // 临时凭证的会话令牌，可选
+	proxyEntry      *widget.Entry
+	consoleURLEntry *widget.Entry
+	anonymousCheck  *widget.Check
+	ignoreCaseCheck *widget.Check
+	pathStyleCheck  *widget.Check
+	providerSelect  *widget.Select
+	regionEntry     *widget.Entry
+	checksumCheck   *widget.Check
+	testButton      *widget.Button
+
+	provider         s3client.Provider // 当前选择的服务类型
+	presetEndpoint   string            // 最近一次根据服务类型自动填写的 Endpoint，用户修改后不再随区域变化
+	pathStyleTouched bool              // 用户是否手动修改过路径风格选项，修改过后不再随 Endpoint 自动变化
+	settingPathStyle bool              // 正在根据 Endpoint 自动设置路径风格选项
 }
 
-// createServiceFormContent 创建一个用于添加/编辑服务配置的表单内容
-func (sv *ServicesView) createServiceFormContent(service *config.S3ServiceConfig) (fyne.CanvasObject, *widget.Entry, *widget.Entry, *widget.Entry, *widget.Entry, *widget.Entry) {
-	aliasEntry := widget.NewEntry()
-	aliasEntry.SetPlaceHolder("例如：我的Minio")
-	endpointEntry := widget.NewEntry()
-	endpointEntry.SetPlaceHolder("例如：http://localhost:9000")
-	accessKeyEntry := widget.NewEntry()
-	secretKeyEntry := widget.NewPasswordEntry()
-	proxyEntry := widget.NewEntry()
-	proxyEntry.SetPlaceHolder("例如：http://127.0.0.1:7890")
+// newServiceForm 创建一个用于添加/编辑服务配置的表单，service 不为 nil 时用其填充表单
+func (sv *ServicesView) newServiceForm(service *config.S3ServiceConfig) *serviceForm {
+	f := &serviceForm{
+		aliasEntry:      widget.NewEntry(),
+		endpointEntry:   widget.NewEntry(),
+		accessKeyEntry:  widget.NewEntry(),
+		secretKeyEntry:  widget.NewPasswordEntry(),
+		tokenEntry:      widget.NewPasswordEntry(),
+		proxyEntry:      widget.NewEntry(),
+		consoleURLEntry: widget.NewEntry(),
+		regionEntry:     widget.NewEntry(),
+		provider:        s3client.ProviderByID(s3client.ProviderCustom),
+	}
+	f.aliasEntry.SetPlaceHolder(T("例如：我的Minio"))
+	f.endpointEntry.SetPlaceHolder(T("例如：http://localhost:9000"))
+	f.tokenEntry.SetPlaceHolder(T("可选，使用临时凭证时填写"))
+	f.proxyEntry.SetPlaceHolder(T("例如：http://127.0.0.1:7890"))
+	f.consoleURLEntry.SetPlaceHolder(T("可选，例如：http://localhost:9001"))
+	// 部分网关不区分对象键的大小写，开启后检测同名对象时忽略大小写，避免意外覆盖
+	f.ignoreCaseCheck = widget.NewCheck(T("不区分大小写"), nil)
+	// MinIO 等服务需要路径风格访问，AWS 推荐虚拟主机风格；未手动修改时根据 Endpoint 自动选择
+	f.pathStyleCheck = widget.NewCheck(T("路径风格访问"), func(bool) {
+		if !f.settingPathStyle {
+			f.pathStyleTouched = true
+		}
+	})
+	f.endpointEntry.OnChanged = func(endpoint string) {
+		if f.pathStyleTouched {
+			return
+		}
+		pathStyle := f.provider.PathStyle
+		if f.provider.ID == s3client.ProviderCustom {
+			pathStyle = s3client.DefaultPathStyle(endpoint)
+		}
+		f.settingPathStyle = true
+		f.pathStyleCheck.SetChecked(pathStyle)
+		f.settingPathStyle = false
+	}
+	// Endpoint 仍是自动填写的值时随区域一起变化
+	f.regionEntry.SetPlaceHolder("us-east-1")
+	f.regionEntry.OnChanged = func(region string) {
+		if f.presetEndpoint == "" || f.endpointEntry.Text != f.presetEndpoint {
+			return
+		}
+		f.presetEndpoint = f.provider.Endpoint(region)
+		f.endpointEntry.SetText(f.presetEndpoint)
+	}
+	// 阿里云 OSS 等服务不支持新版 SDK 默认附加的校验和请求头
+	f.checksumCheck = widget.NewCheck(T("仅在必需时计算校验和"), nil)
+
+	// 选择服务类型后填入该服务推荐的 Endpoint、区域、路径风格和校验和设置，各项仍可手动修改
+	providerNames := make([]string, len(s3client.Providers))
+	for i, p := range s3client.Providers {
+		providerNames[i] = T(p.Name)
+	}
+	f.providerSelect = widget.NewSelect(providerNames, nil)
+	// 保存前先用当前填写的设置测试连接，避免保存错误的 Endpoint 或密钥
+	f.testButton = widget.NewButtonWithIcon(T("测试连接"), theme.ConfirmIcon(), func() { sv.testConnection(f) })
+	// 匿名访问不需要凭证，勾选后禁用 Access Key 和 Secret Key 输入框
+	f.anonymousCheck = widget.NewCheck(T("匿名访问（仅浏览公开存储桶）"), func(checked bool) {
+		if checked {
+			f.accessKeyEntry.Disable()
+			f.secretKeyEntry.Disable()
+			f.tokenEntry.Disable()
+		} else {
+			f.accessKeyEntry.Enable()
+			f.secretKeyEntry.Enable()
+			f.tokenEntry.Enable()
+		}
+	})
 
 	if service != nil {
-		aliasEntry.SetText(service.Alias)
-		endpointEntry.SetText(service.Endpoint)
-		accessKeyEntry.SetText(service.AccessKey)
-		secretKeyEntry.SetText(service.SecretKey)
-		proxyEntry.SetText(service.Proxy)
+		f.aliasEntry.SetText(service.Alias)
+		f.endpointEntry.SetText(service.Endpoint)
+		f.accessKeyEntry.SetText(service.AccessKey)
+		f.secretKeyEntry.SetText(service.SecretKey)
+		f.tokenEntry.SetText(service.SessionToken)
+		f.proxyEntry.SetText(service.Proxy)
+		f.consoleURLEntry.SetText(service.ConsoleURL)
+		f.anonymousCheck.SetChecked(service.Anonymous)
+		f.ignoreCaseCheck.SetChecked(service.CaseInsensitiveKeys)
+		f.pathStyleCheck.SetChecked(service.PathStyle)
+		f.pathStyleTouched = true // 已保存的服务保持原有设置
+		f.regionEntry.SetText(service.Region)
+		f.checksumCheck.SetChecked(service.ChecksumWhenRequired)
+		f.provider = s3client.ProviderByID(service.Provider)
+	}
+	// 先显示当前的服务类型再设置回调，避免编辑已保存的服务时覆盖其设置
+	f.providerSelect.SetSelected(T(f.provider.Name))
+	f.providerSelect.OnChanged = func(name string) {
+		for _, p := range s3client.Providers {
+			if T(p.Name) == name {
+				f.applyProvider(p)
+				return
+			}
+		}
 	}
 
-	formContent := container.New(layout.NewFormLayout(),
-		widget.NewLabel("别名:"), aliasEntry,
-		widget.NewLabel("Endpoint:"), endpointEntry,
-		widget.NewLabel("Access Key:"), accessKeyEntry,
-		widget.NewLabel("Secret Key:"), secretKeyEntry,
-		widget.NewLabel("Proxy:"), proxyEntry,
+	f.content = container.New(layout.NewFormLayout(),
+		widget.NewLabel(T("服务类型:")), f.providerSelect,
+		widget.NewLabel(T("别名:")), f.aliasEntry,
+		widget.NewLabel("Endpoint:"), f.endpointEntry,
+		widget.NewLabel(T("区域:")), f.regionEntry,
+		widget.NewLabel("Access Key:"), f.accessKeyEntry,
+		widget.NewLabel("Secret Key:"), f.secretKeyEntry,
+		widget.NewLabel("Session Token:"), f.tokenEntry,
+		widget.NewLabel("Proxy:"), f.proxyEntry,
+		widget.NewLabel(T("控制台地址:")), f.consoleURLEntry,
+		widget.NewLabel(""), f.anonymousCheck,
+		widget.NewLabel(""), f.ignoreCaseCheck,
+		widget.NewLabel(""), f.pathStyleCheck,
+		widget.NewLabel(""), f.checksumCheck,
+		widget.NewLabel(""), container.NewHBox(f.testButton),
 	)
-	return formContent, aliasEntry, endpointEntry, accessKeyEntry, secretKeyEntry, proxyEntry
+	return f
+}
+
+// testConnection 使用表单中当前填写的设置创建临时客户端并列出存储桶，不会保存服务
+func (sv *ServicesView) testConnection(f *serviceForm) {
+	if f.endpointEntry.Text == "" || (!f.anonymousCheck.Checked && (f.accessKeyEntry.Text == "" || f.secretKeyEntry.Text == "")) {
+		dialog.ShowInformation(T("提示"), T("请先填写 Endpoint、Access Key 和 Secret Key"), sv.window)
+		return
+	}
+	client, err := s3client.NewS3Client(f.serviceConfig(config.S3ServiceConfig{}))
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(T("连接失败: %v"), T(err.Error())), sv.window)
+		return
+	}
+
+	f.testButton.Disable()
+	f.testButton.SetText(T("正在测试..."))
+	go func() {
+		count, err := client.TestConnection()
+		fyne.Do(func() {
+			f.testButton.SetText(T("测试连接"))
+			f.testButton.Enable()
+			switch {
+			case err != nil:
+				dialog.ShowError(fmt.Errorf(T("连接失败: %v"), err), sv.window)
+			case count < 0:
+				ShowToast(sv.window, T("连接成功（匿名访问无法列出存储桶）"))
+			default:
+				ShowToast(sv.window, fmt.Sprintf(T("连接成功，共 %d 个存储桶"), count))
+			}
+		})
+	}()
+}
+
+// applyProvider 切换服务类型并填入其推荐设置。自定义类型没有 Endpoint 模板，保留已填写的 Endpoint。
+func (f *serviceForm) applyProvider(p s3client.Provider) {
+	f.provider = p
+	f.presetEndpoint = ""
+	f.regionEntry.SetText(p.DefaultRegion)
+	if p.EndpointFormat != "" {
+		f.presetEndpoint = p.Endpoint(p.DefaultRegion)
+		f.endpointEntry.SetText(f.presetEndpoint)
+	}
+	f.settingPathStyle = true
+	if p.ID == s3client.ProviderCustom {
+		f.pathStyleCheck.SetChecked(s3client.DefaultPathStyle(f.endpointEntry.Text))
+	} else {
+		f.pathStyleCheck.SetChecked(p.PathStyle)
+	}
+	f.settingPathStyle = false
+	f.pathStyleTouched = false
+	f.checksumCheck.SetChecked(p.ChecksumWhenRequired)
+}
+
+// serviceConfig 将表单内容写入 base 并返回，base 中未出现在表单里的字段（如视图模式）保持不变
+func (f *serviceForm) serviceConfig(base config.S3ServiceConfig) config.S3ServiceConfig {
+	base.Alias = f.aliasEntry.Text
+	base.Endpoint = f.endpointEntry.Text
+	if endpoint, err := s3client.NormalizeEndpoint(f.endpointEntry.Text); err == nil {
+		base.Endpoint = endpoint
+	}
+	base.AccessKey = f.accessKeyEntry.Text
+	base.SecretKey = f.secretKeyEntry.Text
+	base.SessionToken = strings.TrimSpace(f.tokenEntry.Text)
+	base.Proxy = f.proxyEntry.Text
+	base.ConsoleURL = f.consoleURLEntry.Text
+	base.Anonymous = f.anonymousCheck.Checked
+	base.CaseInsensitiveKeys = f.ignoreCaseCheck.Checked
+	base.PathStyle = f.pathStyleCheck.Checked
+	base.Provider = f.provider.ID
+	base.Region = strings.TrimSpace(f.regionEntry.Text)
+	base.ChecksumWhenRequired = f.checksumCheck.Checked
+	return base
+}
+
+// validate 检查必填字段，返回提示信息；为空表示校验通过
+func (f *serviceForm) validate() string {
+	if f.aliasEntry.Text == "" || f.endpointEntry.Text == "" {
+		return T("除了代理和控制台地址，所有字段都不能为空！")
+	}
+	if !f.anonymousCheck.Checked && (f.accessKeyEntry.Text == "" || f.secretKeyEntry.Text == "") {
+		return T("除了代理和控制台地址，所有字段都不能为空！")
+	}
+	if _, err := s3client.NormalizeEndpoint(f.endpointEntry.Text); err != nil {
+		return T(err.Error())
+	}
+	return ""
+}
+
+// ShowAddServiceDialog 显示添加服务的对话框，添加成功后自动选中新服务
+func (sv *ServicesView) ShowAddServiceDialog() {
+	sv.showAddServiceDialog(nil)
+}
+
+// showAddServiceDialog 显示添加服务的对话框，prefill 不为 nil 时用其预先填充表单
+func (sv *ServicesView) showAddServiceDialog(prefill *config.S3ServiceConfig) {
+	form := sv.newServiceForm(prefill)
+	d := dialog.NewCustomConfirm(T("添加 S3 服务"), T("添加"), T("取消"), form.content, func(confirmed bool) {
+		if confirmed {
+			if msg := form.validate(); msg != "" {
+				dialog.ShowInformation(T("提示"), msg, sv.window)
+				return
+			}
+			// 新服务使用设置中的默认视图模式和每页显示数量
+			newService := form.serviceConfig(config.S3ServiceConfig{
+				ViewMode:     defaultViewModeSetting(),
+				PageSize:     defaultPageSizeSetting(),
+				FoldersFirst: true,
+			})
+			err := sv.configStore.AddService(newService)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(T("添加服务失败: %v"), err), sv.window)
+				return
+			}
+			sv.loadConfig(func() {
+				// 添加后，自动选择新添加的服务
+				newlySelectedID := -1
+				for i, svc := range sv.configStore.Services {
+					if svc.Alias == newService.Alias {
+						newlySelectedID = i
+						break
+					}
+				}
+				if newlySelectedID != -1 {
+					sv.handleServiceTapped(newlySelectedID)
+				}
+			})
+		}
+	}, sv.window)
+	d.Resize(fyne.NewSize(420, 560))
+	d.Show()
 }
 
 // GetContent 返回 ServicesView 的 Fyne UI 内容
@@ -222,7 +542,7 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 		},
 		func() fyne.CanvasObject {
 			entry := &serviceListEntry{
-				label: widget.NewLabel("服务别名"),
+				label: widget.NewLabel(T("服务别名")),
 				sv:    sv,
 			}
 			entry.ExtendBaseWidget(entry)
@@ -240,42 +560,7 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 	// 添加服务按钮
 	addButton := widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() {
 		// 动画结束后执行的逻辑
-		formContent, aliasEntry, endpointEntry, accessKeyEntry, secretKeyEntry, proxyEntry := sv.createServiceFormContent(nil)
-		d := dialog.NewCustomConfirm("添加 S3 服务", "添加", "取消", formContent, func(confirmed bool) {
-			if confirmed {
-				newService := config.S3ServiceConfig{
-					Alias:     aliasEntry.Text,
-					Endpoint:  endpointEntry.Text,
-					AccessKey: accessKeyEntry.Text,
-					SecretKey: secretKeyEntry.Text,
-					Proxy:     proxyEntry.Text,
-				}
-				if newService.Alias == "" || newService.Endpoint == "" || newService.AccessKey == "" || newService.SecretKey == "" {
-					dialog.ShowInformation("提示", "除了代理，所有字段都不能为空！", sv.window)
-					return
-				}
-				err := sv.configStore.AddService(newService)
-				if err != nil {
-					dialog.ShowError(fmt.Errorf("添加服务失败: %v", err), sv.window)
-					return
-				}
-				sv.loadConfig(func() {
-					// 添加后，自动选择新添加的服务
-					newlySelectedID := -1
-					for i, svc := range sv.configStore.Services {
-						if svc.Alias == newService.Alias {
-							newlySelectedID = i
-							break
-						}
-					}
-					if newlySelectedID != -1 {
-						sv.handleServiceTapped(newlySelectedID)
-					}
-				})
-			}
-		}, sv.window)
-		d.Resize(fyne.NewSize(400, 250))
-		d.Show()
+		sv.ShowAddServiceDialog()
 	})
 	
 	// 为按钮添加点击动画
@@ -293,29 +578,22 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 	// 编辑服务按钮
 	sv.editButton = widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
 		if sv.selectedServiceID == -1 || sv.selectedServiceID >= len(sv.configStore.Services) {
-			dialog.ShowInformation("提示", "请先选择一个要编辑的服务。", sv.window)
+			dialog.ShowInformation(T("提示"), T("请先选择一个要编辑的服务。"), sv.window)
 			return
 		}
 		selectedService := sv.configStore.Services[sv.selectedServiceID]
 		oldAlias := selectedService.Alias
-		formContent, aliasEntry, endpointEntry, accessKeyEntry, secretKeyEntry, proxyEntry := sv.createServiceFormContent(&selectedService)
-		d := dialog.NewCustomConfirm("编辑 S3 服务", "保存", "取消", formContent, func(confirmed bool) {
+		form := sv.newServiceForm(&selectedService)
+		d := dialog.NewCustomConfirm(T("编辑 S3 服务"), T("保存"), T("取消"), form.content, func(confirmed bool) {
 			if confirmed {
-				newService := config.S3ServiceConfig{
-					Alias:     aliasEntry.Text,
-					Endpoint:  endpointEntry.Text,
-					AccessKey: accessKeyEntry.Text,
-					SecretKey: secretKeyEntry.Text,
-					ViewMode:  selectedService.ViewMode,
-					Proxy:     proxyEntry.Text,
-				}
-				if newService.Alias == "" || newService.Endpoint == "" || newService.AccessKey == "" || newService.SecretKey == "" {
-					dialog.ShowInformation("提示", "除了代理，所有字段都不能为空！", sv.window)
+				if msg := form.validate(); msg != "" {
+					dialog.ShowInformation(T("提示"), msg, sv.window)
 					return
 				}
+				newService := form.serviceConfig(selectedService)
 				err := sv.configStore.UpdateService(oldAlias, newService)
 				if err != nil {
-					dialog.ShowError(fmt.Errorf("更新服务失败: %v", err), sv.window)
+					dialog.ShowError(fmt.Errorf(T("更新服务失败: %v"), err), sv.window)
 					return
 				}
 				sv.loadConfig(func() {
@@ -336,7 +614,7 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 				})
 			}
 		}, sv.window)
-		d.Resize(fyne.NewSize(400, 250))
+		d.Resize(fyne.NewSize(420, 560))
 		d.Show()
 	})
 	
@@ -352,19 +630,26 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 		}
 	}
 
+	// 复制服务按钮
+	sv.duplicateButton = widget.NewButtonWithIcon("", theme.ContentCopyIcon(), sv.duplicateSelectedService)
+
+	// 上移/下移服务按钮
+	sv.moveUpButton = widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() { sv.moveSelectedService(-1) })
+	sv.moveDownButton = widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() { sv.moveSelectedService(1) })
+
 	// 删除服务按钮
 	sv.deleteButton = widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
 		if sv.selectedServiceID == -1 || sv.selectedServiceID >= len(sv.configStore.Services) {
-			dialog.ShowInformation("提示", "请先选择一个要删除的服务。", sv.window)
+			dialog.ShowInformation(T("提示"), T("请先选择一个要删除的服务。"), sv.window)
 			return
 		}
 		selectedService := sv.configStore.Services[sv.selectedServiceID]
 
-		dialog.ShowConfirm("确认删除", fmt.Sprintf("确定要删除服务 \"%s\" 吗？", selectedService.Alias), func(confirmed bool) {
+		dialog.ShowConfirm(T("确认删除"), fmt.Sprintf(T("确定要删除服务 \"%s\" 吗？"), selectedService.Alias), func(confirmed bool) {
 			if confirmed {
 				err := sv.configStore.DeleteService(selectedService.Alias)
 				if err != nil {
-					dialog.ShowError(fmt.Errorf("删除服务失败: %v", err), sv.window)
+					dialog.ShowError(fmt.Errorf(T("删除服务失败: %v"), err), sv.window)
 					return
 				}
 				sv.loadConfig(func() {
@@ -398,6 +683,11 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 		layout.NewSpacer(),
 		sv.editButton,
 		layout.NewSpacer(),
+		sv.duplicateButton,
+		layout.NewSpacer(),
+		sv.moveUpButton,
+		sv.moveDownButton,
+		layout.NewSpacer(),
 		sv.deleteButton,
 		layout.NewSpacer(),
 		sv.loadingIndicator,
@@ -405,5 +695,10 @@ func (sv *ServicesView) GetContent() fyne.CanvasObject {
 
 	topContent := container.NewVBox(buttonBox, widget.NewSeparator())
 
-	return container.NewBorder(topContent, nil, nil, nil, sv.serviceList)
+	emptyAddButton := widget.NewButtonWithIcon(T("添加服务"), theme.ContentAddIcon(), sv.ShowAddServiceDialog)
+	emptyAddButton.Importance = widget.HighImportance
+	sv.emptyState = newEmptyState(theme.StorageIcon(), T("还没有配置服务"), emptyAddButton)
+	sv.refreshServiceList()
+
+	return container.NewBorder(topContent, nil, nil, nil, container.NewStack(sv.serviceList, sv.emptyState))
 }
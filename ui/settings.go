@@ -0,0 +1,382 @@
+package ui
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/common"
+	"s3-explorer/config"
+	"s3-explorer/s3client"
+)
+
+// 应用级设置保存在 Fyne Preferences 中，单个服务的设置保存在数据库的服务配置中
+const (
+	prefDefaultPageSize = "defaultPageSize" // 新服务的默认每页显示数量
+	prefDefaultViewMode = "defaultViewMode" // 新服务的默认视图模式
+
+	prefUploadTimestamp         = "uploadTimestamp"         // 上传文件时是否追加时间戳
+	prefUploadTimestampTemplate = "uploadTimestampTemplate" // 追加时间戳的命名模板
+	prefUploadSkipUnchanged     = "uploadSkipUnchanged"     // 上传时是否跳过内容未更改的文件
+
+	prefOperationTimeout  = "operationTimeout"  // 单次请求的超时时间（秒）
+	prefTransferRetries   = "transferRetries"   // 上传/下载单个文件失败后的重试次数
+	prefPartSizeMB        = "partSizeMB"        // 分段上传的分段大小（MiB）
+	prefUploadConcurrency = "uploadConcurrency" // 分段上传时同时上传的分段数量
+	prefTransferWorkers   = "transferWorkers"   // 批量上传、下载和删除时同时处理的文件数量
+	prefOpenAfterDownload = "openAfterDownload" // 下载完成后是否自动打开文件或所在文件夹
+
+	prefLanguage = "language" // 界面语言：auto、zh 或 en
+	prefFontPath = "fontPath" // 用户指定的界面字体文件路径，为空时使用内嵌字体
+
+	prefThemeVariant = "themeVariant" // 界面主题：system、light 或 dark
+
+	prefShowFolderCounts = "showFolderCounts" // 是否显示文件夹中的对象数量
+	prefRelativeTime     = "relativeTime"     // 是否以相对时间显示对象的修改时间
+
+	prefLoadThumbnails       = "loadThumbnails"       // 是否为图片加载缩略图
+	prefThumbnailConcurrency = "thumbnailConcurrency" // 同时下载缩略图的数量
+
+	prefConfirmDelete    = "confirmDelete"    // 删除对象前是否确认
+	prefConfirmOverwrite = "confirmOverwrite" // 覆盖已有对象前是否确认
+	prefConfirmPaste     = "confirmPaste"     // 粘贴复制的对象前是否确认
+
+	prefSuppressPastePrompt       = "suppressPrompt.paste"       // 粘贴确认中勾选了“不再提示”
+	prefSuppressLargeUploadPrompt = "suppressPrompt.largeUpload" // 大文件上传提示中勾选了“不再提示”
+	prefSuppressMovePrompt        = "suppressPrompt.move"        // 拖动移动确认中勾选了“不再提示”
+
+	prefOnboardingShown = "onboardingShown" // 首次运行的引导是否已经显示过
+)
+
+// defaultPageSizeSetting 返回新服务的默认每页显示数量
+func defaultPageSizeSetting() int {
+	return fyne.CurrentApp().Preferences().IntWithFallback(prefDefaultPageSize, config.DefaultPageSize)
+}
+
+// defaultViewModeSetting 返回新服务的默认视图模式
+func defaultViewModeSetting() string {
+	return fyne.CurrentApp().Preferences().StringWithFallback(prefDefaultViewMode, listViewMode)
+}
+
+// uploadTimestampSetting 返回上传时是否追加时间戳以及命名模板
+func uploadTimestampSetting() (bool, string) {
+	prefs := fyne.CurrentApp().Preferences()
+	return prefs.Bool(prefUploadTimestamp), prefs.StringWithFallback(prefUploadTimestampTemplate, common.DefaultTimestampTemplate)
+}
+
+// newUploadTimestampOptions 创建上传对话框中的时间戳命名选项，修改会立即保存
+func newUploadTimestampOptions() fyne.CanvasObject {
+	prefs := fyne.CurrentApp().Preferences()
+	enabled, template := uploadTimestampSetting()
+
+	templateEntry := widget.NewEntry()
+	templateEntry.SetText(template)
+	templateEntry.OnChanged = func(s string) {
+		prefs.SetString(prefUploadTimestampTemplate, s)
+	}
+
+	timestampCheck := widget.NewCheck(T("文件名追加时间戳"), func(checked bool) {
+		prefs.SetBool(prefUploadTimestamp, checked)
+		if checked {
+			templateEntry.Enable()
+		} else {
+			templateEntry.Disable()
+		}
+	})
+	timestampCheck.SetChecked(enabled)
+	if !enabled {
+		templateEntry.Disable()
+	}
+
+	hint := widget.NewLabel(T("可用占位符: {name} {ext} {YYYY} {MM} {DD} {HH} {mm} {ss}"))
+	hint.Wrapping = fyne.TextWrapWord
+	return container.NewVBox(timestampCheck, templateEntry, hint)
+}
+
+// uploadSkipUnchangedSetting 返回上传时是否跳过与已有对象内容相同的文件，默认关闭
+func uploadSkipUnchangedSetting() bool {
+	return fyne.CurrentApp().Preferences().Bool(prefUploadSkipUnchanged)
+}
+
+// newUploadSkipUnchangedOption 创建上传对话框中的“跳过未更改的文件”选项，修改会立即保存
+func newUploadSkipUnchangedOption() fyne.CanvasObject {
+	check := widget.NewCheck(T("跳过未更改的文件"), func(checked bool) {
+		fyne.CurrentApp().Preferences().SetBool(prefUploadSkipUnchanged, checked)
+	})
+	check.SetChecked(uploadSkipUnchangedSetting())
+	hint := widget.NewLabel(T("开启后上传到同名位置：内容相同的文件被跳过，内容不同的文件将覆盖已有对象。"))
+	hint.Wrapping = fyne.TextWrapWord
+	return container.NewVBox(check, hint)
+}
+
+// ShowNewServiceDefaultsDialog 显示新服务默认设置对话框，修改只影响之后添加的服务
+func ShowNewServiceDefaultsDialog(w fyne.Window) {
+	showSettingsPages(w, T("新服务默认设置"), fyne.NewSize(400, 220), newServiceDefaultsPage())
+}
+
+// newServiceDefaultsPage 创建新服务默认视图和每页显示数量的设置页
+func newServiceDefaultsPage() settingsPage {
+	viewModeOptions := map[string]string{T("列表"): listViewMode, T("缩略图"): gridViewMode}
+	viewModeSelect := widget.NewSelect([]string{T("列表"), T("缩略图")}, nil)
+	if defaultViewModeSetting() == gridViewMode {
+		viewModeSelect.SetSelected(T("缩略图"))
+	} else {
+		viewModeSelect.SetSelected(T("列表"))
+	}
+
+	pageSizeEntry := widget.NewEntry()
+	pageSizeEntry.SetText(strconv.Itoa(defaultPageSizeSetting()))
+
+	var pageSize int
+	return settingsPage{
+		title: T("新服务默认设置"),
+		content: container.NewVBox(
+			container.New(layout.NewFormLayout(),
+				widget.NewLabel(T("默认视图:")), viewModeSelect,
+				widget.NewLabel(T("每页显示:")), pageSizeEntry,
+			),
+			widget.NewLabel(T("以上设置仅应用于新添加的服务，每页显示为 0 表示不分页。")),
+		),
+		validate: func() error {
+			var ok bool
+			if pageSize, ok = parseIntSetting(pageSizeEntry.Text, 0, math.MaxInt); !ok {
+				return errors.New(T("无效的页面大小"))
+			}
+			return nil
+		},
+		save: func() {
+			prefs := fyne.CurrentApp().Preferences()
+			prefs.SetInt(prefDefaultPageSize, pageSize)
+			prefs.SetString(prefDefaultViewMode, viewModeOptions[viewModeSelect.Selected])
+		},
+	}
+}
+
+// operationTimeoutSetting 返回单次请求的超时时间（秒）
+func operationTimeoutSetting() int {
+	return fyne.CurrentApp().Preferences().IntWithFallback(prefOperationTimeout, int(s3client.DefaultOperationTimeout/time.Second))
+}
+
+// defaultTransferRetries 是单个文件传输失败后的默认重试次数
+const defaultTransferRetries = 2
+
+// transferRetriesSetting 返回单个文件传输失败后的重试次数
+func transferRetriesSetting() int {
+	return fyne.CurrentApp().Preferences().IntWithFallback(prefTransferRetries, defaultTransferRetries)
+}
+
+// openAfterDownloadSetting 返回下载完成后是否自动打开文件或所在文件夹，默认关闭
+func openAfterDownloadSetting() bool {
+	return fyne.CurrentApp().Preferences().Bool(prefOpenAfterDownload)
+}
+
+// S3 允许的分段大小范围（MiB）
+const (
+	minPartSizeMB = 5
+	maxPartSizeMB = s3client.MaxPartSize >> 20
+)
+
+// partSizeMBSetting 返回分段上传的分段大小（MiB）
+func partSizeMBSetting() int {
+	return fyne.CurrentApp().Preferences().IntWithFallback(prefPartSizeMB, s3client.DefaultPartSize>>20)
+}
+
+// uploadConcurrencySetting 返回分段上传时同时上传的分段数量
+func uploadConcurrencySetting() int {
+	return fyne.CurrentApp().Preferences().IntWithFallback(prefUploadConcurrency, s3client.DefaultUploadConcurrency)
+}
+
+// 传输并发数的默认值和允许范围
+const (
+	defaultTransferConcurrency = 10
+	minTransferConcurrency     = 1
+	maxTransferConcurrency     = 32
+)
+
+// transferConcurrencySetting 返回批量上传、下载和删除时同时处理的文件数量，超出范围的值被限制在 1–32 之间
+func transferConcurrencySetting() int {
+	n := fyne.CurrentApp().Preferences().IntWithFallback(prefTransferWorkers, defaultTransferConcurrency)
+	return min(max(n, minTransferConcurrency), maxTransferConcurrency)
+}
+
+// 单个大文件范围下载时并发下载的分段数量的允许范围
+const (
+	minRangeDownloadWorkers = 2
+	maxRangeDownloadWorkers = 8
+)
+
+// rangeWorkersFor 根据传输并发数返回单个大文件范围下载时的并发数，限制在 2–8 之间。
+// 批量下载时每个文件都会同时下载多个分段，因此分段并发数不随传输并发数无限增长。
+func rangeWorkersFor(transferConcurrency int) int {
+	return min(max(transferConcurrency/2, minRangeDownloadWorkers), maxRangeDownloadWorkers)
+}
+
+// showFolderCountsSetting 返回是否显示文件夹中的对象数量，默认关闭，因为会为每个文件夹额外发出列举请求
+func showFolderCountsSetting() bool {
+	return fyne.CurrentApp().Preferences().Bool(prefShowFolderCounts)
+}
+
+// showRelativeTimeSetting 返回是否以相对时间显示对象的修改时间，默认显示绝对时间
+func showRelativeTimeSetting() bool {
+	return fyne.CurrentApp().Preferences().Bool(prefRelativeTime)
+}
+
+// defaultThumbnailConcurrency 是默认同时下载缩略图的数量
+const defaultThumbnailConcurrency = 4
+
+// loadThumbnailsSetting 返回是否为图片加载缩略图，默认开启
+func loadThumbnailsSetting() bool {
+	return fyne.CurrentApp().Preferences().BoolWithFallback(prefLoadThumbnails, true)
+}
+
+// thumbnailConcurrencySetting 返回同时下载缩略图的数量
+func thumbnailConcurrencySetting() int {
+	return fyne.CurrentApp().Preferences().IntWithFallback(prefThumbnailConcurrency, defaultThumbnailConcurrency)
+}
+
+// 界面主题设置的取值
+const (
+	ThemeVariantSystem = "system" // 跟随系统的浅色/深色设置
+	ThemeVariantLight  = "light"
+	ThemeVariantDark   = "dark"
+)
+
+// ThemeVariantSetting 返回保存的界面主题，默认跟随系统
+func ThemeVariantSetting() string {
+	switch v := fyne.CurrentApp().Preferences().String(prefThemeVariant); v {
+	case ThemeVariantLight, ThemeVariantDark:
+		return v
+	}
+	return ThemeVariantSystem
+}
+
+// SetThemeVariantSetting 保存界面主题，需要调用方重新设置应用主题才能生效
+func SetThemeVariantSetting(variant string) {
+	fyne.CurrentApp().Preferences().SetString(prefThemeVariant, variant)
+}
+
+// FontPathSetting 返回用户指定的界面字体文件路径，未指定时返回空字符串
+func FontPathSetting() string {
+	return fyne.CurrentApp().Preferences().String(prefFontPath)
+}
+
+// ApplySavedSettings 将已保存的应用级设置应用到各模块，应在创建应用后调用
+func ApplySavedSettings() {
+	currentLanguage = resolveLanguage(languageSetting())
+	s3client.SetOperationTimeout(time.Duration(operationTimeoutSetting()) * time.Second)
+	s3client.SetMultipartUploadConfig(int64(partSizeMBSetting())<<20, uploadConcurrencySetting())
+	transferWorkers = transferConcurrencySetting()
+	rangeDownloadWorkers = rangeWorkersFor(transferWorkers)
+	s3client.SetMaxRetries(transferRetriesSetting())
+}
+
+// ShowTimeoutSettingsDialog 显示请求超时设置对话框，保存后立即生效
+func ShowTimeoutSettingsDialog(w fyne.Window) {
+	showSettingsPages(w, T("超时设置"), fyne.NewSize(420, 420), transferSettingsPage())
+}
+
+// transferSettingsPage 创建请求超时、重试次数和并发数等传输设置页，保存后立即生效
+func transferSettingsPage() settingsPage {
+	timeoutEntry := widget.NewEntry()
+	timeoutEntry.SetText(strconv.Itoa(operationTimeoutSetting()))
+	retriesEntry := widget.NewEntry()
+	retriesEntry.SetText(strconv.Itoa(transferRetriesSetting()))
+	thumbnailEntry := widget.NewEntry()
+	thumbnailEntry.SetText(strconv.Itoa(thumbnailConcurrencySetting()))
+	partSizeEntry := widget.NewEntry()
+	partSizeEntry.SetText(strconv.Itoa(partSizeMBSetting()))
+	concurrencyEntry := widget.NewEntry()
+	concurrencyEntry.SetText(strconv.Itoa(uploadConcurrencySetting()))
+	transferWorkersEntry := widget.NewEntry()
+	transferWorkersEntry.SetText(strconv.Itoa(transferConcurrencySetting()))
+
+	// 每个输入框对应的首选项、取值范围和无效时的提示，按界面上的顺序校验
+	fields := []struct {
+		entry    *widget.Entry
+		pref     string
+		min, max int
+		invalid  string
+		value    int
+	}{
+		{entry: timeoutEntry, pref: prefOperationTimeout, min: 1, max: math.MaxInt, invalid: T("无效的超时时间")},
+		{entry: retriesEntry, pref: prefTransferRetries, min: 0, max: math.MaxInt, invalid: T("无效的重试次数")},
+		{entry: thumbnailEntry, pref: prefThumbnailConcurrency, min: 1, max: math.MaxInt, invalid: T("无效的缩略图并发数")},
+		{entry: partSizeEntry, pref: prefPartSizeMB, min: minPartSizeMB, max: maxPartSizeMB, invalid: T("无效的分段大小")},
+		{entry: concurrencyEntry, pref: prefUploadConcurrency, min: 1, max: math.MaxInt, invalid: T("无效的分段上传并发数")},
+		{entry: transferWorkersEntry, pref: prefTransferWorkers, min: minTransferConcurrency, max: maxTransferConcurrency, invalid: T("无效的传输并发数")},
+	}
+
+	return settingsPage{
+		title: T("传输"),
+		content: container.NewVBox(
+			container.New(layout.NewFormLayout(),
+				widget.NewLabel(T("请求超时(秒):")), timeoutEntry,
+				widget.NewLabel(T("失败重试次数:")), retriesEntry,
+				widget.NewLabel(T("缩略图并发数:")), thumbnailEntry,
+				widget.NewLabel(T("分段大小(MiB):")), partSizeEntry,
+				widget.NewLabel(T("分段上传并发数:")), concurrencyEntry,
+				widget.NewLabel(T("传输并发数:")), transferWorkersEntry,
+			),
+			widget.NewLabel(T("列举对象等操作使用该时间的 4 倍；上传和下载在超过该时间没有数据传输时视为超时。")),
+			widget.NewLabel(T("上传或下载失败的文件会自动重试，重试次数为 0 表示不重试。")),
+			widget.NewLabel(T("超过 64 MiB 的文件使用分段上传，分段大小范围 5–5120 MiB。")),
+			widget.NewLabel(T("传输并发数是批量上传、下载和删除时同时处理的文件数量，范围 1–32。")),
+		),
+		validate: func() error {
+			for i := range fields {
+				value, ok := parseIntSetting(fields[i].entry.Text, fields[i].min, fields[i].max)
+				if !ok {
+					return errors.New(fields[i].invalid)
+				}
+				fields[i].value = value
+			}
+			return nil
+		},
+		save: func() {
+			prefs := fyne.CurrentApp().Preferences()
+			for _, field := range fields {
+				prefs.SetInt(field.pref, field.value)
+			}
+			ApplySavedSettings()
+		},
+	}
+}
+
+// ShowLanguageDialog 显示界面语言设置对话框，修改在重启应用后生效
+func ShowLanguageDialog(w fyne.Window) {
+	showSettingsPages(w, T("语言"), fyne.NewSize(400, 200), languageSettingsPage())
+}
+
+// languageSettingsPage 创建界面语言设置页，修改在重启应用后生效
+func languageSettingsPage() settingsPage {
+	options := []string{T("跟随系统"), "中文", "English"}
+	values := []string{languageAuto, languageZH, languageEN}
+	languageSelect := widget.NewSelect(options, nil)
+	for i, value := range values {
+		if value == languageSetting() {
+			languageSelect.SetSelected(options[i])
+		}
+	}
+
+	return settingsPage{
+		title: T("语言"),
+		content: container.NewVBox(
+			container.New(layout.NewFormLayout(),
+				widget.NewLabel(T("界面语言:")), languageSelect,
+			),
+			widget.NewLabel(T("修改将在重启应用后生效。")),
+		),
+		save: func() {
+			for i, option := range options {
+				if option == languageSelect.Selected {
+					fyne.CurrentApp().Preferences().SetString(prefLanguage, values[i])
+				}
+			}
+		},
+	}
+}
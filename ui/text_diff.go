@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+const (
+	// maxDiffFileSize 允许比较的单个文件大小上限
+	maxDiffFileSize = 1 << 20
+	// maxDiffLines 去掉相同的开头和结尾后，每个文件参与逐行比较的最大行数，限制 LCS 表的内存占用
+	maxDiffLines = 2000
+	// maxDiffColumnWidth 并排显示时每一侧的最大宽度（字符数），更长的行会被截断
+	maxDiffColumnWidth = 100
+)
+
+// errDiffTooLarge 表示两个文件差异的部分太长，无法逐行比较
+var errDiffTooLarge = errors.New("文件差异过大，无法比较")
+
+// diffOp 是一行在比较结果中的类型
+type diffOp int
+
+const (
+	diffEqual   diffOp = iota // 两个文件中都有的行
+	diffRemoved               // 只在第一个文件中出现的行
+	diffAdded                 // 只在第二个文件中出现的行
+)
+
+// diffLine 是比较结果中的一行
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+// diffLines 基于最长公共子序列逐行比较 a 和 b。相同的开头和结尾不参与 LCS 计算。
+func diffLines(a, b []string) ([]diffLine, error) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(midA) > maxDiffLines || len(midB) > maxDiffLines {
+		return nil, errDiffTooLarge
+	}
+
+	// lcs[i][j] 是 midA[i:] 与 midB[j:] 的最长公共子序列长度
+	lcs := make([][]int32, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	result := make([]diffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		result = append(result, diffLine{diffEqual, line})
+	}
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		switch {
+		case midA[i] == midB[j]:
+			result = append(result, diffLine{diffEqual, midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, diffLine{diffRemoved, midA[i]})
+			i++
+		default:
+			result = append(result, diffLine{diffAdded, midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		result = append(result, diffLine{diffRemoved, midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		result = append(result, diffLine{diffAdded, midB[j]})
+	}
+	for _, line := range a[len(a)-suffix:] {
+		result = append(result, diffLine{diffEqual, line})
+	}
+	return result, nil
+}
+
+// splitTextLines 将文本按行拆分，统一换行符并展开制表符
+func splitTextLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// canCompareObjects 判断选中的对象能否进行文本比较：恰好两个文本文件，并且都不超过大小上限
+func canCompareObjects(objects []s3client.S3Object) bool {
+	if len(objects) != 2 {
+		return false
+	}
+	for _, obj := range objects {
+		if obj.IsFolder || previewTypeFor(obj.Name) != "text" || obj.Size > maxDiffFileSize {
+			return false
+		}
+	}
+	return true
+}
+
+// showCompareWindow 下载两个文本对象并在新窗口中并排显示逐行差异
+func (ov *ObjectsView) showCompareWindow(left, right s3client.S3Object) {
+	client, bucket := ov.s3Client, ov.currentBucket
+	loading := dialog.NewProgressInfinite(T("比较两个对象"), T("正在下载文件..."), ov.window)
+	loading.Show()
+
+	go func() {
+		var texts [2]string
+		var errs [2]error
+		var wg sync.WaitGroup
+		for i, obj := range []s3client.S3Object{left, right} {
+			wg.Add(1)
+			go func(i int, key string) {
+				defer wg.Done()
+				body, err := client.DownloadObject(context.Background(), bucket, key)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				defer body.Close()
+				data, err := io.ReadAll(io.LimitReader(body, maxDiffFileSize+1))
+				if err == nil && len(data) > maxDiffFileSize {
+					err = fmt.Errorf("文件 '%s' 超过比较的大小上限", key)
+				}
+				texts[i], errs[i] = string(data), err
+			}(i, obj.Key)
+		}
+		wg.Wait()
+
+		var lines []diffLine
+		err := errs[0]
+		if err == nil {
+			err = errs[1]
+		}
+		if err == nil {
+			lines, err = diffLines(splitTextLines(texts[0]), splitTextLines(texts[1]))
+		}
+
+		fyne.Do(func() {
+			loading.Hide()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(T("比较文件失败: %v"), err), ov.window)
+				return
+			}
+			w := fyne.CurrentApp().NewWindow(fmt.Sprintf(T("比较 - %s ↔ %s"), left.Name, right.Name))
+			w.SetContent(newDiffView(left.Key, right.Key, lines))
+			w.Resize(fyne.NewSize(1100, 700))
+			w.Show()
+		})
+	}()
+}
+
+// newDiffView 创建并排的差异视图：左侧为第一个文件，右侧为第二个文件，删除的行标为红色，新增的行标为绿色
+func newDiffView(leftTitle, rightTitle string, lines []diffLine) fyne.CanvasObject {
+	removedStyle := &widget.CustomTextGridStyle{BGColor: color.NRGBA{R: 0xf4, G: 0x43, B: 0x36, A: 0x50}}
+	addedStyle := &widget.CustomTextGridStyle{BGColor: color.NRGBA{R: 0x4c, G: 0xaf, B: 0x50, A: 0x50}}
+
+	width := 0
+	for _, l := range lines {
+		if l.op != diffAdded {
+			if n := len([]rune(l.text)); n > width {
+				width = n
+			}
+		}
+	}
+	if width > maxDiffColumnWidth {
+		width = maxDiffColumnWidth
+	}
+
+	cellsFor := func(text string, style widget.TextGridStyle, pad bool) []widget.TextGridCell {
+		runes := []rune(text)
+		if len(runes) > maxDiffColumnWidth {
+			runes = append(runes[:maxDiffColumnWidth-1], '…')
+		}
+		n := len(runes)
+		if pad {
+			n = width
+		}
+		cells := make([]widget.TextGridCell, n)
+		for i := range cells {
+			cells[i] = widget.TextGridCell{Rune: ' ', Style: style}
+			if i < len(runes) {
+				cells[i].Rune = runes[i]
+			}
+		}
+		return cells
+	}
+
+	grid := widget.NewTextGrid()
+	added, removed := 0, 0
+	// 连续的删除行和新增行并排显示在同一行中
+	for i := 0; i < len(lines); {
+		if lines[i].op == diffEqual {
+			row := append(cellsFor(lines[i].text, nil, true), cellsFor(" │ ", nil, false)...)
+			grid.SetRow(len(grid.Rows), widget.TextGridRow{Cells: append(row, cellsFor(lines[i].text, nil, false)...)})
+			i++
+			continue
+		}
+		var removedLines, addedLines []string
+		for ; i < len(lines) && lines[i].op != diffEqual; i++ {
+			if lines[i].op == diffRemoved {
+				removedLines = append(removedLines, lines[i].text)
+			} else {
+				addedLines = append(addedLines, lines[i].text)
+			}
+		}
+		removed += len(removedLines)
+		added += len(addedLines)
+		for k := 0; k < len(removedLines) || k < len(addedLines); k++ {
+			var row []widget.TextGridCell
+			if k < len(removedLines) {
+				row = cellsFor(removedLines[k], removedStyle, true)
+			} else {
+				row = cellsFor("", nil, true)
+			}
+			row = append(row, cellsFor(" │ ", nil, false)...)
+			if k < len(addedLines) {
+				row = append(row, cellsFor(addedLines[k], addedStyle, false)...)
+			}
+			grid.SetRow(len(grid.Rows), widget.TextGridRow{Cells: row})
+		}
+	}
+
+	header := widget.NewLabel(fmt.Sprintf(T("左: %s    右: %s    删除 %d 行，新增 %d 行"), leftTitle, rightTitle, removed, added))
+	header.Truncation = fyne.TextTruncateEllipsis
+	return container.NewBorder(header, nil, nil, nil, container.NewScroll(grid))
+}
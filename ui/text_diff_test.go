@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestDiffLines(t *testing.T) {
+	a := splitTextLines("host=a\nport=80\ndebug=false\ntimeout=5\n")
+	b := splitTextLines("host=a\nport=8080\ndebug=false\ntimeout=5\nretries=3\n")
+	got, err := diffLines(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []diffLine{
+		{diffEqual, "host=a"},
+		{diffRemoved, "port=80"},
+		{diffAdded, "port=8080"},
+		{diffEqual, "debug=false"},
+		{diffEqual, "timeout=5"},
+		{diffAdded, "retries=3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines = %v, 期望 %v", got, want)
+	}
+}
+
+func TestDiffLinesTooLarge(t *testing.T) {
+	a := strings.Split(strings.Repeat("a\n", maxDiffLines+1), "\n")
+	b := strings.Split(strings.Repeat("b\n", maxDiffLines+1), "\n")
+	if _, err := diffLines(a, b); err != errDiffTooLarge {
+		t.Errorf("差异过大时应返回 errDiffTooLarge，实际为 %v", err)
+	}
+}
+
+func TestCanCompareObjects(t *testing.T) {
+	text := s3client.S3Object{Name: "a.yaml", Size: 10}
+	tests := []struct {
+		objects []s3client.S3Object
+		want    bool
+	}{
+		{[]s3client.S3Object{text, {Name: "b.json", Size: 10}}, true},
+		{[]s3client.S3Object{text}, false},
+		{[]s3client.S3Object{text, {Name: "b.png", Size: 10}}, false},
+		{[]s3client.S3Object{text, {Name: "dir/", IsFolder: true}}, false},
+		{[]s3client.S3Object{text, {Name: "big.txt", Size: maxDiffFileSize + 1}}, false},
+	}
+	for i, tt := range tests {
+		if got := canCompareObjects(tt.objects); got != tt.want {
+			t.Errorf("第 %d 组: canCompareObjects = %v, 期望 %v", i, got, tt.want)
+		}
+	}
+}
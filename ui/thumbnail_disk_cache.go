@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+
+	"s3-explorer/config"
+	"s3-explorer/s3client"
+)
+
+// thumbnailDiskDir 返回缩略图磁盘缓存的目录，测试中可以替换
+var thumbnailDiskDir = func() (string, error) {
+	dir, err := config.AppConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "thumbnails"), nil
+}
+
+// thumbnailDiskName 返回对象缩略图在磁盘缓存中的文件名。名称由存储桶、键、大小、ETag 和修改时间计算，
+// 对象被修改后得到新的文件名，旧的缩略图不再使用。
+func thumbnailDiskName(bucket string, item s3client.S3Object) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%s\x00%s", bucket, item.Key, item.Size, item.ETag, item.LastModified)))
+	return hex.EncodeToString(sum[:]) + ".png"
+}
+
+// loadDiskThumbnail 从磁盘缓存读取对象的缩略图，不存在或无法解码时返回 false
+func loadDiskThumbnail(bucket string, item s3client.S3Object) (image.Image, bool) {
+	dir, err := thumbnailDiskDir()
+	if err != nil {
+		return nil, false
+	}
+	f, err := os.Open(filepath.Join(dir, thumbnailDiskName(bucket, item)))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		log.Printf("读取缓存的缩略图失败 (%s): %v", item.Key, err)
+		return nil, false
+	}
+	return img, true
+}
+
+// saveDiskThumbnail 将缩略图以 PNG 格式写入磁盘缓存。先写入临时文件再重命名，避免读到写了一半的文件。
+// 写入失败只记录日志，不影响缩略图显示。
+func saveDiskThumbnail(bucket string, item s3client.S3Object, thumb image.Image) {
+	dir, err := thumbnailDiskDir()
+	if err != nil {
+		log.Printf("缓存缩略图失败: %v", err)
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Printf("创建缩略图缓存目录失败: %v", err)
+		return
+	}
+	tmp, err := os.CreateTemp(dir, "thumb-*.tmp")
+	if err != nil {
+		log.Printf("缓存缩略图失败 (%s): %v", item.Key, err)
+		return
+	}
+	err = png.Encode(tmp, thumb)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(dir, thumbnailDiskName(bucket, item)))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		log.Printf("缓存缩略图失败 (%s): %v", item.Key, err)
+	}
+}
+
+// clearDiskThumbnailCache 删除磁盘上缓存的所有缩略图
+func clearDiskThumbnailCache() error {
+	dir, err := thumbnailDiskDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// ClearThumbnailCache 清除内存和磁盘中缓存的所有缩略图，然后为当前列表重新加载缩略图
+func (ov *ObjectsView) ClearThumbnailCache() {
+	clearThumbnailCache("")
+	if err := clearDiskThumbnailCache(); err != nil {
+		ShowToast(ov.window, fmt.Sprintf(T("清除缩略图缓存失败: %v"), err))
+		return
+	}
+	ShowToast(ov.window, T("已清除缩略图缓存。"))
+	ov.loadThumbnails()
+	ov.refreshObjectView()
+}
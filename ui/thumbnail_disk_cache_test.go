@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestDiskThumbnailCache(t *testing.T) {
+	dir := t.TempDir()
+	old := thumbnailDiskDir
+	thumbnailDiskDir = func() (string, error) { return dir + "/thumbnails", nil }
+	defer func() { thumbnailDiskDir = old }()
+
+	item := s3client.S3Object{Key: "photos/a.png", Size: 1024, ETag: "abc"}
+	if _, ok := loadDiskThumbnail("bucket", item); ok {
+		t.Fatal("缓存为空时不应读到缩略图")
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	thumb.Set(1, 1, color.RGBA{R: 255, A: 255})
+	saveDiskThumbnail("bucket", item, thumb)
+
+	got, ok := loadDiskThumbnail("bucket", item)
+	if !ok {
+		t.Fatal("应读到保存的缩略图")
+	}
+	if got.Bounds() != thumb.Bounds() {
+		t.Errorf("缩略图尺寸 = %v, 期望 %v", got.Bounds(), thumb.Bounds())
+	}
+	if r, _, _, _ := got.At(1, 1).RGBA(); r>>8 != 255 {
+		t.Errorf("缩略图像素 = %v, 期望红色", got.At(1, 1))
+	}
+
+	// 对象被修改（ETag 变化）或位于其他存储桶时不使用旧的缩略图
+	changed := item
+	changed.ETag = "def"
+	if _, ok := loadDiskThumbnail("bucket", changed); ok {
+		t.Error("ETag 变化后不应使用缓存的缩略图")
+	}
+	if _, ok := loadDiskThumbnail("other", item); ok {
+		t.Error("其他存储桶中的同名对象不应使用缓存的缩略图")
+	}
+
+	if err := clearDiskThumbnailCache(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loadDiskThumbnail("bucket", item); ok {
+		t.Error("清除缓存后不应读到缩略图")
+	}
+}
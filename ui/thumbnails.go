@@ -0,0 +1,20 @@
+package ui
+
+import "fyne.io/fyne/v2"
+
+// SetLoadThumbnails 开启或关闭图片缩略图并保存到首选项。关闭后取消正在进行的加载，图片显示为通用图标。
+func (ov *ObjectsView) SetLoadThumbnails(enabled bool) {
+	fyne.CurrentApp().Preferences().SetBool(prefLoadThumbnails, enabled)
+	ov.thumbnailsEnabled = enabled
+	if enabled {
+		ov.loadThumbnails()
+	} else {
+		ov.cancelThumbnails()
+	}
+	ov.refreshObjectView()
+}
+
+// LoadThumbnails 返回是否为图片加载缩略图
+func (ov *ObjectsView) LoadThumbnails() bool {
+	return ov.thumbnailsEnabled
+}
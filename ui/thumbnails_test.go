@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"s3-explorer/s3client"
+)
+
+func TestRunThumbnailJobsLimitsConcurrency(t *testing.T) {
+	var items []s3client.S3Object
+	for i := 0; i < 20; i++ {
+		items = append(items, s3client.S3Object{Key: fmt.Sprintf("%d.png", i)})
+	}
+
+	var running, maxRunning, done int32
+	runThumbnailJobs(context.Background(), items, 3, func(s3client.S3Object) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&done, 1)
+	})
+	if done != 20 {
+		t.Errorf("应处理全部 20 个任务，实际 %d 个", done)
+	}
+	if maxRunning > 3 {
+		t.Errorf("同时运行的任务最多应为 3 个，实际 %d 个", maxRunning)
+	}
+}
+
+func TestRunThumbnailJobsStopsWhenCancelled(t *testing.T) {
+	var items []s3client.S3Object
+	for i := 0; i < 20; i++ {
+		items = append(items, s3client.S3Object{Key: fmt.Sprintf("%d.png", i)})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	started := 0
+	runThumbnailJobs(ctx, items, 2, func(s3client.S3Object) {
+		mu.Lock()
+		started++
+		if started == 2 {
+			cancel()
+		}
+		mu.Unlock()
+	})
+	if started > 4 {
+		t.Errorf("取消后不应再开始新的任务，实际开始了 %d 个", started)
+	}
+}
+
+func TestClearThumbnailCache(t *testing.T) {
+	cacheLock.Lock()
+	old := thumbnailCache
+	thumbnailCache = map[string]fyne.Resource{
+		"photos/a.png":     nil,
+		"photos/sub/b.png": nil,
+		"other/c.png":      nil,
+	}
+	cacheLock.Unlock()
+	defer func() { thumbnailCache = old }()
+
+	clearThumbnailCache("photos/")
+	if _, ok := thumbnailCache["other/c.png"]; !ok || len(thumbnailCache) != 1 {
+		t.Errorf("清除后的缓存 = %v, 期望只保留 other/c.png", thumbnailCache)
+	}
+}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// transferTarget 是一次传输使用的服务客户端和存储桶，在传输开始时从 ObjectsView 中取得。
+// 传输在后台进行，期间用户可以继续浏览、切换存储桶或开始其它操作，因此传输过程中不读取 ObjectsView 的当前状态。
+type transferTarget struct {
+	client ObjectStore
+	bucket string
+}
+
+// currentTransferTarget 返回当前服务和存储桶对应的传输目标
+func (ov *ObjectsView) currentTransferTarget() transferTarget {
+	return transferTarget{client: ov.s3Client, bucket: ov.currentBucket}
+}
+
+// reloadIfShowing 在传输结束后刷新对象列表。用户已切换到其它服务或存储桶时不刷新。需要在 UI 线程中调用。
+func (ov *ObjectsView) reloadIfShowing(target transferTarget) {
+	if ov.s3Client == target.client && ov.currentBucket == target.bucket {
+		ov.loadObjects()
+	}
+}
+
+// transferTitle 返回传输面板中显示的传输名称：单个项目显示其名称，多个项目显示按名称排序后的第一个名称和项目总数
+func transferTitle(action string, names []string) string {
+	switch len(names) {
+	case 0:
+		return action
+	case 1:
+		return action + " " + names[0]
+	}
+	return action + " " + fmt.Sprintf(T("%s 等 %d 个项目"), slices.Min(names), len(names))
+}
+
+// transferState 是传输任务的状态
+type transferState int
+
+const (
+	transferRunning transferState = iota
+	transferSucceeded
+	transferFailed
+	transferCancelled
+)
+
+// transferPanelHeight 是展开的传输面板中列表区域的高度
+const transferPanelHeight = 160
+
+// transferTask 是传输面板中的一项传输，显示进度、速度和结果。点击取消按钮会取消 ctx，正在进行的传输随之中止。
+// 除 ctx 外的字段只在 UI 线程中读写。
+type transferTask struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	manager *TransferManager
+	state   transferState
+
+	icon         *widget.Icon
+	bar          *StyledProgressBar
+	status       *widget.Label
+	detailButton *widget.Button
+	actionButton *widget.Button // 传输中为取消，结束后为从列表中移除
+	content      fyne.CanvasObject
+}
+
+// SetValue 更新进度条，需要在 UI 线程中调用
+func (t *transferTask) SetValue(value float64) {
+	t.bar.SetValue(value)
+}
+
+// SetStatus 更新传输速度和剩余时间，需要在 UI 线程中调用。传输结束后忽略，以免覆盖结果。
+func (t *transferTask) SetStatus(text string) {
+	if t.state == transferRunning && t.ctx.Err() == nil {
+		t.status.SetText(text)
+	}
+}
+
+// cancelled 返回传输是否已被用户取消，需要在 UI 线程中调用
+func (t *transferTask) cancelled() bool {
+	return t.ctx.Err() != nil
+}
+
+// finish 将任务标记为已结束并显示 message。failures 不为空时显示“详情”按钮，点击后列出失败的项目。
+// 需要在 UI 线程中调用。
+func (t *transferTask) finish(state transferState, message string, failures []transferFailure) {
+	t.cancel()
+	t.state = state
+	t.status.SetText(message)
+	switch state {
+	case transferSucceeded:
+		t.bar.SetValue(1)
+		t.icon.SetResource(theme.ConfirmIcon())
+	case transferFailed:
+		t.icon.SetResource(theme.ErrorIcon())
+	case transferCancelled:
+		t.icon.SetResource(theme.CancelIcon())
+	}
+	if len(failures) > 0 {
+		t.detailButton.OnTapped = func() {
+			showTransferFailures(t.manager.window, message, failures)
+		}
+		t.detailButton.Show()
+	}
+	t.actionButton.SetIcon(theme.DeleteIcon())
+	t.actionButton.OnTapped = func() { t.manager.remove(t) }
+	t.manager.refresh()
+}
+
+// TransferManager 管理后台进行的上传和下载，并在可折叠的传输面板中显示每项传输的进度和结果。
+// 传输不会像进度对话框那样阻挡窗口，用户可以在传输期间继续浏览和开始其它传输。
+// 所有方法都需要在 UI 线程中调用。
+type TransferManager struct {
+	window   fyne.Window
+	tasks    []*transferTask
+	expanded bool
+
+	list         *fyne.Container
+	listArea     fyne.CanvasObject
+	summary      *widget.Label
+	toggleButton *widget.Button
+	clearButton  *widget.Button
+	content      *fyne.Container
+}
+
+// NewTransferManager 创建传输管理器，没有传输时面板隐藏
+func NewTransferManager(window fyne.Window) *TransferManager {
+	m := &TransferManager{window: window, expanded: true, list: container.NewVBox(), summary: widget.NewLabel("")}
+	scroll := container.NewVScroll(m.list)
+	scroll.SetMinSize(fyne.NewSize(0, transferPanelHeight))
+	m.listArea = scroll
+
+	m.toggleButton = widget.NewButtonWithIcon("", theme.MenuDropDownIcon(), func() {
+		m.expanded = !m.expanded
+		m.refresh()
+	})
+	m.toggleButton.Importance = widget.LowImportance
+	m.clearButton = widget.NewButtonWithIcon(T("清除已结束"), theme.ContentClearIcon(), m.clearFinished)
+	m.clearButton.Importance = widget.LowImportance
+
+	header := container.NewHBox(m.toggleButton, m.summary, layout.NewSpacer(), m.clearButton)
+	m.content = container.NewVBox(widget.NewSeparator(), header, m.listArea)
+	m.refresh()
+	return m
+}
+
+// GetContent 返回传输面板的界面
+func (m *TransferManager) GetContent() fyne.CanvasObject {
+	return m.content
+}
+
+// add 在面板中添加一项名为 name 的传输并展开面板，返回的任务用于更新进度和结束传输
+func (m *TransferManager) add(name string, icon fyne.Resource) *transferTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &transferTask{
+		ctx:          ctx,
+		cancel:       cancel,
+		manager:      m,
+		icon:         widget.NewIcon(icon),
+		bar:          NewStyledProgressBar(),
+		status:       widget.NewLabel(T("正在准备...")),
+		detailButton: widget.NewButton(T("详情"), nil),
+	}
+	title := widget.NewLabel(name)
+	title.Truncation = fyne.TextTruncateEllipsis
+	t.status.Importance = widget.LowImportance
+	t.detailButton.Importance = widget.LowImportance
+	t.detailButton.Hide()
+	t.actionButton = widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+		t.cancel()
+		t.status.SetText(T("正在取消..."))
+	})
+	t.actionButton.Importance = widget.LowImportance
+
+	t.content = container.NewBorder(nil, nil, t.icon, container.NewHBox(t.detailButton, t.actionButton),
+		container.NewVBox(container.NewBorder(nil, nil, nil, t.status, title), t.bar))
+	m.tasks = append(m.tasks, t)
+	m.expanded = true
+	m.refresh()
+	return t
+}
+
+// remove 从面板中移除一项已结束的传输
+func (m *TransferManager) remove(t *transferTask) {
+	for i, task := range m.tasks {
+		if task == t {
+			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			break
+		}
+	}
+	m.refresh()
+}
+
+// clearFinished 移除所有已结束的传输，进行中的传输保留
+func (m *TransferManager) clearFinished() {
+	running := m.tasks[:0]
+	for _, t := range m.tasks {
+		if t.state == transferRunning {
+			running = append(running, t)
+		}
+	}
+	clear(m.tasks[len(running):])
+	m.tasks = running
+	m.refresh()
+}
+
+// counts 返回进行中和已结束的传输数量
+func (m *TransferManager) counts() (running, finished int) {
+	for _, t := range m.tasks {
+		if t.state == transferRunning {
+			running++
+		} else {
+			finished++
+		}
+	}
+	return running, finished
+}
+
+// refresh 根据当前的传输更新面板的列表、摘要和可见性。没有传输时隐藏整个面板。
+func (m *TransferManager) refresh() {
+	running, finished := m.counts()
+	m.summary.SetText(fmt.Sprintf(T("传输: %d 个进行中，%d 个已结束"), running, finished))
+	if finished > 0 {
+		m.clearButton.Enable()
+	} else {
+		m.clearButton.Disable()
+	}
+
+	// 最新的传输显示在最上方
+	objects := make([]fyne.CanvasObject, 0, len(m.tasks))
+	for i := len(m.tasks) - 1; i >= 0; i-- {
+		objects = append(objects, m.tasks[i].content)
+	}
+	m.list.Objects = objects
+	m.list.Refresh()
+
+	if m.expanded {
+		m.toggleButton.SetIcon(theme.MenuDropDownIcon())
+		m.listArea.Show()
+	} else {
+		m.toggleButton.SetIcon(theme.MenuExpandIcon())
+		m.listArea.Hide()
+	}
+	if len(m.tasks) == 0 {
+		m.content.Hide()
+	} else {
+		m.content.Show()
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTransferTitle(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{nil, "上传"},
+		{[]string{"a.txt"}, "上传 a.txt"},
+		{[]string{"photos", "a.txt", "b.txt"}, "上传 a.txt 等 3 个项目"},
+	}
+	for _, tt := range tests {
+		if got := transferTitle("上传", tt.names); got != tt.want {
+			t.Errorf("transferTitle(%v) = %q, 期望 %q", tt.names, got, tt.want)
+		}
+	}
+}
+
+// 传输开始后用户切换到其它存储桶，文件仍应上传到开始时的存储桶
+func TestTransferUsesTargetCapturedAtStart(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newMemStore()
+	ov := newTestObjectsView(store, "")
+	plan, scanErrors := ov.buildUploadPlan([]string{path}, "", uploadNaming{})
+	if len(scanErrors) > 0 {
+		t.Fatalf("扫描失败: %v", scanErrors)
+	}
+	target := ov.currentTransferTarget()
+	ov.currentBucket = "other"
+
+	var done int64
+	if _, failures := target.executeUploadPlan(context.Background(), plan, &transferProgress{done: &done}, 0); len(failures) > 0 {
+		t.Fatalf("上传失败: %v", failures)
+	}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, []string{"a.txt"}) {
+		t.Errorf("%s 中的对象 = %v, 期望 [a.txt]", testBucket, got)
+	}
+	if got := store.keys("other"); len(got) != 0 {
+		t.Errorf("不应上传到切换后的存储桶，实际 %v", got)
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// transferRateInterval 是刷新传输速度和剩余时间的最短间隔，避免频繁刷新界面
+const transferRateInterval = 250 * time.Millisecond
+
+// transferRate 根据一批并发传输共享的已传输字节数计算传输速度和剩余时间，多个 ProgressTracker 共享同一个 transferRate
+type transferRate struct {
+	mu        sync.Mutex
+	total     int64        // 所有文件的总字节数
+	start     time.Time    // 开始传输的时间
+	lastTick  time.Time    // 上次刷新的时间
+	lastBytes int64        // 上次刷新时已传输的字节数
+	speed     float64      // 平滑后的传输速度，字节/秒
+	onUpdate  func(string) // 在 UI 线程中显示速度和剩余时间
+}
+
+// newTransferRate 创建一个速度计算器，onUpdate 最多每 transferRateInterval 被调用一次
+func newTransferRate(total int64, onUpdate func(string)) *transferRate {
+	now := time.Now()
+	return &transferRate{total: total, start: now, lastTick: now, onUpdate: onUpdate}
+}
+
+// sample 在已传输 done 字节时记录一次采样，距离上次刷新不足 transferRateInterval 时返回 false。
+// 速度按本次间隔内传输的字节数计算，并与之前的速度平滑，使剩余时间不会剧烈跳动。
+func (r *transferRate) sample(now time.Time, done int64) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	elapsed := now.Sub(r.lastTick)
+	if elapsed < transferRateInterval {
+		return "", false
+	}
+	// 失败重试会撤销已计入的进度，此时本次间隔按没有传输数据计算
+	delta := done - r.lastBytes
+	if delta < 0 {
+		delta = 0
+	}
+	instant := float64(delta) / elapsed.Seconds()
+	if r.lastTick.Equal(r.start) {
+		r.speed = instant
+	} else {
+		r.speed = 0.7*r.speed + 0.3*instant
+	}
+	r.lastTick = now
+	r.lastBytes = done
+	return formatTransferRate(r.speed, r.total-done), true
+}
+
+// update 记录一次采样，需要刷新时在 UI 线程中调用 onUpdate
+func (r *transferRate) update(done int64) {
+	if text, ok := r.sample(time.Now(), done); ok && r.onUpdate != nil {
+		fyne.Do(func() { r.onUpdate(text) })
+	}
+}
+
+// formatTransferRate 将速度和剩余字节数格式化为 "12.4 MB/s · 剩余 00:42"，速度为 0 时不显示剩余时间
+func formatTransferRate(bytesPerSecond float64, remaining int64) string {
+	speed := formatBytes(int64(bytesPerSecond)) + "/s"
+	if bytesPerSecond <= 0 || remaining <= 0 {
+		return speed
+	}
+	eta := time.Duration(float64(remaining) / bytesPerSecond * float64(time.Second))
+	return fmt.Sprintf(T("%s · 剩余 %s"), speed, formatETA(eta))
+}
+
+// formatETA 将剩余时间格式化为 mm:ss，超过一小时时为 h:mm:ss
+func formatETA(d time.Duration) string {
+	seconds := int64(d.Round(time.Second) / time.Second)
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
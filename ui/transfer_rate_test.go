@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTransferRate(t *testing.T) {
+	tests := []struct {
+		speed     float64
+		remaining int64
+		want      string
+	}{
+		{13 << 20, 13 << 20 * 42, "13.0 MB/s · 剩余 00:42"},
+		{1 << 20, 1 << 20 * 3725, "1.0 MB/s · 剩余 1:02:05"},
+		{512, 0, "512 B/s"},
+		{0, 1 << 20, "0 B/s"},
+	}
+	for _, tt := range tests {
+		if got := formatTransferRate(tt.speed, tt.remaining); got != tt.want {
+			t.Errorf("formatTransferRate(%v, %d) = %q, 期望 %q", tt.speed, tt.remaining, got, tt.want)
+		}
+	}
+}
+
+// 采样间隔不足 transferRateInterval 时不刷新，重试撤销进度时速度不为负
+func TestTransferRateSample(t *testing.T) {
+	r := newTransferRate(10<<20, nil)
+	start := r.start
+
+	if _, ok := r.sample(start.Add(100*time.Millisecond), 1<<20); ok {
+		t.Error("间隔不足时不应刷新")
+	}
+	text, ok := r.sample(start.Add(time.Second), 2<<20)
+	if !ok || text != "2.0 MB/s · 剩余 00:04" {
+		t.Errorf("第一次采样 = %q, %v, 期望 2.0 MB/s · 剩余 00:04", text, ok)
+	}
+	if _, ok := r.sample(start.Add(2*time.Second), 1<<20); !ok || r.speed < 0 {
+		t.Errorf("进度回退后速度 = %v, 不应为负", r.speed)
+	}
+}
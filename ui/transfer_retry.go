@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// transferRetryDelay 是第一次重试前的等待时间，之后每次重试递增
+var transferRetryDelay = time.Second
+
+// transferProgress 是一批并发传输共享的总进度
+type transferProgress struct {
+	total  int64             // 所有文件的总字节数
+	done   *int64            // 已传输的字节数，多个 worker 原子更新
+	dialog progressIndicator // 显示总进度的对话框或传输面板中的任务，可以为 nil
+	rate   *transferRate     // 计算传输速度和剩余时间，可以为 nil
+}
+
+// transferAttempt 记录单个文件一次传输尝试计入总进度的字节数，失败重试前用于撤销这部分进度
+type transferAttempt struct {
+	progress *transferProgress
+	counted  int64
+}
+
+// track 为数据流添加进度跟踪，读取的字节同时计入总进度和本次尝试
+func (a *transferAttempt) track(reader io.Reader) *ProgressTracker {
+	tracker := NewProgressTracker(reader, a.progress.total, a.progress.done, a.progress.dialog)
+	tracker.attemptBytes = &a.counted
+	tracker.rate = a.progress.rate
+	return tracker
+}
+
+// skip 将断点续传时本地已有的字节计入总进度和本次尝试，尝试失败时同样会被撤销
+func (a *transferAttempt) skip(n int64) {
+	if a.progress == nil || n <= 0 {
+		return
+	}
+	atomic.AddInt64(a.progress.done, n)
+	atomic.AddInt64(&a.counted, n)
+}
+
+// rollback 撤销本次尝试已计入总进度的字节数
+func (a *transferAttempt) rollback() {
+	if a.progress == nil {
+		return
+	}
+	atomic.AddInt64(a.progress.done, -atomic.SwapInt64(&a.counted, 0))
+}
+
+// transferFailure 是一个重试耗尽后仍然失败的传输项目
+type transferFailure struct {
+	name   string  // 显示名称（文件名或对象键）
+	errors []error // 每次尝试的错误，最后一个为最终错误
+}
+
+// isRetryableTransferError 判断错误是否值得重试。本地文件错误、凭证过期和匿名写操作重试也不会成功，用户取消的传输不再重试。
+// s3client 已按相同的重试次数重试过的请求错误不再重试，这里只重试数据流中途断开等 s3client 没有重试的错误。
+func isRetryableTransferError(err error) bool {
+	var pathErr *fs.PathError
+	return !errors.As(err, &pathErr) &&
+		!errors.Is(err, context.Canceled) &&
+		!s3client.IsRetriedError(err) &&
+		!errors.Is(err, s3client.ErrAnonymousReadOnly) &&
+		!s3client.IsExpiredTokenError(err)
+}
+
+// transferWithRetry 执行一次传输，失败时最多重试 retries 次，每次重试前撤销失败尝试计入的进度。
+// 返回每次失败尝试的错误；最终成功时 err 为 nil，此时 attemptErrors 中只包含已被重试掩盖的错误。
+func transferWithRetry(progress *transferProgress, retries int, name string, fn func(attempt *transferAttempt) error) (attemptErrors []error, err error) {
+	for i := 0; ; i++ {
+		attempt := &transferAttempt{progress: progress}
+		err = fn(attempt)
+		if err == nil {
+			return attemptErrors, nil
+		}
+		attempt.rollback()
+		attemptErrors = append(attemptErrors, err)
+		if i >= retries || !isRetryableTransferError(err) {
+			return attemptErrors, err
+		}
+		log.Printf("传输 '%s' 第 %d 次失败，准备重试: %v", name, i+1, err)
+		time.Sleep(transferRetryDelay * time.Duration(i+1))
+	}
+}
+
+// showTransferFailures 显示重试耗尽后仍然失败的项目及其每次尝试的错误，也用于显示其它批量操作中失败的项目
+func showTransferFailures(w fyne.Window, title string, failures []transferFailure) {
+	rows := container.NewVBox()
+	for _, failure := range failures {
+		name := widget.NewLabelWithStyle(failure.name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		rows.Add(name)
+		finalErr := widget.NewLabel(failure.errors[len(failure.errors)-1].Error())
+		finalErr.Wrapping = fyne.TextWrapWord
+		rows.Add(finalErr)
+		if len(failure.errors) > 1 {
+			for i, err := range failure.errors[:len(failure.errors)-1] {
+				attempt := widget.NewLabel(fmt.Sprintf(T("第 %d 次尝试: %v"), i+1, err))
+				attempt.Wrapping = fyne.TextWrapWord
+				attempt.Importance = widget.LowImportance
+				rows.Add(attempt)
+			}
+		}
+		rows.Add(widget.NewSeparator())
+	}
+
+	summaryFormat := T("%d 个项目失败:")
+	for _, failure := range failures {
+		if len(failure.errors) > 1 {
+			summaryFormat = T("%d 个项目在重试后仍然失败:")
+			break
+		}
+	}
+	summary := widget.NewLabel(fmt.Sprintf(summaryFormat, len(failures)))
+	d := dialog.NewCustom(title, T("关闭"), container.NewBorder(summary, nil, nil, nil, container.NewVScroll(rows)), w)
+	d.Resize(fyne.NewSize(600, 400))
+	d.Show()
+}
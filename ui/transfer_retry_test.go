@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestTransferWithRetryRollsBackFailedAttempts(t *testing.T) {
+	old := transferRetryDelay
+	transferRetryDelay = 0
+	defer func() { transferRetryDelay = old }()
+
+	var done int64
+	progress := &transferProgress{total: 10, done: &done}
+	calls := 0
+	attemptErrors, err := transferWithRetry(progress, 2, "file", func(attempt *transferAttempt) error {
+		calls++
+		if _, err := io.Copy(io.Discard, attempt.track(strings.NewReader("0123456789"))); err != nil {
+			return err
+		}
+		if calls < 3 {
+			return fmt.Errorf("第 %d 次失败", calls)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transferWithRetry() 返回错误: %v", err)
+	}
+	if calls != 3 || len(attemptErrors) != 2 {
+		t.Errorf("期望 3 次调用和 2 个失败记录，实际 %d 次调用和 %d 个失败记录", calls, len(attemptErrors))
+	}
+	if done != 10 {
+		t.Errorf("失败尝试的进度应被撤销，期望已传输 10 字节，实际 %d", done)
+	}
+}
+
+func TestTransferWithRetryStopsOnLocalError(t *testing.T) {
+	old := transferRetryDelay
+	transferRetryDelay = 0
+	defer func() { transferRetryDelay = old }()
+
+	calls := 0
+	_, err := transferWithRetry(nil, 5, "file", func(*transferAttempt) error {
+		calls++
+		_, err := os.Open("/nonexistent/s3-explorer-test")
+		return fmt.Errorf("打开文件失败: %w", err)
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("本地文件错误不应重试，调用了 %d 次，err = %v", calls, err)
+	}
+
+	calls = 0
+	attemptErrors, err := transferWithRetry(nil, 1, "file", func(*transferAttempt) error {
+		calls++
+		return errors.New("网络错误")
+	})
+	if err == nil || calls != 2 || len(attemptErrors) != 2 {
+		t.Errorf("期望重试 1 次后失败，实际调用 %d 次，err = %v", calls, err)
+	}
+}
+
+// s3client 已重试过的请求错误不再由传输重试，总尝试次数为 s3client 的 MaxRetries+1 而不是两者相乘
+func TestTransferWithRetrySkipsErrorsRetriedByClient(t *testing.T) {
+	old := transferRetryDelay
+	transferRetryDelay = 0
+	defer func() { transferRetryDelay = old }()
+
+	calls := 0
+	_, err := transferWithRetry(nil, 2, "file", func(*transferAttempt) error {
+		calls++
+		return fmt.Errorf("上传失败: %w", fmt.Errorf("%w: 503", s3client.ErrRetried))
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("已重试的错误不应再次重试，调用了 %d 次，err = %v", calls, err)
+	}
+
+	// 数据流中途断开的错误没有被 s3client 重试，仍由传输重试
+	calls = 0
+	_, err = transferWithRetry(nil, 2, "file", func(*transferAttempt) error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	if err == nil || calls != 3 {
+		t.Errorf("期望重试 2 次后失败，实际调用 %d 次，err = %v", calls, err)
+	}
+}
+
+func TestProgressTrackerSeekToStartRollsBackProgress(t *testing.T) {
+	var done, attemptBytes int64
+	tracker := NewProgressTracker(strings.NewReader("hello"), 5, &done, nil)
+	tracker.attemptBytes = &attemptBytes
+	if _, err := io.ReadAll(tracker); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tracker.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if done != 0 || attemptBytes != 0 {
+		t.Errorf("回到开头后的进度 = %d（本次尝试 %d），期望 0", done, attemptBytes)
+	}
+	if _, err := io.ReadAll(tracker); err != nil {
+		t.Fatal(err)
+	}
+	if done != 5 {
+		t.Errorf("重新读取后的进度 = %d，期望 5", done)
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/common"
+	"s3-explorer/s3client"
+)
+
+// fileTypeCategories 是类型筛选按钮及其对应的 common.GetIconForFile 分类
+var fileTypeCategories = []struct {
+	label    string
+	category string
+}{
+	{"图片", "image"},
+	{"文档", "text"},
+	{"音频", "audio"},
+	{"视频", "video"},
+	{"压缩包", "archive"},
+	{"其他", "file"},
+}
+
+// filterObjectList 返回名称包含 searchTerm（不区分大小写）且属于 types 中任一分类的对象。
+// types 为空时不按类型筛选；文件夹不属于任何分类，始终保留以便继续浏览。
+// 没有任何筛选条件时返回 nil，表示显示全部对象。
+func filterObjectList(objects []s3client.S3Object, searchTerm string, types map[string]bool, order s3client.SortOrder) []s3client.S3Object {
+	if searchTerm == "" && len(types) == 0 {
+		return nil
+	}
+	searchTerm = strings.ToLower(searchTerm)
+
+	filtered := make([]s3client.S3Object, 0)
+	for _, obj := range objects {
+		// 将对象名称转换为小写进行不区分大小写的搜索
+		if !strings.Contains(strings.ToLower(obj.Name), searchTerm) {
+			continue
+		}
+		if len(types) > 0 && !obj.IsFolder && !types[common.GetIconForFile(obj.Name)] {
+			continue
+		}
+		filtered = append(filtered, obj)
+	}
+
+	// 对过滤后的对象进行排序，与列表使用相同的顺序
+	s3client.SortObjectsBy(filtered, order)
+	return filtered
+}
+
+// newTypeFilterBar 创建类型筛选按钮栏。选中的类型只在本次运行中保留，切换目录后仍然生效。
+func (ov *ObjectsView) newTypeFilterBar() fyne.CanvasObject {
+	bar := container.NewHBox(widget.NewLabel(T("类型:")))
+	for _, c := range fileTypeCategories {
+		category := c.category
+		var chip *widget.Button
+		chip = widget.NewButton(T(c.label), func() {
+			if ov.typeFilters[category] {
+				delete(ov.typeFilters, category)
+				chip.Importance = widget.MediumImportance
+			} else {
+				ov.typeFilters[category] = true
+				chip.Importance = widget.HighImportance
+			}
+			chip.Refresh()
+			ov.filterObjects(ov.searchText())
+		})
+		if ov.typeFilters[category] {
+			chip.Importance = widget.HighImportance
+		}
+		bar.Add(chip)
+	}
+	return bar
+}
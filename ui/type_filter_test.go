@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestFilterObjectList(t *testing.T) {
+	objects := []s3client.S3Object{
+		{Name: "photos", IsFolder: true},
+		{Name: "cat.JPG"},
+		{Name: "notes.txt"},
+		{Name: "song.mp3"},
+		{Name: "setup.exe"},
+	}
+	names := func(objs []s3client.S3Object) []string {
+		var result []string
+		for _, obj := range objs {
+			result = append(result, obj.Name)
+		}
+		return result
+	}
+
+	if got := filterObjectList(objects, "", nil, s3client.SortOrder{FoldersFirst: true}); got != nil {
+		t.Errorf("没有筛选条件时应返回 nil，实际为 %v", names(got))
+	}
+
+	got := filterObjectList(objects, "", map[string]bool{"image": true, "file": true}, s3client.SortOrder{FoldersFirst: true})
+	if want := []string{"photos", "cat.JPG", "setup.exe"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("按类型筛选 = %v, 期望 %v", names(got), want)
+	}
+
+	got = filterObjectList(objects, "o", map[string]bool{"text": true, "audio": true}, s3client.SortOrder{FoldersFirst: true})
+	if want := []string{"photos", "notes.txt", "song.mp3"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("按类型和名称筛选 = %v, 期望 %v", names(got), want)
+	}
+}
+
+func TestSearchResultsView(t *testing.T) {
+	ov := &ObjectsView{
+		currentBucket:  testBucket,
+		searchResults:  []s3client.S3Object{{Name: "b.txt", Key: "x/b.txt"}, {Name: "a.jpg", Key: "y/a.jpg"}, {Name: "c.txt", Key: "c.txt"}},
+		searchedTerm:   "txt",
+		searchedBucket: testBucket,
+	}
+	if !ov.hasSearchResults("txt") || ov.hasSearchResults("jpg") {
+		t.Error("hasSearchResults 应只对相同的搜索词和存储桶有效")
+	}
+
+	names := func(objs []s3client.S3Object) []string {
+		var result []string
+		for _, obj := range objs {
+			result = append(result, obj.Name)
+		}
+		return result
+	}
+	if got, want := names(ov.searchResultsView()), []string{"a.jpg", "b.txt", "c.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("searchResultsView() = %v, 期望 %v", got, want)
+	}
+
+	ov.typeFilters = map[string]bool{"image": true}
+	if got, want := names(ov.searchResultsView()), []string{"a.jpg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("按类型筛选搜索结果 = %v, 期望 %v", got, want)
+	}
+
+	ov.typeFilters = map[string]bool{"audio": true}
+	if got := ov.searchResultsView(); got == nil || len(got) != 0 {
+		t.Errorf("没有匹配时应返回空列表，实际为 %v", got)
+	}
+
+	ov.currentBucket = "other"
+	if ov.hasSearchResults("txt") {
+		t.Error("切换存储桶后搜索结果应失效")
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// userMetadataPrefix 是 S3 用户元数据请求头的前缀
+const userMetadataPrefix = "x-amz-meta-"
+
+// uploadHeader 是上传对话框中填写的一个请求头
+type uploadHeader struct {
+	name  string
+	value string
+}
+
+// parseUploadHeaders 将填写的请求头转换为上传选项。支持 Content-Type、Content-Disposition、Cache-Control
+// 以及 x-amz-meta-* 用户元数据，请求头名称不区分大小写；名称为空的行被忽略。
+func parseUploadHeaders(headers []uploadHeader) (s3client.UploadOptions, error) {
+	var opts s3client.UploadOptions
+	for _, h := range headers {
+		name := strings.ToLower(strings.TrimSpace(h.name))
+		value := strings.TrimSpace(h.value)
+		if name == "" {
+			continue
+		}
+		switch {
+		case name == "content-type":
+			opts.ContentType = value
+		case name == "content-disposition":
+			opts.ContentDisposition = value
+		case name == "cache-control":
+			opts.CacheControl = value
+		case strings.HasPrefix(name, userMetadataPrefix):
+			key := strings.TrimPrefix(name, userMetadataPrefix)
+			if key == "" || strings.ContainsAny(key, " \t:") {
+				return s3client.UploadOptions{}, fmt.Errorf(T("无效的元数据名称: %s"), h.name)
+			}
+			if opts.Metadata == nil {
+				opts.Metadata = make(map[string]string)
+			}
+			opts.Metadata[key] = value
+		default:
+			return s3client.UploadOptions{}, fmt.Errorf(T("不支持的请求头: %s（仅支持 Content-Type、Content-Disposition、Cache-Control 和 x-amz-meta-*）"), h.name)
+		}
+	}
+	return opts, nil
+}
+
+// newUploadHeadersEditor 创建上传对话框中“高级”部分的请求头编辑表格，返回的函数用于读取填写的请求头
+func newUploadHeadersEditor() (fyne.CanvasObject, func() []uploadHeader) {
+	type headerRow struct {
+		name, value *widget.Entry
+	}
+	var rows []*headerRow
+	rowsBox := container.NewVBox()
+
+	addRow := func(name string) {
+		row := &headerRow{name: widget.NewEntry(), value: widget.NewEntry()}
+		row.name.SetPlaceHolder(T("名称，例如 Cache-Control"))
+		row.name.SetText(name)
+		row.value.SetPlaceHolder(T("值"))
+		rows = append(rows, row)
+
+		var rowContainer *fyne.Container
+		removeButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			for i, r := range rows {
+				if r == row {
+					rows = append(rows[:i], rows[i+1:]...)
+					break
+				}
+			}
+			rowsBox.Remove(rowContainer)
+		})
+		rowContainer = container.NewBorder(nil, nil, nil, removeButton, container.NewGridWithColumns(2, row.name, row.value))
+		rowsBox.Add(rowContainer)
+	}
+	addRow("Content-Type")
+
+	addButton := widget.NewButtonWithIcon(T("添加请求头"), theme.ContentAddIcon(), func() { addRow("") })
+	hint := widget.NewLabel(T("留空的 Content-Type 会根据文件扩展名自动推断。自定义元数据请使用 x-amz-meta- 前缀。"))
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(rowsBox, container.NewHBox(addButton, layout.NewSpacer()), hint)
+	return content, func() []uploadHeader {
+		headers := make([]uploadHeader, len(rows))
+		for i, r := range rows {
+			headers[i] = uploadHeader{name: r.name.Text, value: r.value.Text}
+		}
+		return headers
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+func TestParseUploadHeaders(t *testing.T) {
+	opts, err := parseUploadHeaders([]uploadHeader{
+		{"Content-Type", " text/html; charset=utf-8 "},
+		{"cache-control", "max-age=3600"},
+		{"Content-Disposition", "attachment"},
+		{"X-Amz-Meta-Owner", "ops"},
+		{"", "忽略名称为空的行"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := s3client.UploadOptions{
+		ContentType:        "text/html; charset=utf-8",
+		CacheControl:       "max-age=3600",
+		ContentDisposition: "attachment",
+		Metadata:           map[string]string{"owner": "ops"},
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("parseUploadHeaders = %+v, 期望 %+v", opts, want)
+	}
+
+	for _, name := range []string{"Authorization", "x-amz-meta-", "x-amz-meta-a b"} {
+		if _, err := parseUploadHeaders([]uploadHeader{{name, "v"}}); err == nil {
+			t.Errorf("请求头 %q 应返回错误", name)
+		}
+	}
+}
+
+func TestUploadStorageClassOption(t *testing.T) {
+	tests := map[string]string{
+		"":            "",
+		"STANDARD":    "",
+		"STANDARD_IA": "STANDARD_IA",
+		"GLACIER":     "GLACIER",
+	}
+	for class, want := range tests {
+		if got := uploadStorageClassOption(class); got != want {
+			t.Errorf("uploadStorageClassOption(%q) = %q, 期望 %q", class, got, want)
+		}
+	}
+}
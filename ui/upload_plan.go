@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"s3-explorer/common"
+	"s3-explorer/s3client"
+)
+
+// uploadFile 是一个待上传的本地文件
+type uploadFile struct {
+	LocalPath string
+	S3Key     string
+	Size      int64
+}
+
+// uploadPlan 是扫描待上传项目得到的上传计划。空文件同样会被上传为 0 字节对象。
+type uploadPlan struct {
+	files      []uploadFile
+	folders    []string // 需要创建的文件夹 S3 key，以 / 结尾
+	totalSize  int64
+	skipped    int      // 内容与已有对象相同而跳过的文件数量
+	overwrites []string // 内容已更改、上传时将被覆盖的已有对象
+
+	options s3client.UploadOptions // 上传每个文件时附加的请求头和元数据
+}
+
+// uploadNaming 控制直接选择的文件的命名，文件夹内的文件保持原有结构
+type uploadNaming struct {
+	timestamp     bool      // 是否在文件名中追加时间戳
+	template      string    // 时间戳命名模板
+	time          time.Time // 本次上传的时间
+	skipUnchanged bool      // 上传到原有名称，跳过内容与已有对象相同的文件，覆盖内容已更改的对象
+}
+
+// buildUploadPlan 扫描本地文件和文件夹，生成上传到 targetPrefix 下的上传计划。
+// 与已有对象或文件夹同名时使用带递增数字的新名称；开启 skipUnchanged 时保持原有名称，
+// 跳过内容相同的文件，内容不同的文件记录在 overwrites 中。
+func (ov *ObjectsView) buildUploadPlan(localPaths []string, targetPrefix string, naming uploadNaming) (uploadPlan, []error) {
+	var plan uploadPlan
+	var scanErrors []error
+	var scanWg sync.WaitGroup
+	var scanMu sync.Mutex
+
+	for _, localPath := range localPaths {
+		scanWg.Add(1)
+		go func(path string) {
+			defer scanWg.Done()
+			info, err := os.Stat(path)
+			if err != nil {
+				scanMu.Lock()
+				scanErrors = append(scanErrors, fmt.Errorf("无法获取项目信息 '%s': %w", filepath.Base(path), err))
+				scanMu.Unlock()
+				return
+			}
+
+			if info.IsDir() {
+				baseFolderName := filepath.Base(path)
+
+				availableFolderName := baseFolderName
+				if !naming.skipUnchanged {
+					availableFolderName, err = ov.findAvailableFolderNameIn(targetPrefix, baseFolderName)
+					if err != nil {
+						scanMu.Lock()
+						scanErrors = append(scanErrors, fmt.Errorf("查找可用文件夹名称失败 '%s': %w", baseFolderName, err))
+						scanMu.Unlock()
+						return
+					}
+				}
+
+				err = filepath.Walk(path, func(p string, i os.FileInfo, err error) error {
+					if err != nil {
+						return err
+					}
+					relPath, err := filepath.Rel(path, p)
+					if err != nil {
+						return err
+					}
+					s3Key := filepath.Join(targetPrefix, availableFolderName, relPath)
+					s3Key = strings.ReplaceAll(s3Key, string(os.PathSeparator), "/")
+
+					if i.IsDir() {
+						if naming.skipUnchanged {
+							exists, err := ov.s3Client.ObjectExists(ov.currentBucket, s3Key+"/")
+							if err != nil || exists {
+								return err
+							}
+						}
+						scanMu.Lock()
+						plan.folders = append(plan.folders, s3Key+"/")
+						scanMu.Unlock()
+						return nil
+					}
+					return ov.addPlannedFile(&plan, &scanMu, uploadFile{LocalPath: p, S3Key: s3Key, Size: i.Size()}, naming.skipUnchanged)
+				})
+				if err != nil {
+					scanMu.Lock()
+					scanErrors = append(scanErrors, fmt.Errorf("遍历文件夹 '%s' 失败: %w", filepath.Base(path), err))
+					scanMu.Unlock()
+				}
+			} else {
+				fileName := filepath.Base(path)
+				if naming.timestamp {
+					fileName = common.ApplyTimestampTemplate(fileName, naming.template, naming.time)
+				}
+				s3Key := targetPrefix + fileName
+
+				if naming.skipUnchanged {
+					if err := ov.addPlannedFile(&plan, &scanMu, uploadFile{LocalPath: path, S3Key: s3Key, Size: info.Size()}, true); err != nil {
+						scanMu.Lock()
+						scanErrors = append(scanErrors, err)
+						scanMu.Unlock()
+					}
+					return
+				}
+
+				availableKey, err := ov.findAvailableObjectKey(s3Key)
+				if err != nil {
+					scanMu.Lock()
+					scanErrors = append(scanErrors, fmt.Errorf("查找可用对象key失败 '%s': %w", s3Key, err))
+					scanMu.Unlock()
+					return
+				}
+
+				ov.addPlannedFile(&plan, &scanMu, uploadFile{LocalPath: path, S3Key: availableKey, Size: info.Size()}, false)
+			}
+		}(localPath)
+	}
+	scanWg.Wait()
+	return plan, scanErrors
+}
+
+// addPlannedFile 将文件加入上传计划。compare 为 true 时先与目标 key 上的已有对象比较，内容相同则跳过。
+func (ov *ObjectsView) addPlannedFile(plan *uploadPlan, mu *sync.Mutex, file uploadFile, compare bool) error {
+	target := uploadTargetMissing
+	if compare {
+		var err error
+		target, err = ov.compareUploadTarget(file.S3Key, file.LocalPath, file.Size)
+		if err != nil {
+			return err
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	switch target {
+	case uploadTargetUnchanged:
+		plan.skipped++
+		return nil
+	case uploadTargetChanged:
+		plan.overwrites = append(plan.overwrites, file.S3Key)
+	}
+	plan.files = append(plan.files, file)
+	plan.totalSize += file.Size
+	return nil
+}
+
+// executeUploadPlan 并行创建计划中的文件夹并上传文件，返回上传完成的文件数和重试后仍然失败的项目。
+// 全部是空文件时总字节数为 0，此时按已完成的文件数更新进度。
+// ctx 被取消后不再开始新的项目，因取消而中止的项目既不计为完成也不计为失败。
+func (t transferTarget) executeUploadPlan(ctx context.Context, plan uploadPlan, progress *transferProgress, retries int) (int, []transferFailure) {
+	var uploadWg sync.WaitGroup
+	var uploadMu sync.Mutex
+	var failedUploads []transferFailure
+	completed := 0
+	numWorkers := transferWorkers
+
+	// 1. 并行创建所有文件夹
+	if len(plan.folders) > 0 {
+		folderChannel := make(chan string, len(plan.folders))
+		for i := 0; i < numWorkers; i++ {
+			uploadWg.Add(1)
+			go func() {
+				defer uploadWg.Done()
+				for s3Key := range folderChannel {
+					if ctx.Err() != nil {
+						continue
+					}
+					attemptErrors, err := transferWithRetry(nil, retries, s3Key, func(*transferAttempt) error {
+						return t.client.CreateFolder(t.bucket, s3Key)
+					})
+					if err != nil {
+						log.Printf("创建文件夹 %s 失败: %v", s3Key, err)
+						uploadMu.Lock()
+						failedUploads = append(failedUploads, transferFailure{name: s3Key, errors: attemptErrors})
+						uploadMu.Unlock()
+					}
+				}
+			}()
+		}
+		for _, key := range plan.folders {
+			folderChannel <- key
+		}
+		close(folderChannel)
+		uploadWg.Wait() // 等待文件夹创建完成后再上传文件
+	}
+
+	// 2. 并行上传所有文件
+	if len(plan.files) > 0 {
+		fileChannel := make(chan uploadFile, len(plan.files))
+		filesDone := 0
+
+		for i := 0; i < numWorkers; i++ {
+			uploadWg.Add(1)
+			go func() {
+				defer uploadWg.Done()
+				for fileInfo := range fileChannel {
+					if ctx.Err() != nil {
+						continue // 已取消，丢弃剩余文件
+					}
+					attemptErrors, err := transferWithRetry(progress, retries, fileInfo.LocalPath, func(attempt *transferAttempt) error {
+						return t.uploadSingleFile(ctx, fileInfo.LocalPath, fileInfo.S3Key, fileInfo.Size, plan.options, attempt)
+					})
+					uploadMu.Lock()
+					if err != nil && ctx.Err() == nil {
+						failedUploads = append(failedUploads, transferFailure{name: filepath.Base(fileInfo.LocalPath), errors: attemptErrors})
+						log.Printf("上传文件 %s 失败: %v", fileInfo.LocalPath, err)
+					} else if err == nil {
+						completed++
+					}
+					filesDone++
+					value := float64(filesDone) / float64(len(plan.files))
+					uploadMu.Unlock()
+					if progress.total == 0 && progress.dialog != nil {
+						fyne.Do(func() { progress.dialog.SetValue(value) })
+					}
+				}
+			}()
+		}
+		for _, f := range plan.files {
+			fileChannel <- f
+		}
+		close(fileChannel)
+		uploadWg.Wait()
+	}
+	return completed, failedUploads
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"s3-explorer/s3client"
+)
+
+// 只包含空文件的上传集合应上传为 0 字节对象，而不是被跳过
+func TestUploadPlanWithOnlyEmptyFiles(t *testing.T) {
+	dir := t.TempDir()
+	emptyFile := filepath.Join(dir, "empty.txt")
+	folder := filepath.Join(dir, "blank")
+	for _, path := range []string{emptyFile, filepath.Join(folder, "a.txt"), filepath.Join(folder, "sub", "b.txt")} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newMemStore()
+	ov := newTestObjectsView(store, "dst/")
+	plan, scanErrors := ov.buildUploadPlan([]string{emptyFile, folder}, "dst/", uploadNaming{})
+	if len(scanErrors) > 0 {
+		t.Fatalf("扫描失败: %v", scanErrors)
+	}
+	if len(plan.files) != 3 || plan.totalSize != 0 {
+		t.Fatalf("期望 3 个空文件、总大小 0，实际 %d 个文件、总大小 %d", len(plan.files), plan.totalSize)
+	}
+
+	var done int64
+	_, failures := ov.currentTransferTarget().executeUploadPlan(context.Background(), plan, &transferProgress{done: &done}, 0)
+	if len(failures) > 0 {
+		t.Fatalf("上传失败: %v", failures)
+	}
+
+	want := []string{"dst/blank/", "dst/blank/a.txt", "dst/blank/sub/", "dst/blank/sub/b.txt", "dst/empty.txt"}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, want) {
+		t.Errorf("上传后的对象 = %v, 期望 %v", got, want)
+	}
+	for _, key := range want {
+		if data := store.buckets[testBucket][key]; len(data) != 0 {
+			t.Errorf("对象 %s 应为 0 字节，实际 %d 字节", key, len(data))
+		}
+	}
+}
+
+// 开启跳过未更改的文件时，重新上传文件夹只上传内容有变化的文件，并且保持原有名称
+func TestUploadPlanSkipsUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	folder := filepath.Join(dir, "site")
+	files := map[string]string{"index.html": "<html>", "app.js": "v1", "new.css": "body{}"}
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(folder, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newMemStore()
+	store.put(testBucket, "dst/site/", "")
+	store.put(testBucket, "dst/site/index.html", "<html>")
+	store.put(testBucket, "dst/site/app.js", "v0")
+	ov := newTestObjectsView(store, "dst/")
+
+	plan, scanErrors := ov.buildUploadPlan([]string{folder}, "dst/", uploadNaming{skipUnchanged: true})
+	if len(scanErrors) > 0 {
+		t.Fatalf("扫描失败: %v", scanErrors)
+	}
+	if plan.skipped != 1 {
+		t.Errorf("skipped = %d, 期望 1", plan.skipped)
+	}
+	if !reflect.DeepEqual(plan.overwrites, []string{"dst/site/app.js"}) {
+		t.Errorf("overwrites = %v, 期望 [dst/site/app.js]", plan.overwrites)
+	}
+	if len(plan.folders) != 0 {
+		t.Errorf("已存在的文件夹不应重新创建，实际 %v", plan.folders)
+	}
+
+	var done int64
+	if _, failures := ov.currentTransferTarget().executeUploadPlan(context.Background(), plan, &transferProgress{done: &done}, 0); len(failures) > 0 {
+		t.Fatalf("上传失败: %v", failures)
+	}
+	want := []string{"dst/site/", "dst/site/app.js", "dst/site/index.html", "dst/site/new.css"}
+	if got := store.keys(testBucket); !reflect.DeepEqual(got, want) {
+		t.Errorf("上传后的对象 = %v, 期望 %v", got, want)
+	}
+	if got := string(store.buckets[testBucket]["dst/site/app.js"]); got != "v1" {
+		t.Errorf("app.js 应被覆盖为新内容，实际为 %q", got)
+	}
+}
+
+// 上传计划中的请求头和元数据应附加到每个上传的文件
+func TestUploadPlanAppliesUploadOptions(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.html", "b.html"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newMemStore()
+	ov := newTestObjectsView(store, "")
+	plan, scanErrors := ov.buildUploadPlan([]string{dir}, "", uploadNaming{})
+	if len(scanErrors) > 0 {
+		t.Fatalf("扫描失败: %v", scanErrors)
+	}
+	plan.options = s3client.UploadOptions{CacheControl: "no-cache", Metadata: map[string]string{"owner": "ops"}}
+	var done int64
+	if _, failures := ov.currentTransferTarget().executeUploadPlan(context.Background(), plan, &transferProgress{total: plan.totalSize, done: &done}, 0); len(failures) > 0 {
+		t.Fatalf("上传失败: %v", failures)
+	}
+	// 未指定 Content-Type 时按文件扩展名推断
+	want := plan.options
+	want.ContentType = "text/html; charset=utf-8"
+	base := filepath.Base(dir)
+	for _, key := range []string{base + "/a.html", base + "/b.html"} {
+		if got := store.uploadOptions[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s 的上传选项 = %+v, 期望 %+v", key, got, want)
+		}
+	}
+}
+
+// 取消后不再上传剩余文件，未上传的文件既不计为完成也不计为失败
+func TestUploadPlanStopsWhenCancelled(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newMemStore()
+	ov := newTestObjectsView(store, "")
+	plan, scanErrors := ov.buildUploadPlan([]string{dir}, "", uploadNaming{})
+	if len(scanErrors) > 0 {
+		t.Fatalf("扫描失败: %v", scanErrors)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var done int64
+	completed, failures := ov.currentTransferTarget().executeUploadPlan(ctx, plan, &transferProgress{total: plan.totalSize, done: &done}, 0)
+	if completed != 0 || len(failures) > 0 {
+		t.Errorf("取消后完成 %d 个文件、失败 %v, 期望都为空", completed, failures)
+	}
+	if got := store.keys(testBucket); len(got) > 0 {
+		t.Errorf("取消后不应上传任何对象，实际为 %v", got)
+	}
+}
+
+// 传输并发数设置为 1 时所有文件仍然依次上传完成
+func TestUploadPlanWithSingleTransferWorker(t *testing.T) {
+	saved := transferWorkers
+	transferWorkers = 1
+	defer func() { transferWorkers = saved }()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := newMemStore()
+	ov := newTestObjectsView(store, "")
+	plan, scanErrors := ov.buildUploadPlan([]string{dir}, "", uploadNaming{})
+	if len(scanErrors) > 0 {
+		t.Fatalf("扫描失败: %v", scanErrors)
+	}
+	var done int64
+	completed, failures := ov.currentTransferTarget().executeUploadPlan(context.Background(), plan, &transferProgress{total: plan.totalSize, done: &done}, 0)
+	if len(failures) > 0 || completed != 3 {
+		t.Fatalf("期望上传 3 个文件且没有失败，实际完成 %d 个，失败 %v", completed, failures)
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"s3-explorer/s3client"
+)
+
+// uploadTarget 描述上传目标 key 上已有对象的情况
+type uploadTarget int
+
+const (
+	uploadTargetMissing   uploadTarget = iota // 目标不存在
+	uploadTargetUnchanged                     // 已有对象与本地文件内容相同，可以跳过
+	uploadTargetChanged                       // 已有对象与本地文件不同，上传将覆盖它
+)
+
+// compareUploadTarget 比较本地文件与目标 key 上的已有对象。先比较大小，大小相同时比较本地文件的 MD5 与对象的 ETag。
+// 分段上传的对象 ETag 不是内容的 MD5，无法比较，按内容不同处理。
+func (ov *ObjectsView) compareUploadTarget(key, localPath string, size int64) (uploadTarget, error) {
+	exists, err := ov.s3Client.ObjectExists(ov.currentBucket, key)
+	if err != nil {
+		return uploadTargetMissing, fmt.Errorf("检查对象 '%s' 是否存在时出错: %w", key, err)
+	}
+	if !exists {
+		return uploadTargetMissing, nil
+	}
+	info, err := ov.s3Client.StatObject(ov.currentBucket, key)
+	if err != nil {
+		return uploadTargetMissing, err
+	}
+	if info.Size != size || info.ETag == "" || s3client.IsMultipartETag(info.ETag) {
+		return uploadTargetChanged, nil
+	}
+	sum, err := fileMD5(localPath)
+	if err != nil {
+		return uploadTargetMissing, err
+	}
+	if strings.EqualFold(sum, info.ETag) {
+		return uploadTargetUnchanged, nil
+	}
+	return uploadTargetChanged, nil
+}
+
+// fileMD5 计算本地文件内容的 MD5，返回十六进制字符串
+func fileMD5(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("无法打开文件 '%s': %w", path, err)
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("读取文件 '%s' 失败: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// confirmUploadOverwrites 在上传会覆盖内容已更改的对象时请求确认，返回用户是否同意。
+// 关闭覆盖确认时直接返回 true。在后台 goroutine 中调用，会阻塞直到用户做出选择。
+func (ov *ObjectsView) confirmUploadOverwrites(keys []string) bool {
+	if len(keys) == 0 || !confirmOverwriteSetting() {
+		return true
+	}
+	return ov.confirmBlocking(T("确认覆盖"), fmt.Sprintf(T("%d 个文件的内容与已有对象不同，上传将覆盖这些对象，例如: %s\n\n是否继续上传？"), len(keys), keys[0]))
+}
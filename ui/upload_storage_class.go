@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"s3-explorer/s3client"
+)
+
+// SetUploadStorageClass 设置当前服务上传时使用的存储类别，为空时使用标准存储
+func (ov *ObjectsView) SetUploadStorageClass(storageClass string) {
+	ov.uploadStorageClass = storageClass
+}
+
+// uploadStorageClassOption 返回上传选项中的存储类别。标准存储不发送存储类别请求头，
+// 这样不认识该请求头的 S3 兼容服务也能正常上传；其它类别原样发送，服务端不支持时返回错误。
+func uploadStorageClassOption(storageClass string) string {
+	if storageClass == s3client.StorageClasses[0] {
+		return ""
+	}
+	return storageClass
+}
+
+// newUploadStorageClassOption 创建上传对话框中的存储类别选择框，修改会保存到当前服务的配置
+func (ov *ObjectsView) newUploadStorageClassOption() fyne.CanvasObject {
+	selectWidget := widget.NewSelect(s3client.StorageClasses, func(selected string) {
+		if selected == ov.uploadStorageClass {
+			return
+		}
+		ov.uploadStorageClass = selected
+		if ov.OnUploadStorageClassChanged != nil && ov.currentServiceAlias != "" {
+			go ov.OnUploadStorageClassChanged(ov.currentServiceAlias, selected)
+		}
+	})
+	current := ov.uploadStorageClass
+	if current == "" {
+		current = s3client.StorageClasses[0]
+	}
+	selectWidget.SetSelected(current)
+	return container.NewBorder(nil, nil, widget.NewLabel(T("存储类别")), nil, selectWidget)
+}
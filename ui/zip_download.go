@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"s3-explorer/s3client"
+)
+
+// zipEntryName 返回对象在压缩包中的路径，即相对于 folderKey 的路径。文件夹和文件夹占位对象返回空字符串，不写入压缩包。
+func zipEntryName(folderKey string, obj s3client.S3Object) string {
+	if obj.IsFolder || strings.HasSuffix(obj.Key, "/") {
+		return ""
+	}
+	return strings.TrimLeft(strings.TrimPrefix(obj.Key, folderKey), "/")
+}
+
+// startZipDownload 让用户选择保存位置，然后将文件夹下的所有文件打包下载为一个 zip 文件
+func (ov *ObjectsView) startZipDownload(folder s3client.S3Object) {
+	if ov.s3Client == nil || ov.currentBucket == "" {
+		return
+	}
+	client, bucket := ov.s3Client, ov.currentBucket
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, ov.window)
+			return
+		}
+		if writer == nil {
+			return // 用户取消
+		}
+		// 下载使用开始时的客户端和存储桶，不占用耗时操作
+		go ov.runZipDownload(client, bucket, folder, writer)
+	}, ov.window)
+	saveDialog.SetFileName(folder.Name + ".zip")
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+	saveDialog.Show()
+}
+
+// runZipDownload 列出文件夹下的所有文件并逐个写入 writer 对应的 zip 文件，在传输面板中显示进度。
+// 单个文件失败不会中止打包，结束后统一报告；取消或写入失败时删除未完成的文件。在后台 goroutine 中调用。
+func (ov *ObjectsView) runZipDownload(client ObjectStore, bucket string, folder s3client.S3Object, writer fyne.URIWriteCloser) {
+	remove := func() {
+		if err := storage.Delete(writer.URI()); err != nil {
+			log.Printf("删除未完成的压缩包失败: %v", err)
+		}
+	}
+	discard := func() {
+		writer.Close()
+		remove()
+	}
+
+	var scanDialog dialog.Dialog
+	fyne.Do(func() {
+		scanDialog = dialog.NewProgressInfinite(T("下载为 ZIP"), T("正在扫描待下载项目..."), ov.window)
+		scanDialog.Show()
+	})
+	objects, err := client.ListAllObjectsFlat(bucket, folder.Key)
+	fyne.Do(func() { scanDialog.Hide() })
+	if err != nil {
+		discard()
+		fyne.Do(func() {
+			dialog.ShowError(fmt.Errorf(T("扫描部分项目失败: %s"), err.Error()), ov.window)
+		})
+		return
+	}
+
+	var totalSize int64
+	files := 0
+	for _, obj := range objects {
+		if zipEntryName(folder.Key, obj) != "" {
+			totalSize += obj.Size
+			files++
+		}
+	}
+	if files == 0 {
+		discard()
+		fyne.Do(func() {
+			ShowToast(ov.window, T("没有可下载的项目。"))
+		})
+		return
+	}
+
+	var task *transferTask
+	fyne.DoAndWait(func() {
+		task = ov.transfers.add(fmt.Sprintf(T("下载 %s 为 ZIP"), folder.Name), theme.DownloadIcon())
+	})
+	var bytesDone int64
+	progress := &transferProgress{total: totalSize, done: &bytesDone, dialog: task}
+	progress.rate = newTransferRate(totalSize, task.SetStatus)
+
+	zw := zip.NewWriter(writer)
+	completed, failures, err := writeZipEntries(task.ctx, client, bucket, folder.Key, objects, zw, progress)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		remove()
+	}
+
+	fyne.Do(func() {
+		switch {
+		case task.cancelled() || errors.Is(err, context.Canceled):
+			task.finish(transferCancelled, fmt.Sprintf(T("已取消下载，已完成 %d / %d 个文件。"), completed, files), failures)
+		case err != nil:
+			task.finish(transferFailed, fmt.Sprintf(T("下载为 ZIP 失败: %v"), err), failures)
+		case len(failures) > 0:
+			task.finish(transferFailed, T("部分项目下载失败"), failures)
+		default:
+			task.finish(transferSucceeded, fmt.Sprintf(T("已将 %d 个文件打包到 %s"), completed, writer.URI().Path()), nil)
+		}
+	})
+}
+
+// writeZipEntries 依次下载 objects 中的文件并写入 zw，返回写入完成的文件数和下载失败的文件。
+// 每个文件先完整下载到临时文件，成功后才写入压缩包，因此下载中途失败的文件不会以截断的内容出现在压缩包中。
+// 单个文件下载失败时记录并继续；写入压缩包失败（如磁盘已满）或 ctx 被取消时中止并返回错误。
+func writeZipEntries(ctx context.Context, client ObjectStore, bucket, folderKey string, objects []s3client.S3Object, zw *zip.Writer, progress *transferProgress) (int, []transferFailure, error) {
+	completed := 0
+	var failures []transferFailure
+	for _, obj := range objects {
+		name := zipEntryName(folderKey, obj)
+		if name == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return completed, failures, err
+		}
+
+		tmp, err := spoolObject(ctx, client, bucket, obj.Key, progress)
+		if err != nil {
+			if ctx.Err() != nil {
+				return completed, failures, ctx.Err()
+			}
+			var pathErr *fs.PathError
+			if errors.As(err, &pathErr) {
+				return completed, failures, fmt.Errorf("写入临时文件失败: %w", err)
+			}
+			log.Printf("下载文件 '%s' 失败: %v", obj.Key, err)
+			failures = append(failures, transferFailure{name: name, errors: []error{err}})
+			continue
+		}
+		err = addZipEntry(zw, name, obj, tmp)
+		tmp.Close()
+		os.Remove(tmp.Name())
+		if err != nil {
+			return completed, failures, fmt.Errorf("写入压缩包失败: %w", err)
+		}
+		completed++
+	}
+	return completed, failures, nil
+}
+
+// spoolObject 将对象完整下载到临时文件并返回定位到开头的文件。下载失败时删除临时文件并撤销计入总进度的字节数。
+func spoolObject(ctx context.Context, client ObjectStore, bucket, key string, progress *transferProgress) (*os.File, error) {
+	body, err := client.DownloadObject(ctx, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp("", "s3-explorer-zip-*")
+	if err != nil {
+		return nil, err
+	}
+	attempt := &transferAttempt{progress: progress}
+	_, err = io.Copy(tmp, attempt.track(body))
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		attempt.rollback()
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return tmp, nil
+}
+
+// addZipEntry 将已下载完成的文件 r 写入压缩包中名为 name 的条目
+func addZipEntry(zw *zip.Writer, name string, obj s3client.S3Object, r io.Reader) error {
+	entry, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: obj.ModifiedTime})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, r)
+	return err
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"s3-explorer/s3client"
+)
+
+func TestZipEntryName(t *testing.T) {
+	tests := []struct {
+		obj  s3client.S3Object
+		want string
+	}{
+		{s3client.S3Object{Key: "docs/a.txt"}, "a.txt"},
+		{s3client.S3Object{Key: "docs/sub/b.txt"}, "sub/b.txt"},
+		{s3client.S3Object{Key: "docs/sub/"}, ""},
+		{s3client.S3Object{Key: "docs/sub/", IsFolder: true}, ""},
+	}
+	for _, tt := range tests {
+		if got := zipEntryName("docs/", tt.obj); got != tt.want {
+			t.Errorf("zipEntryName(%q) = %q, 期望 %q", tt.obj.Key, got, tt.want)
+		}
+	}
+}
+
+func TestWriteZipEntries(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/a.txt", "hello")
+	store.put(testBucket, "docs/sub/", "")
+	store.put(testBucket, "docs/sub/b.txt", "world")
+	objects, _ := store.ListAllObjectsFlat(testBucket, "docs/")
+	// 列举后被删除的对象下载失败，不应中止整个压缩包
+	objects = append(objects, s3client.S3Object{Key: "docs/missing.txt", Size: 3})
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	var done int64
+	progress := &transferProgress{total: 13, done: &done}
+	completed, failures, err := writeZipEntries(context.Background(), store, testBucket, "docs/", objects, zw, progress)
+	if err != nil {
+		t.Fatalf("writeZipEntries() 返回错误: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if completed != 2 || len(failures) != 1 || failures[0].name != "missing.txt" {
+		t.Errorf("完成 %d 个，失败 %+v，期望完成 2 个、missing.txt 失败", completed, failures)
+	}
+	if done != 10 {
+		t.Errorf("进度 = %d 字节, 期望 10", done)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("无法读取生成的压缩包: %v", err)
+	}
+	got := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		got[f.Name] = string(data)
+	}
+	if len(got) != 2 || got["a.txt"] != "hello" || got["sub/b.txt"] != "world" {
+		t.Errorf("压缩包内容 = %v", got)
+	}
+}
+
+func TestWriteZipEntriesCancelled(t *testing.T) {
+	store := newMemStore()
+	store.put(testBucket, "docs/a.txt", "hello")
+	objects, _ := store.ListAllObjectsFlat(testBucket, "docs/")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var done int64
+	zw := zip.NewWriter(io.Discard)
+	_, failures, err := writeZipEntries(ctx, store, testBucket, "docs/", objects, zw, &transferProgress{done: &done})
+	if err != context.Canceled || len(failures) != 0 {
+		t.Errorf("取消后 writeZipEntries() = %v, %v, 期望 context.Canceled 且没有失败", failures, err)
+	}
+}
+
+// failingBodyStore 的 DownloadObject 对 failKey 返回在读取一部分内容后出错的数据流，模拟下载中途断开
+type failingBodyStore struct {
+	*memStore
+	failKey string
+}
+
+func (s *failingBodyStore) DownloadObject(ctx context.Context, bucketName, key string) (io.ReadCloser, error) {
+	body, err := s.memStore.DownloadObject(ctx, bucketName, key)
+	if err != nil || key != s.failKey {
+		return body, err
+	}
+	return io.NopCloser(io.MultiReader(io.LimitReader(body, 2), iotest.ErrReader(io.ErrUnexpectedEOF))), nil
+}
+
+// 下载中途失败的文件不应以截断的内容写入压缩包
+func TestWriteZipEntriesSkipsTruncatedFiles(t *testing.T) {
+	store := &failingBodyStore{memStore: newMemStore(), failKey: "docs/broken.txt"}
+	store.put(testBucket, "docs/a.txt", "hello")
+	store.put(testBucket, "docs/broken.txt", "truncated")
+	objects, _ := store.ListAllObjectsFlat(testBucket, "docs/")
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	var done int64
+	completed, failures, err := writeZipEntries(context.Background(), store, testBucket, "docs/", objects, zw, &transferProgress{total: 14, done: &done})
+	if err != nil {
+		t.Fatalf("writeZipEntries() 返回错误: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if completed != 1 || len(failures) != 1 || failures[0].name != "broken.txt" {
+		t.Errorf("完成 %d 个，失败 %+v，期望完成 1 个、broken.txt 失败", completed, failures)
+	}
+	if done != 5 {
+		t.Errorf("进度 = %d 字节, 期望 5（失败文件的进度应被撤销）", done)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("无法读取生成的压缩包: %v", err)
+	}
+	if len(zr.File) != 1 || zr.File[0].Name != "a.txt" {
+		names := make([]string, len(zr.File))
+		for i, f := range zr.File {
+			names[i] = f.Name
+		}
+		t.Errorf("压缩包中的文件 = %v, 期望只有 a.txt", names)
+	}
+}